@@ -7,5 +7,8 @@ type Config struct {
 		CliEndpoint  string `yaml:"cli_endpoint"`
 	} `yaml:"sdk"`
 	App struct {
+		Keymap map[string]string            `yaml:"keymap"`
+		Theme  string                       `yaml:"theme"`
+		Themes map[string]map[string]string `yaml:"themes"`
 	} `yaml:"app"`
 }
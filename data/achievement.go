@@ -0,0 +1,97 @@
+package loud
+
+import (
+	"time"
+)
+
+// AchievementStats is the user state which achievements are evaluated on
+type AchievementStats struct {
+	Items             map[string]int // item count by name
+	MaxLevel          int
+	GiantKill         int
+	SpecialDragonKill int
+	UndeadDragonKill  int
+	HasSpecial        bool
+	RabbitsHunted     int // rabbit hunts won, from battle history
+	Wins              int
+}
+
+// Achievement is unlocked once when Check passes after a sync or a battle
+type Achievement struct {
+	ID    string
+	Title string
+	Desc  string
+	Check func(AchievementStats) bool
+}
+
+func hasItems(names ...string) func(AchievementStats) bool {
+	return func(stats AchievementStats) bool {
+		for _, name := range names {
+			if stats.Items[name] == 0 {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Achievements is all achievements in the order they are shown
+var Achievements = []Achievement{
+	{"first_win", "First blood", "Win a hunt or a fight", func(s AchievementStats) bool { return s.Wins > 0 }},
+	{"goblin_ear", "Goblin slayer", "Get a goblin ear", hasItems(GOBLIN_EAR)},
+	{"wolf_tail", "Wolf hunter", "Get a wolf tail", hasItems(WOLF_TAIL)},
+	{"troll_toes", "Troll hunter", "Get troll toes", hasItems(TROLL_TOES)},
+	{"giant_kill", "Giant killer", "Defeat a giant", func(s AchievementStats) bool { return s.GiantKill > 0 }},
+	{"special", "Gifted", "Get a bonus skill on a character", func(s AchievementStats) bool { return s.HasSpecial }},
+	{"special_dragon_kill", "Dragon slayer", "Defeat a fire, ice or acid dragon", func(s AchievementStats) bool { return s.SpecialDragonKill > 0 }},
+	{"dragon_drops", "Dragon hoard", "Hold drops of all three special dragons", hasItems(DROP_DRAGONFIRE, DROP_DRAGONICE, DROP_DRAGONACID)},
+	{"angel_sword", "Heavenly smith", "Forge an angel sword", hasItems(ANGEL_SWORD)},
+	{"undead_dragon_kill", "Legend of Undead Dragon", "Defeat the undead dragon", func(s AchievementStats) bool { return s.UndeadDragonKill > 0 }},
+	{"level_10", "Veteran", "Raise a character to level 10", func(s AchievementStats) bool { return s.MaxLevel >= 10 }},
+	{"rabbits_100", "Rabbit plague", "Hunt rabbits 100 times", func(s AchievementStats) bool { return s.RabbitsHunted >= 100 }},
+}
+
+// CollectAchievementStats reads inventory, characters and battle history of the user
+func CollectAchievementStats(user User) AchievementStats {
+	stats := AchievementStats{Items: map[string]int{}}
+	for _, item := range user.InventoryItems() {
+		stats.Items[item.Name]++
+	}
+	for _, ch := range user.InventoryCharacters() {
+		if ch.Level > stats.MaxLevel {
+			stats.MaxLevel = ch.Level
+		}
+		stats.GiantKill += ch.GiantKill
+		stats.SpecialDragonKill += ch.SpecialDragonKill
+		stats.UndeadDragonKill += ch.UndeadDragonKill
+		if ch.Special != NO_SPECIAL {
+			stats.HasSpecial = true
+		}
+	}
+	for _, report := range user.GetBattleHistory() {
+		if report.CharacterLost {
+			continue
+		}
+		stats.Wins++
+		switch BaseHuntRecipe(report.Action) {
+		case RCP_HUNT_RABBITS_NOSWORD, RCP_HUNT_RABBITS_YESWORD:
+			stats.RabbitsHunted++
+		}
+	}
+	return stats
+}
+
+// UpdateAchievements unlocks achievements passing their checks and returns the newly unlocked ones
+func UpdateAchievements(user User) []Achievement {
+	stats := CollectAchievementStats(user)
+	unlocked := user.GetAchievements()
+	newAchievements := []Achievement{}
+	for _, achievement := range Achievements {
+		if _, ok := unlocked[achievement.ID]; ok || !achievement.Check(stats) {
+			continue
+		}
+		user.UnlockAchievement(achievement.ID, time.Now().Unix())
+		newAchievements = append(newAchievements, achievement)
+	}
+	return newAchievements
+}
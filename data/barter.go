@@ -0,0 +1,97 @@
+package loud
+
+import (
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// BarterTrdReqFromTrade parses all coin and item inputs and outputs of a barter trade
+func BarterTrdReqFromTrade(trade types.Trade) BarterTrdReq {
+	request := BarterTrdReq{
+		ID:         trade.ID,
+		OfferItems: []Item{},
+		WantItems:  []ItemSpec{},
+		ItemInputs: trade.ItemInputs,
+		OfferPylon: int(trade.CoinOutputs.AmountOf("pylon").Int64()),
+		OfferGold:  int(trade.CoinOutputs.AmountOf("loudcoin").Int64()),
+	}
+	for _, coinInput := range trade.CoinInputs {
+		switch coinInput.Coin {
+		case "pylon":
+			request.WantPylon += int(coinInput.Count)
+		case "loudcoin":
+			request.WantGold += int(coinInput.Count)
+		}
+	}
+	for _, itemInput := range trade.ItemInputs {
+		itspec := ItemSpec{}
+		for _, param := range itemInput.Strings {
+			if param.Key == "Name" {
+				itspec.Name = param.Value
+			}
+		}
+		for _, param := range itemInput.Longs {
+			if param.Key == "level" {
+				itspec.Level = [2]int{param.MinValue, param.MaxValue}
+			}
+		}
+		request.WantItems = append(request.WantItems, itspec)
+	}
+	for _, itemOutput := range trade.ItemOutputs {
+		level, _ := itemOutput.FindLong("level")
+		name, _ := itemOutput.FindString("Name")
+		attack, _ := itemOutput.FindDouble("attack")
+		request.OfferItems = append(request.OfferItems, Item{
+			ID:     itemOutput.ID,
+			Name:   name,
+			Level:  level,
+			Attack: int(attack),
+		})
+	}
+	return request
+}
+
+// MatchBarterItemIDs picks one inventory item for each item input of a barter request, lowest value first.
+// It returns false if any of item inputs has no matching item.
+func MatchBarterItemIDs(user User, request BarterTrdReq) ([]string, bool) {
+	itemIDs := []string{}
+	usedItemIDs := make(map[string]bool)
+	for _, ii := range request.ItemInputs {
+		found := false
+		for _, item := range MatchItemCandidates(user, ii) {
+			if usedItemIDs[item.ID] {
+				continue
+			}
+			usedItemIDs[item.ID] = true
+			itemIDs = append(itemIDs, item.ID)
+			found = true
+			break
+		}
+		if !found {
+			return itemIDs, false
+		}
+	}
+	return itemIDs, true
+}
+
+// BarterOfferItems returns inventory items which can be offered on a barter request, active weapon and armor
+// are left out so that they aren't given away silently when the request is fulfilled
+func BarterOfferItems(user User) []Item {
+	activeWeapon, activeArmor := user.GetActiveWeapon(), user.GetActiveArmor()
+	items := []Item{}
+	for _, item := range user.InventoryItems() {
+		if (activeWeapon != nil && activeWeapon.ID == item.ID) || (activeArmor != nil && activeArmor.ID == item.ID) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// CanFulfillBarterTrdReq checks if user has all wanted items and coins of a barter request
+func CanFulfillBarterTrdReq(user User, request BarterTrdReq) bool {
+	if request.WantPylon > user.GetPylonAmount() || request.WantGold > user.GetGold() {
+		return false
+	}
+	_, ok := MatchBarterItemIDs(user, request)
+	return ok
+}
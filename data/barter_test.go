@@ -0,0 +1,40 @@
+package loud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBarterOfferItems(t *testing.T) {
+	items := []Item{
+		{ID: "s0", Name: "Wooden sword"},
+		{ID: "a0", Name: "Iron armor", Type: ARMOR_TYPE},
+		{ID: "s1", Name: "Copper sword"},
+		{ID: "e0", Name: "Goblin ear"},
+	}
+	tests := []struct {
+		name   string
+		weapon string
+		armor  string
+		want   []string
+	}{
+		{"first sword and armor are active by default", "", "", []string{"s1", "e0"}},
+		{"selected weapon is left out", "s1", NO_ACTIVE_ID, []string{"s0", "a0", "e0"}},
+		{"nothing active", NO_ACTIVE_ID, NO_ACTIVE_ID, []string{"s0", "a0", "s1", "e0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &dbUser{
+				UserData: UserData{Items: items, ActiveWeaponID: tt.weapon, ActiveArmorID: tt.armor},
+				world:    &dbWorld{},
+			}
+			ids := []string{}
+			for _, item := range BarterOfferItems(user) {
+				ids = append(ids, item.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("offer items = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
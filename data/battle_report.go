@@ -0,0 +1,186 @@
+package loud
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/handlers"
+)
+
+// BattleReport is the structured result of a hunt or fight recipe execution
+type BattleReport struct {
+	TxHash         string
+	Action         string // recipe name
+	Block          int64
+	Time           int64
+	Gold           int64
+	CharacterName  string
+	XPBefore       float64
+	XPAfter        float64
+	LevelBefore    int
+	LevelAfter     int
+	SpecialBefore  int
+	SpecialAfter   int
+	CharacterLost  bool
+	WeaponName     string
+	WeaponLevel    int
+	WeaponLost     bool
+	ArmorName      string
+	ArmorLost      bool
+	Consumable     string // consumable used for the battle
+	ConsumableUsed bool
+	Revived        bool
+	Drops          []string
+}
+
+// Loadout is the character and the equipment used for a battle
+type Loadout struct {
+	Character  *Character
+	Weapon     *Item
+	Armor      *Item
+	Consumable *Item
+}
+
+// ActiveLoadout copies active character and equipment of the user before a battle so that they can be compared after sync
+func ActiveLoadout(user User) Loadout {
+	loadout := Loadout{}
+	if ch := user.GetActiveCharacter(); ch != nil {
+		chCopy := *ch
+		loadout.Character = &chCopy
+	}
+	if w := user.GetActiveWeapon(); w != nil {
+		wCopy := *w
+		loadout.Weapon = &wCopy
+	}
+	if a := user.GetActiveArmor(); a != nil {
+		aCopy := *a
+		loadout.Armor = &aCopy
+	}
+	loadout.Consumable = user.GetActiveConsumableItem()
+	return loadout
+}
+
+func findCharacter(user User, id string) *Character {
+	for _, ch := range user.InventoryCharacters() {
+		if ch.ID == id {
+			return &ch
+		}
+	}
+	return nil
+}
+
+func findItem(user User, id string) *Item {
+	for _, item := range user.InventoryItems() {
+		if item.ID == id {
+			return &item
+		}
+	}
+	return nil
+}
+
+// ParseRecipeOutput reads the coins and item IDs from execute recipe output
+func ParseRecipeOutput(txResult []byte) (int64, []string) {
+	respOutput := []handlers.ExecuteRecipeSerialize{}
+	json.Unmarshal(txResult, &respOutput)
+	gold := int64(0)
+	itemIDs := []string{}
+	for _, out := range respOutput {
+		if out.Type == "COIN" && out.Coin == "loudcoin" {
+			gold += out.Amount
+		} else if len(out.ItemID) > 0 {
+			itemIDs = append(itemIDs, out.ItemID)
+		}
+	}
+	return gold, itemIDs
+}
+
+// BuildBattleReport builds battle report from execute recipe output after the user is synced
+// loadout should be the one used for the recipe, taken before the execution
+func BuildBattleReport(user User, txhash string, action string, loadout Loadout, txResult []byte) BattleReport {
+	report := BattleReport{
+		TxHash: txhash,
+		Action: action,
+		Block:  user.GetLatestBlockHeight(),
+		Time:   time.Now().Unix(),
+	}
+	gold, itemIDs := ParseRecipeOutput(txResult)
+	report.Gold = gold
+
+	character, weapon, armor, consumable := loadout.Character, loadout.Weapon, loadout.Armor, loadout.Consumable
+	if !IsArmorRecipe(action) {
+		armor = nil
+	}
+	characterKept := false
+	weaponKept := weapon == nil
+	armorKept := armor == nil
+	consumableKept := consumable == nil
+	for _, id := range itemIDs {
+		switch {
+		case character != nil && id == character.ID:
+			characterKept = true
+		case weapon != nil && id == weapon.ID:
+			weaponKept = true
+		case armor != nil && id == armor.ID:
+			armorKept = true
+		case consumable != nil && id == consumable.ID:
+			consumableKept = true
+		default:
+			if item := findItem(user, id); item != nil {
+				report.Drops = append(report.Drops, item.Name)
+			}
+		}
+	}
+
+	if character != nil {
+		report.CharacterName = character.Name
+		report.XPBefore = character.XP
+		report.XPAfter = character.XP
+		report.LevelBefore = character.Level
+		report.LevelAfter = character.Level
+		report.SpecialBefore = character.Special
+		report.SpecialAfter = character.Special
+		after := findCharacter(user, character.ID)
+		report.CharacterLost = !characterKept || after == nil
+		if after != nil {
+			report.XPAfter = after.XP
+			report.LevelAfter = after.Level
+			report.SpecialAfter = after.Special
+		}
+	}
+	if weapon != nil {
+		report.WeaponName = weapon.Name
+		report.WeaponLevel = weapon.Level
+		report.WeaponLost = !weaponKept
+	}
+	if armor != nil {
+		report.ArmorName = armor.Name
+		report.ArmorLost = !armorKept
+	}
+	if consumable != nil {
+		report.Consumable = consumable.Name
+		report.ConsumableUsed = !consumableKept
+		report.Revived = consumable.Name == REVIVE_SCROLL && report.ConsumableUsed && !report.CharacterLost
+	}
+	return report
+}
+
+// LevelUps returns how many levels the character gained
+func (report BattleReport) LevelUps() int {
+	return report.LevelAfter - report.LevelBefore
+}
+
+// GotSpecial returns true when the character acquired a special from the battle
+func (report BattleReport) GotSpecial() bool {
+	return !report.CharacterLost && report.SpecialAfter != report.SpecialBefore && report.SpecialAfter != NO_SPECIAL
+}
+
+// SortBattleHistory sorts battle reports from the latest one
+func SortBattleHistory(reports []BattleReport) {
+	sort.SliceStable(reports, func(i, j int) bool {
+		if reports[i].Time == reports[j].Time {
+			return reports[i].Block > reports[j].Block
+		}
+		return reports[i].Time > reports[j].Time
+	})
+}
@@ -42,7 +42,7 @@ func (w *dbWorld) load() {
 	} else {
 		// Make default tables
 		db.Update(func(tx *bolt.Tx) error {
-			buckets := []string{"users"}
+			buckets := []string{"users", "trade_history", "battle_history", "leaderboard", "my_orders"}
 
 			for _, bucket := range buckets {
 				_, err := tx.CreateBucketIfNotExists([]byte(bucket))
@@ -67,16 +67,26 @@ func LoadWorldFromDB(filename string) World {
 
 // UserData is a JSON-serializable set of information about a User.
 type UserData struct {
-	Gold                 int
-	PylonAmount          int
-	Username             string `json:""`
-	Address              string
-	Location             UserLocation
-	Items                []Item
+	Gold              int
+	PylonAmount       int
+	Username          string `json:""`
+	Address           string
+	Location          UserLocation
+	Items             []Item
+	ActiveWeaponID    string // empty ID is the first entry, NO_ACTIVE_ID uses nothing
+	Characters        []Character
+	ActiveCharacterID string
+	ActiveConsumable  string // consumable name used on hunts and fights, empty when not used
+	ActiveArmorID     string
+	PrivKey           string
+	WatchRules        []WatchRule
+	WatchBell         bool
+	Achievements      map[string]int64 // unlock time by achievement ID
+	// ActiveWeaponIndex, ActiveCharacterIndex and ActiveArmorIndex are saved by older versions,
+	// they are migrated to the IDs above on load and cleared
 	ActiveWeaponIndex    int
-	Characters           []Character
 	ActiveCharacterIndex int
-	PrivKey              string
+	ActiveArmorIndex     int
 	lastTransaction      string
 	lastTxMetaData       string
 	lastUpdate           int64
@@ -116,6 +126,7 @@ func (user *dbUser) Reload() {
 		user.Save()
 	} else {
 		MSGUnpack(record, &(user.UserData))
+		user.UserData.migrateActiveIndexes()
 		log.Printf("Loaded user %v", user.UserData)
 	}
 	log.Println("start InitPylonAccount")
@@ -176,42 +187,127 @@ func (user *dbUser) SetItems(items []Item) {
 	user.UserData.Items = items
 }
 
-func (user *dbUser) SetActiveWeaponIndex(idx int) {
-	user.UserData.ActiveWeaponIndex = idx
+// activeIndex returns index of the active ID, empty ID is the first entry as on a new account
+func activeIndex(ids []string, id string) int {
+	if len(id) == 0 && len(ids) > 0 {
+		id = ids[0]
+	}
+	for idx, iid := range ids {
+		if iid == id && !IsLocked(iid) {
+			return idx
+		}
+	}
+	return -1
 }
 
-func (user *dbUser) GetActiveWeapon() *Item {
-	i := user.UserData.ActiveWeaponIndex
-	swords := user.InventorySwords()
-	if i < 0 || i >= len(swords) {
-		return nil
+// migrateActiveIndex maps an active index of older versions to the ID at that index, index out of range uses nothing
+func migrateActiveIndex(ids []string, idx int, id string) string {
+	if len(id) > 0 || idx == 0 {
+		return id
+	}
+	if idx > 0 && idx < len(ids) {
+		return ids[idx]
+	}
+	return NO_ACTIVE_ID
+}
+
+func (userData *UserData) migrateActiveIndexes() {
+	weaponIDs, armorIDs, characterIDs := []string{}, []string{}, []string{}
+	for _, item := range userData.Items {
+		if item.IsSword() {
+			weaponIDs = append(weaponIDs, item.ID)
+		}
+		if item.IsArmor() {
+			armorIDs = append(armorIDs, item.ID)
+		}
+	}
+	for _, char := range userData.Characters {
+		characterIDs = append(characterIDs, char.ID)
 	}
-	return &swords[i]
+	userData.ActiveWeaponID = migrateActiveIndex(weaponIDs, userData.ActiveWeaponIndex, userData.ActiveWeaponID)
+	userData.ActiveArmorID = migrateActiveIndex(armorIDs, userData.ActiveArmorIndex, userData.ActiveArmorID)
+	userData.ActiveCharacterID = migrateActiveIndex(characterIDs, userData.ActiveCharacterIndex, userData.ActiveCharacterID)
+	userData.ActiveWeaponIndex, userData.ActiveArmorIndex, userData.ActiveCharacterIndex = 0, 0, 0
+}
+
+func activeItem(items []Item, id string) *Item {
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	if idx := activeIndex(ids, id); idx >= 0 {
+		return &items[idx]
+	}
+	return nil
+}
+
+func (user *dbUser) SetActiveWeaponID(id string) {
+	user.UserData.ActiveWeaponID = id
 }
 
-func (user *dbUser) GetActiveWeaponIndex() int {
-	return user.UserData.ActiveWeaponIndex
+func (user *dbUser) GetActiveWeapon() *Item {
+	return activeItem(user.InventorySwords(), user.UserData.ActiveWeaponID)
+}
+
+func (user *dbUser) GetActiveWeaponID() string {
+	return user.UserData.ActiveWeaponID
+}
+
+func (user *dbUser) SetActiveArmorID(id string) {
+	user.UserData.ActiveArmorID = id
+}
+
+func (user *dbUser) GetActiveArmor() *Item {
+	return activeItem(user.InventoryArmors(), user.UserData.ActiveArmorID)
+}
+
+func (user *dbUser) GetActiveArmorID() string {
+	return user.UserData.ActiveArmorID
+}
+
+func (user *dbUser) SetActiveConsumable(name string) {
+	user.UserData.ActiveConsumable = name
+}
+
+func (user *dbUser) GetActiveConsumable() string {
+	return user.UserData.ActiveConsumable
+}
+
+func (user *dbUser) GetActiveConsumableItem() *Item {
+	name := user.UserData.ActiveConsumable
+	if len(name) == 0 {
+		return nil
+	}
+	for _, item := range user.InventoryConsumables() {
+		if item.Name == name {
+			return &item
+		}
+	}
+	return nil
 }
 
 func (user *dbUser) SetCharacters(items []Character) {
 	user.UserData.Characters = items
 }
 
-func (user *dbUser) SetActiveCharacterIndex(idx int) {
-	user.UserData.ActiveCharacterIndex = idx
+func (user *dbUser) SetActiveCharacterID(id string) {
+	user.UserData.ActiveCharacterID = id
 }
 
-func (user *dbUser) GetActiveCharacterIndex() int {
-	return user.UserData.ActiveCharacterIndex
+func (user *dbUser) GetActiveCharacterID() string {
+	return user.UserData.ActiveCharacterID
 }
 
 func (user *dbUser) GetActiveCharacter() *Character {
-	i := user.UserData.ActiveCharacterIndex
 	chars := user.UserData.Characters
-	if i < 0 || i >= len(chars) {
-		return nil
+	ids := []string{}
+	for _, char := range chars {
+		ids = append(ids, char.ID)
 	}
-	return &chars[i]
+	if idx := activeIndex(ids, user.UserData.ActiveCharacterID); idx >= 0 {
+		return &chars[idx]
+	}
+	return nil
 }
 
 func (user *dbUser) InventoryItems() []Item {
@@ -262,6 +358,28 @@ func (user *dbUser) InventorySwords() []Item {
 	return uis
 }
 
+func (user *dbUser) InventoryArmors() []Item {
+	iis := user.InventoryItems()
+	uis := []Item{}
+	for _, ii := range iis {
+		if ii.IsArmor() {
+			uis = append(uis, ii)
+		}
+	}
+	return uis
+}
+
+func (user *dbUser) InventoryConsumables() []Item {
+	iis := user.InventoryItems()
+	uis := []Item{}
+	for _, ii := range iis {
+		if ii.IsConsumable() && !IsLocked(ii.ID) {
+			uis = append(uis, ii)
+		}
+	}
+	return uis
+}
+
 func (user *dbUser) InventoryCharacters() []Character {
 	return user.UserData.Characters
 }
@@ -270,7 +388,7 @@ func (user *dbUser) InventoryUpgradableItems() []Item {
 	iis := user.InventoryItems()
 	uis := []Item{}
 	for _, ii := range iis {
-		if ii.Level == 1 && (ii.Name == COPPER_SWORD || ii.Name == WOODEN_SWORD) {
+		if ii.Level == 1 && (ii.Name == COPPER_SWORD || ii.Name == WOODEN_SWORD) && !IsLocked(ii.ID) {
 			uis = append(uis, ii)
 		}
 	}
@@ -281,7 +399,7 @@ func (user *dbUser) InventorySellableItems() []Item {
 	iis := user.InventoryItems()
 	uis := []Item{}
 	for _, ii := range iis {
-		if ii.Name == COPPER_SWORD || ii.Name == WOODEN_SWORD {
+		if (ii.Name == COPPER_SWORD || ii.Name == WOODEN_SWORD) && !IsLocked(ii.ID) {
 			uis = append(uis, ii)
 		}
 	}
@@ -309,6 +427,188 @@ func (user dbUser) GetLatestBlockHeight() int64 {
 	return user.UserData.lastUpdate
 }
 
+func (user *dbUser) GetTradeHistory() []TradeRecord {
+	records := []TradeRecord{}
+	if user.world.database != nil {
+		user.world.database.View(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket([]byte("trade_history"))
+			return bucket.ForEach(func(k, v []byte) error {
+				record := TradeRecord{}
+				if err := MSGUnpack(v, &record); err != nil {
+					log.Printf("Can't unmarshal trade record %s: %v", string(k), err)
+					return nil
+				}
+				records = append(records, record)
+				return nil
+			})
+		})
+	}
+	return records
+}
+
+func (user *dbUser) AddTradeHistory(records []TradeRecord) {
+	if user.world.database == nil {
+		return
+	}
+	user.world.database.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte("trade_history"))
+		for _, record := range records {
+			bytes, err := MSGPack(record)
+			if err != nil {
+				log.Printf("Can't marshal trade record: %v", err)
+				continue
+			}
+			if err = bucket.Put([]byte(record.ID), bytes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (user *dbUser) GetBattleHistory() []BattleReport {
+	reports := []BattleReport{}
+	if user.world.database != nil {
+		user.world.database.View(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket([]byte("battle_history"))
+			return bucket.ForEach(func(k, v []byte) error {
+				report := BattleReport{}
+				if err := MSGUnpack(v, &report); err != nil {
+					log.Printf("Can't unmarshal battle report %s: %v", string(k), err)
+					return nil
+				}
+				reports = append(reports, report)
+				return nil
+			})
+		})
+	}
+	SortBattleHistory(reports)
+	return reports
+}
+
+func (user *dbUser) AddBattleReport(report BattleReport) {
+	if user.world.database == nil {
+		return
+	}
+	bytes, err := MSGPack(report)
+	if err != nil {
+		log.Printf("Can't marshal battle report: %v", err)
+		return
+	}
+	user.world.database.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte("battle_history"))
+		return bucket.Put([]byte(report.TxHash), bytes)
+	})
+}
+
+func (user *dbUser) GetLeaderboard() Leaderboard {
+	leaderboard := Leaderboard{}
+	if user.world.database != nil {
+		user.world.database.View(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket([]byte("leaderboard"))
+			if record := bucket.Get([]byte("characters")); record != nil {
+				if err := MSGUnpack(record, &leaderboard); err != nil {
+					log.Printf("Can't unmarshal leaderboard: %v", err)
+				}
+			}
+			return nil
+		})
+	}
+	return leaderboard
+}
+
+func (user *dbUser) SetLeaderboard(leaderboard Leaderboard) {
+	if user.world.database == nil {
+		return
+	}
+	bytes, err := MSGPack(leaderboard)
+	if err != nil {
+		log.Printf("Can't marshal leaderboard: %v", err)
+		return
+	}
+	user.world.database.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte("leaderboard"))
+		return bucket.Put([]byte("characters"), bytes)
+	})
+}
+
+// GetKnownUsernames returns usernames of local users by address
+func (user *dbUser) GetKnownUsernames() map[string]string {
+	usernames := map[string]string{}
+	if user.world.database != nil {
+		user.world.database.View(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket([]byte("users"))
+			return bucket.ForEach(func(k, v []byte) error {
+				userData := UserData{}
+				if err := MSGUnpack(v, &userData); err == nil && len(userData.Address) > 0 {
+					usernames[userData.Address] = userData.Username
+				}
+				return nil
+			})
+		})
+	}
+	return usernames
+}
+
+// GetMyOrderSnapshot returns my open orders stored by the latest sync, by address
+func (user *dbUser) GetMyOrderSnapshot() MyOrderSnapshot {
+	snapshot := MyOrderSnapshot{}
+	if user.world.database != nil {
+		user.world.database.View(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket([]byte("my_orders"))
+			if record := bucket.Get([]byte(user.UserData.Address)); record != nil {
+				if err := MSGUnpack(record, &snapshot); err != nil {
+					log.Printf("Can't unmarshal my order snapshot: %v", err)
+				}
+			}
+			return nil
+		})
+	}
+	return snapshot
+}
+
+func (user *dbUser) SetMyOrderSnapshot(snapshot MyOrderSnapshot) {
+	if user.world.database == nil || len(user.UserData.Address) == 0 {
+		return
+	}
+	bytes, err := MSGPack(snapshot)
+	if err != nil {
+		log.Printf("Can't marshal my order snapshot: %v", err)
+		return
+	}
+	user.world.database.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte("my_orders"))
+		return bucket.Put([]byte(user.UserData.Address), bytes)
+	})
+}
+
+func (user *dbUser) GetWatchRules() []WatchRule {
+	return user.UserData.WatchRules
+}
+
+func (user *dbUser) SetWatchRules(rules []WatchRule) {
+	user.UserData.WatchRules = rules
+}
+
+func (user *dbUser) GetWatchBell() bool {
+	return user.UserData.WatchBell
+}
+
+func (user *dbUser) SetWatchBell(bell bool) {
+	user.UserData.WatchBell = bell
+}
+
+func (user *dbUser) GetAchievements() map[string]int64 {
+	return user.UserData.Achievements
+}
+
+func (user *dbUser) UnlockAchievement(id string, at int64) {
+	if user.UserData.Achievements == nil {
+		user.UserData.Achievements = map[string]int64{}
+	}
+	user.UserData.Achievements[id] = at
+}
+
 func getUserFromDB(world *dbWorld, username string) User {
 	user := dbUser{
 		UserData: UserData{
@@ -0,0 +1,37 @@
+package loud
+
+import "testing"
+
+func TestMigrateActiveIndexes(t *testing.T) {
+	items := []Item{
+		{ID: "s0", Name: "Wooden sword"},
+		{ID: "a0", Name: "Iron armor", Type: ARMOR_TYPE},
+		{ID: "s1", Name: "Copper sword"},
+		{ID: "a1", Name: "Copper armor", Type: ARMOR_TYPE},
+	}
+	chars := []Character{{ID: "c0"}, {ID: "c1"}, {ID: "c2"}}
+	tests := []struct {
+		name                     string
+		data                     UserData
+		weapon, armor, character string
+	}{
+		{"first entries stay default", UserData{}, "", "", ""},
+		{"index maps to ID", UserData{ActiveWeaponIndex: 1, ActiveArmorIndex: 1, ActiveCharacterIndex: 2}, "s1", "a1", "c2"},
+		{"out of range uses nothing", UserData{ActiveWeaponIndex: 2, ActiveArmorIndex: -1, ActiveCharacterIndex: 3}, NO_ACTIVE_ID, NO_ACTIVE_ID, NO_ACTIVE_ID},
+		{"ID is kept", UserData{ActiveWeaponID: "s0", ActiveWeaponIndex: 1, ActiveCharacterID: NO_ACTIVE_ID}, "s0", "", NO_ACTIVE_ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data
+			data.Items, data.Characters = items, chars
+			data.migrateActiveIndexes()
+			if data.ActiveWeaponID != tt.weapon || data.ActiveArmorID != tt.armor || data.ActiveCharacterID != tt.character {
+				t.Errorf("weapon, armor, character = %q, %q, %q, want %q, %q, %q",
+					data.ActiveWeaponID, data.ActiveArmorID, data.ActiveCharacterID, tt.weapon, tt.armor, tt.character)
+			}
+			if data.ActiveWeaponIndex != 0 || data.ActiveArmorIndex != 0 || data.ActiveCharacterIndex != 0 {
+				t.Errorf("indexes are not cleared: %d, %d, %d", data.ActiveWeaponIndex, data.ActiveArmorIndex, data.ActiveCharacterIndex)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package loud
+
+import (
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// MaterialDropRecipes is the fight recipe which drops each crafting material
+var MaterialDropRecipes = map[string]string{
+	GOBLIN_EAR:      RCP_FIGHT_GOBLIN,
+	WOLF_TAIL:       RCP_FIGHT_WOLF,
+	TROLL_TOES:      RCP_FIGHT_TROLL,
+	DROP_DRAGONFIRE: RCP_FIGHT_DRAGONFIRE,
+	DROP_DRAGONICE:  RCP_FIGHT_DRAGONICE,
+	DROP_DRAGONACID: RCP_FIGHT_DRAGONACID,
+}
+
+// CraftSource is where a crafting material drops
+type CraftSource struct {
+	Action    string  // fight recipe name
+	Weight    string  // weight program of the output dropping the material, empty when recipe is not synced
+	Chance    float64 // drop chance for active character and a usable sword
+	HasChance bool
+}
+
+// CraftNode is a sword or a material of the crafting tree
+type CraftNode struct {
+	Name     string
+	Price    int // shop price of swords, 0 for materials
+	Owned    int
+	Source   *CraftSource // nil for swords
+	Children []CraftNode  // materials needed to make the sword
+}
+
+const (
+	CRAFT_STEP_DONE      = "done"      // target is owned
+	CRAFT_STEP_BUY       = "buy"       // make or buy Name in the shop
+	CRAFT_STEP_EARN      = "earn"      // earn Gold more to buy Name
+	CRAFT_STEP_FIGHT     = "fight"     // run Action to get Name
+	CRAFT_STEP_CHARACTER = "character" // active character can't run Action
+)
+
+// CraftStep is the next concrete action toward a target sword
+type CraftStep struct {
+	Kind   string
+	Name   string
+	Action string
+	Gold   int
+}
+
+// CraftableSwords is the shop swords which need materials
+func CraftableSwords() []Item {
+	swords := []Item{}
+	for _, item := range ShopItems {
+		if item.IsSword() && len(item.PreItems) > 0 {
+			swords = append(swords, item)
+		}
+	}
+	return swords
+}
+
+func inventoryCount(user User, name string) int {
+	count := 0
+	for _, item := range user.InventoryItems() {
+		if item.Name == name {
+			count++
+		}
+	}
+	return count
+}
+
+// dropOutputWeight returns the weight program of the output which creates the material
+func dropOutputWeight(rcp types.Recipe, material string) string {
+	for _, wo := range rcp.Outputs {
+		for _, entryIdx := range wo.ResultEntries {
+			if entryIdx < 0 || entryIdx >= len(rcp.Entries) {
+				continue
+			}
+			if io, ok := rcp.Entries[entryIdx].(types.ItemOutput); ok && io.ModifyItem.ItemInputRef == -1 && itemOutputName(io) == material {
+				return wo.Weight
+			}
+		}
+	}
+	return ""
+}
+
+// fightWeapon returns active weapon when it can be used for the recipe, otherwise the first usable sword
+func fightWeapon(user User, rcp types.Recipe) *Item {
+	if len(rcp.ItemInputs) < 2 {
+		return nil
+	}
+	if weapon := user.GetActiveWeapon(); weapon != nil && ItemMatchesInput(*weapon, rcp.ItemInputs[1]) {
+		return weapon
+	}
+	for _, sword := range user.InventorySwords() {
+		if !IsLocked(sword.ID) && ItemMatchesInput(sword, rcp.ItemInputs[1]) {
+			return &sword
+		}
+	}
+	return nil
+}
+
+func craftSource(user User, material string) *CraftSource {
+	source := &CraftSource{Action: MaterialDropRecipes[material]}
+	rcp, ok := LoudRecipes[RcpIDs[source.Action]]
+	if !ok {
+		return source
+	}
+	source.Weight = dropOutputWeight(rcp, material)
+	weapon := fightWeapon(user, rcp)
+	if estimate, err := EstimateRecipe(source.Action, user.GetActiveCharacter(), weapon, nil); err == nil && estimate.DropName == material {
+		source.Chance = estimate.DropChance
+		source.HasChance = true
+	}
+	return source
+}
+
+// BuildCraftTree builds crafting tree of a sword from its materials
+func BuildCraftTree(user User, sword Item) CraftNode {
+	node := CraftNode{
+		Name:  sword.Name,
+		Price: sword.Price,
+		Owned: inventoryCount(user, sword.Name),
+	}
+	for _, material := range sword.PreItems {
+		node.Children = append(node.Children, CraftNode{
+			Name:   material,
+			Owned:  inventoryCount(user, material),
+			Source: craftSource(user, material),
+		})
+	}
+	return node
+}
+
+func shopItemByName(name string) (Item, bool) {
+	for _, item := range ShopItems {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// NextCraftStep returns the next concrete step to get the target sword
+func NextCraftStep(user User, target Item) CraftStep {
+	if inventoryCount(user, target.Name) > 0 {
+		return CraftStep{Kind: CRAFT_STEP_DONE, Name: target.Name}
+	}
+	return nextCraftStep(user, target, 0)
+}
+
+func nextCraftStep(user User, target Item, depth int) CraftStep {
+	for _, material := range target.PreItems {
+		if inventoryCount(user, material) == 0 {
+			return materialStep(user, material, depth)
+		}
+	}
+	if target.Price > user.GetGold() {
+		return CraftStep{Kind: CRAFT_STEP_EARN, Name: target.Name, Gold: target.Price - user.GetGold()}
+	}
+	return CraftStep{Kind: CRAFT_STEP_BUY, Name: target.Name}
+}
+
+// materialStep returns the step to get a material, which can be getting a sword to fight with
+func materialStep(user User, material string, depth int) CraftStep {
+	step := CraftStep{Kind: CRAFT_STEP_FIGHT, Name: material, Action: MaterialDropRecipes[material]}
+	character := user.GetActiveCharacter()
+	if character == nil {
+		step.Kind = CRAFT_STEP_CHARACTER
+		return step
+	}
+	rcp, ok := LoudRecipes[RcpIDs[step.Action]]
+	if !ok || len(rcp.ItemInputs) == 0 {
+		return step
+	}
+	if !CharacterMatchesInput(*character, rcp.ItemInputs[0]) {
+		step.Kind = CRAFT_STEP_CHARACTER
+		return step
+	}
+	if len(rcp.ItemInputs) > 1 && fightWeapon(user, rcp) == nil && depth < len(ShopItems) {
+		weaponName, _ := itemInputType(rcp.ItemInputs[1])
+		weapon, ok := shopItemByName(weaponName)
+		if !ok {
+			weapon = ShopItems[0] // any sword can be used, wooden sword is the cheapest
+		}
+		return nextCraftStep(user, weapon, depth+1)
+	}
+	return step
+}
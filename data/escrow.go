@@ -0,0 +1,43 @@
+package loud
+
+// lockedTrdReqIDs maps IDs of my items and characters which are listed in my open trade requests
+// to the trade request ID, it's rebuilt on sync by UpdateLockedTrdReqIDs
+var lockedTrdReqIDs = map[string]string{}
+
+// UpdateLockedTrdReqIDs rebuilds locked item IDs from my open trade requests.
+// Listed items are still in inventory until the trade is fulfilled,
+// so they should not be used, sold or upgraded meanwhile.
+func UpdateLockedTrdReqIDs() {
+	locked := make(map[string]string)
+	for _, request := range ItemSellTrdReqs {
+		if request.IsMyTrdReq {
+			locked[request.TItem.ID] = request.ID
+		}
+	}
+	for _, request := range CharacterSellTrdReqs {
+		if request.IsMyTrdReq {
+			locked[request.TCharacter.ID] = request.ID
+		}
+	}
+	for _, request := range BarterTrdReqs {
+		if !request.IsMyTrdReq {
+			continue
+		}
+		for _, item := range request.OfferItems {
+			locked[item.ID] = request.ID
+		}
+	}
+	lockedTrdReqIDs = locked
+}
+
+// LockingTrdReqID returns ID of my open trade request which item or character is listed in
+func LockingTrdReqID(itemID string) (string, bool) {
+	trdReqID, ok := lockedTrdReqIDs[itemID]
+	return trdReqID, ok
+}
+
+// IsLocked returns true if item or character is listed in my open trade request
+func IsLocked(itemID string) bool {
+	_, ok := lockedTrdReqIDs[itemID]
+	return ok
+}
@@ -10,7 +10,9 @@ type Item struct {
 	Name       string `json:""`
 	Level      int
 	Attack     int
+	Defense    int
 	Price      int
+	Type       string // empty for swords and drops
 	PreItems   []string
 	LastUpdate int64
 }
@@ -62,12 +64,32 @@ const (
 	DROP_DRAGONICE  = "Icy shards"
 	DROP_DRAGONFIRE = "Fire scale"
 	DROP_DRAGONACID = "Poison claws"
+
+	HEALING_POTION = "Healing potion"
+	REVIVE_SCROLL  = "Revive scroll"
+
+	LEATHER_ARMOR = "Leather armor"
+	IRON_SHIELD   = "Iron shield"
 )
 
+const CONSUMABLE_TYPE = "Consumable"
+const ARMOR_TYPE = "Armor"
+
+// Consumables is the consumable item names which can be used on a hunt or fight
+var Consumables = []string{HEALING_POTION, REVIVE_SCROLL}
+
 func (item Item) IsSword() bool {
 	return strings.Contains(item.Name, "sword")
 }
 
+func (item Item) IsConsumable() bool {
+	return item.Type == CONSUMABLE_TYPE
+}
+
+func (item Item) IsArmor() bool {
+	return item.Type == ARMOR_TYPE
+}
+
 var ShopItems = []Item{
 	Item{
 		ID:    "001",
@@ -109,6 +131,36 @@ var ShopItems = []Item{
 		Price:    20000,
 		PreItems: []string{DROP_DRAGONFIRE, DROP_DRAGONICE, DROP_DRAGONACID},
 	},
+	Item{
+		ID:    "007",
+		Name:  HEALING_POTION,
+		Level: 1,
+		Price: 100,
+		Type:  CONSUMABLE_TYPE,
+	},
+	Item{
+		ID:    "008",
+		Name:  REVIVE_SCROLL,
+		Level: 1,
+		Price: 1000,
+		Type:  CONSUMABLE_TYPE,
+	},
+	Item{
+		ID:      "009",
+		Name:    LEATHER_ARMOR,
+		Level:   1,
+		Defense: 5,
+		Price:   300,
+		Type:    ARMOR_TYPE,
+	},
+	Item{
+		ID:      "010",
+		Name:    IRON_SHIELD,
+		Level:   1,
+		Defense: 20,
+		Price:   1500,
+		Type:    ARMOR_TYPE,
+	},
 }
 
 func (item Item) PreItemStr() string {
@@ -187,6 +239,22 @@ var WorldItemSpecs = []ItemSpec{
 		Name:  DROP_DRAGONACID,
 		Level: [2]int{1, 1},
 	},
+	ItemSpec{
+		Name:  HEALING_POTION,
+		Level: [2]int{1, 1},
+	},
+	ItemSpec{
+		Name:  REVIVE_SCROLL,
+		Level: [2]int{1, 1},
+	},
+	ItemSpec{
+		Name:  LEATHER_ARMOR,
+		Level: [2]int{1, 1},
+	},
+	ItemSpec{
+		Name:  IRON_SHIELD,
+		Level: [2]int{1, 1},
+	},
 }
 
 var WorldCharacterSpecs = []CharacterSpec{
@@ -0,0 +1,137 @@
+package loud
+
+import (
+	"sort"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// itemMatchParams is the set of attributes an item input is evaluated against
+type itemMatchParams struct {
+	longs   map[string]int
+	doubles map[string]float64
+	strings map[string]string
+}
+
+func paramsFromItem(item Item) itemMatchParams {
+	params := itemMatchParams{
+		longs:   map[string]int{"level": item.Level, "defense": item.Defense},
+		doubles: map[string]float64{"attack": float64(item.Attack)},
+		strings: map[string]string{"Name": item.Name},
+	}
+	if item.Type != "" {
+		params.strings["Type"] = item.Type
+	}
+	return params
+}
+
+func paramsFromCharacter(ch Character) itemMatchParams {
+	return itemMatchParams{
+		longs: map[string]int{
+			"level":             ch.Level,
+			"GiantKill":         ch.GiantKill,
+			"Special":           ch.Special,
+			"SpecialDragonKill": ch.SpecialDragonKill,
+			"UndeadDragonKill":  ch.UndeadDragonKill,
+		},
+		doubles: map[string]float64{"XP": ch.XP},
+		strings: map[string]string{"Name": ch.Name, "Type": "Character"},
+	}
+}
+
+// matches checks all long and double ranges and string values of item input
+// an attribute which is missing never matches
+func (p itemMatchParams) matches(ii types.ItemInput) bool {
+	for _, param := range ii.Longs {
+		v, ok := p.longs[param.Key]
+		if !ok || v < param.MinValue || v > param.MaxValue {
+			return false
+		}
+	}
+	for _, param := range ii.Doubles {
+		v, ok := p.doubles[param.Key]
+		if !ok || v < param.MinValue.Float() || v > param.MaxValue.Float() {
+			return false
+		}
+	}
+	for _, param := range ii.Strings {
+		v, ok := p.strings[param.Key]
+		if !ok || v != param.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// ItemMatchesInput returns true if item satisfies item input of a trade
+func ItemMatchesInput(item Item, ii types.ItemInput) bool {
+	return paramsFromItem(item).matches(ii)
+}
+
+// CharacterMatchesInput returns true if character satisfies item input of a trade
+func CharacterMatchesInput(ch Character, ii types.ItemInput) bool {
+	return paramsFromCharacter(ch).matches(ii)
+}
+
+// MatchItemCandidates returns inventory items which can be given for item input, lowest value first.
+// Active weapon and items listed in my open trades are never returned.
+func MatchItemCandidates(user User, ii types.ItemInput) []Item {
+	activeWeapon := user.GetActiveWeapon()
+	candidates := []Item{}
+	for _, item := range user.InventoryItems() {
+		if (activeWeapon != nil && activeWeapon.ID == item.ID) || IsLocked(item.ID) {
+			continue
+		}
+		if ItemMatchesInput(item, ii) {
+			candidates = append(candidates, item)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Level != candidates[j].Level {
+			return candidates[i].Level < candidates[j].Level
+		}
+		return candidates[i].Attack < candidates[j].Attack
+	})
+	return candidates
+}
+
+// MatchCharacterCandidates returns inventory characters which can be given for item input, lowest value first.
+// Active character and characters listed in my open trades are never returned.
+func MatchCharacterCandidates(user User, ii types.ItemInput) []Character {
+	activeCharacter := user.GetActiveCharacter()
+	candidates := []Character{}
+	for _, ch := range user.InventoryCharacters() {
+		if (activeCharacter != nil && activeCharacter.ID == ch.ID) || IsLocked(ch.ID) {
+			continue
+		}
+		if CharacterMatchesInput(ch, ii) {
+			candidates = append(candidates, ch)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Level != candidates[j].Level {
+			return candidates[i].Level < candidates[j].Level
+		}
+		return candidates[i].XP < candidates[j].XP
+	})
+	return candidates
+}
+
+func firstItemInput(itemInputs types.ItemInputList, fallback types.ItemInputList) types.ItemInput {
+	if len(itemInputs) > 0 {
+		return itemInputs[0]
+	}
+	return fallback[0]
+}
+
+// ItemBuyTrdReqCandidates returns inventory items which can fulfill item buy request
+func ItemBuyTrdReqCandidates(user User, request ItemBuyTrdReq) []Item {
+	ii := firstItemInput(request.ItemInputs, GetItemInputsFromItemSpec(request.TItem))
+	return MatchItemCandidates(user, ii)
+}
+
+// CharacterBuyTrdReqCandidates returns inventory characters which can fulfill character buy request
+func CharacterBuyTrdReqCandidates(user User, request CharacterBuyTrdReq) []Character {
+	ii := firstItemInput(request.ItemInputs, GetItemInputsFromCharacterSpec(request.TCharacter))
+	return MatchCharacterCandidates(user, ii)
+}
@@ -0,0 +1,56 @@
+package loud
+
+import (
+	"testing"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+func TestItemMatchesInput(t *testing.T) {
+	armor := Item{Name: LEATHER_ARMOR, Level: 2, Defense: 3, Type: ARMOR_TYPE}
+	sword := Item{Name: WOODEN_SWORD, Level: 2, Attack: 3}
+	tests := []struct {
+		name string
+		item Item
+		ii   types.ItemInput
+		want bool
+	}{
+		{
+			"armor in defense range",
+			armor,
+			types.ItemInput{Longs: types.LongInputParamList{{Key: "defense", MinValue: 1, MaxValue: 5}}},
+			true,
+		},
+		{
+			"armor out of defense range",
+			armor,
+			types.ItemInput{Longs: types.LongInputParamList{{Key: "defense", MinValue: 4, MaxValue: 5}}},
+			false,
+		},
+		{
+			"armor type",
+			armor,
+			types.ItemInput{Strings: types.StringInputParamList{{Key: "Type", Value: ARMOR_TYPE}}},
+			true,
+		},
+		{
+			"sword has no type",
+			sword,
+			types.ItemInput{Strings: types.StringInputParamList{{Key: "Type", Value: ARMOR_TYPE}}},
+			false,
+		},
+		{
+			"sword by name and level",
+			sword,
+			GetItemInputsFromItemSpec(ItemSpec{Name: WOODEN_SWORD, Level: [2]int{1, 2}})[0],
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ItemMatchesInput(tt.item, tt.ii); got != tt.want {
+				t.Errorf("ItemMatchesInput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
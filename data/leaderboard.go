@@ -0,0 +1,104 @@
+package loud
+
+import (
+	"sort"
+	"time"
+
+	pylonSDK "github.com/Pylons-tech/pylons_sdk/cmd/test"
+)
+
+const (
+	LDRBRD_BY_LEVEL     = "level"
+	LDRBRD_BY_XP        = "XP"
+	LDRBRD_BY_GIANTKILL = "giant kills"
+	LDRBRD_BY_DRAGON    = "dragon kills"
+)
+
+// LeaderboardSortKeys is the ranking orders of leaderboard in switching order
+var LeaderboardSortKeys = []string{LDRBRD_BY_LEVEL, LDRBRD_BY_XP, LDRBRD_BY_GIANTKILL, LDRBRD_BY_DRAGON}
+
+// LeaderboardEntry is a LOUD character of any account
+type LeaderboardEntry struct {
+	Character Character
+	Owner     string // owner address
+}
+
+// DragonKill is the number of special and undead dragons killed by the character
+func (entry LeaderboardEntry) DragonKill() int {
+	return entry.Character.SpecialDragonKill + entry.Character.UndeadDragonKill
+}
+
+// Leaderboard is the result of a cookbook-wide character scan, cached locally since the scan is slow
+type Leaderboard struct {
+	Entries   []LeaderboardEntry
+	UpdatedAt int64
+	Block     int64
+}
+
+// ScanLeaderboard lists characters of LOUD cookbook from all accounts
+func ScanLeaderboard(user User) (Leaderboard, error) {
+	leaderboard := Leaderboard{
+		UpdatedAt: time.Now().Unix(),
+		Block:     user.GetLatestBlockHeight(),
+	}
+	rawItems, err := pylonSDK.ListItemsViaCLI("")
+	if err != nil {
+		return leaderboard, err
+	}
+	for _, rawItem := range rawItems {
+		if rawItem.CookbookID != LOUD_CBID {
+			continue
+		}
+		if itemType, _ := rawItem.FindString("Type"); itemType != "Character" {
+			continue
+		}
+		Name, _ := rawItem.FindString("Name")
+		XP, _ := rawItem.FindDouble("XP")
+		Level, _ := rawItem.FindLong("level")
+		GiantKill, _ := rawItem.FindLong("GiantKill")
+		Special, _ := rawItem.FindLong("Special")
+		SpecialDragonKill, _ := rawItem.FindLong("SpecialDragonKill")
+		UndeadDragonKill, _ := rawItem.FindLong("UndeadDragonKill")
+		leaderboard.Entries = append(leaderboard.Entries, LeaderboardEntry{
+			Character: Character{
+				ID:                rawItem.ID,
+				Name:              Name,
+				Level:             Level,
+				XP:                XP,
+				GiantKill:         GiantKill,
+				Special:           Special,
+				SpecialDragonKill: SpecialDragonKill,
+				UndeadDragonKill:  UndeadDragonKill,
+				LastUpdate:        rawItem.LastUpdate,
+			},
+			Owner: rawItem.Sender.String(),
+		})
+	}
+	return leaderboard, nil
+}
+
+// SortLeaderboard ranks leaderboard entries by sort key, using level, XP, giant kills and dragon kills as tiebreakers
+func SortLeaderboard(entries []LeaderboardEntry, sortKey string) {
+	counters := func(entry LeaderboardEntry) []float64 {
+		ch := entry.Character
+		level, xp, giant, dragon := float64(ch.Level), ch.XP, float64(ch.GiantKill), float64(entry.DragonKill())
+		switch sortKey {
+		case LDRBRD_BY_XP:
+			return []float64{xp, level, giant, dragon}
+		case LDRBRD_BY_GIANTKILL:
+			return []float64{giant, level, xp, dragon}
+		case LDRBRD_BY_DRAGON:
+			return []float64{dragon, level, xp, giant}
+		}
+		return []float64{level, xp, giant, dragon}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		ci, cj := counters(entries[i]), counters(entries[j])
+		for k := range ci {
+			if ci[k] != cj[k] {
+				return ci[k] > cj[k]
+			}
+		}
+		return entries[i].Character.ID < entries[j].Character.ID
+	})
+}
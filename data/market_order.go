@@ -0,0 +1,136 @@
+package loud
+
+import (
+	"sort"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/msgs"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MarketOrderPlan is the list of gold trade requests picked to fill a market order
+type MarketOrderPlan struct {
+	IsBuy  bool
+	Fills  []TrdReq
+	Amount int // gold
+	Total  int // pylon
+}
+
+// MarketOrderFillResult is the result of fulfilling one trade request of a market order
+type MarketOrderFillResult struct {
+	TrdReq     TrdReq
+	TxHash     string
+	FailReason string
+}
+
+// AvgPrice returns average pylon price per gold of the planned fills
+func (plan MarketOrderPlan) AvgPrice() float64 {
+	if plan.Amount == 0 {
+		return 0
+	}
+	return float64(plan.Total) / float64(plan.Amount)
+}
+
+// PlanMarketOrder picks the best priced trade requests to buy or sell amount of gold.
+// When isBuy is true, requests should be SellTrdReqs and cheapest ones are picked first
+// while average price stays under limitAvgPrice and pylon total stays under maxPylons.
+// When isBuy is false, requests should be BuyTrdReqs and most expensive ones are picked first
+// while average price stays over limitAvgPrice.
+// Trade requests are fulfilled as a whole, so the ones bigger than remaining amount are skipped.
+func PlanMarketOrder(requests []TrdReq, isBuy bool, amount int, limitAvgPrice float64, maxPylons int) MarketOrderPlan {
+	candidates := []TrdReq{}
+	for _, request := range requests {
+		if request.IsMyTrdReq || request.Amount <= 0 {
+			continue
+		}
+		candidates = append(candidates, request)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if isBuy {
+			return candidates[i].Price < candidates[j].Price
+		}
+		return candidates[i].Price > candidates[j].Price
+	})
+
+	plan := MarketOrderPlan{IsBuy: isBuy}
+	for _, candidate := range candidates {
+		if plan.Amount+candidate.Amount > amount {
+			continue
+		}
+		if isBuy && plan.Total+candidate.Total > maxPylons {
+			continue
+		}
+		nAmount := plan.Amount + candidate.Amount
+		nTotal := plan.Total + candidate.Total
+		nAvgPrice := float64(nTotal) / float64(nAmount)
+		if isBuy && nAvgPrice > limitAvgPrice {
+			continue
+		}
+		if !isBuy && nAvgPrice < limitAvgPrice {
+			continue
+		}
+		plan.Fills = append(plan.Fills, candidate)
+		plan.Amount = nAmount
+		plan.Total = nTotal
+		if plan.Amount == amount {
+			break
+		}
+	}
+	return plan
+}
+
+// MARKET_ORDER_BATCH_SIZE is the number of fulfill trade messages sent in one transaction,
+// it keeps a batch under the default gas of a transaction
+const MARKET_ORDER_BATCH_SIZE = 5
+
+// ExecuteMarketOrder fulfills planned fills with multi message transactions of MARKET_ORDER_BATCH_SIZE.
+// A transaction succeeds or fails as a whole, so every fill reports the result of the batch it's sent in.
+func ExecuteMarketOrder(user User, plan MarketOrderPlan) []MarketOrderFillResult {
+	sdkAddr := GetSDKAddrFromUserName(user.GetUserName())
+	results := []MarketOrderFillResult{}
+	txhashes := []string{}
+	for start := 0; start < len(plan.Fills); start += MARKET_ORDER_BATCH_SIZE {
+		end := start + MARKET_ORDER_BATCH_SIZE
+		if end > len(plan.Fills) {
+			end = len(plan.Fills)
+		}
+		batch := plan.Fills[start:end]
+		txMsgs := []sdk.Msg{}
+		for _, fill := range batch {
+			txMsgs = append(txMsgs, msgs.NewMsgFulfillTrade(fill.ID, sdkAddr, []string{}))
+		}
+		txhash, err := SendTxMsgs(user, txMsgs)
+		if err == nil {
+			txhashes = append(txhashes, txhash)
+		}
+		for _, fill := range batch {
+			result := MarketOrderFillResult{
+				TrdReq: fill,
+				TxHash: txhash,
+			}
+			if err != nil {
+				result.FailReason = err.Error()
+			}
+			results = append(results, result)
+		}
+	}
+	failReasons := ProcessTxResults(user, txhashes)
+	for idx := range results {
+		if reason, ok := failReasons[results[idx].TxHash]; ok {
+			results[idx].FailReason = reason
+		}
+	}
+	return results
+}
+
+// MarketOrderFilled sums up gold and pylon amounts of successfully fulfilled trade requests
+func MarketOrderFilled(results []MarketOrderFillResult) (int, int) {
+	amount, total := 0, 0
+	for _, result := range results {
+		if len(result.FailReason) > 0 {
+			continue
+		}
+		amount += result.TrdReq.Amount
+		total += result.TrdReq.Total
+	}
+	return amount, total
+}
@@ -0,0 +1,61 @@
+package loud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanMarketOrder(t *testing.T) {
+	cheap := TrdReq{ID: "cheap", Price: 2, Amount: 10, Total: 20}
+	dear := TrdReq{ID: "dear", Price: 3, Amount: 5, Total: 15}
+	mine := TrdReq{ID: "mine", Price: 1, Amount: 5, Total: 5, IsMyTrdReq: true}
+
+	tests := []struct {
+		name          string
+		requests      []TrdReq
+		isBuy         bool
+		amount        int
+		limitAvgPrice float64
+		maxPylons     int
+		wantIDs       []string
+		wantAmount    int
+		wantTotal     int
+	}{
+		{"exact fill", []TrdReq{dear, cheap}, true, 15, 10, 1000, []string{"cheap", "dear"}, 15, 35},
+		{"partial fill skips request bigger than the rest", []TrdReq{dear, cheap}, true, 12, 10, 1000, []string{"cheap"}, 10, 20},
+		{"average price limit", []TrdReq{dear, cheap}, true, 15, 2.1, 1000, []string{"cheap"}, 10, 20},
+		{"pylons limit", []TrdReq{dear, cheap}, true, 15, 10, 30, []string{"cheap"}, 10, 20},
+		{"pylons limit skips to smaller request", []TrdReq{dear, cheap}, true, 15, 10, 16, []string{"dear"}, 5, 15},
+		{"own requests are skipped", []TrdReq{mine, cheap}, true, 15, 10, 1000, []string{"cheap"}, 10, 20},
+		{"empty book", []TrdReq{}, true, 15, 10, 1000, nil, 0, 0},
+		{"sell picks the best price first", []TrdReq{cheap, dear}, false, 15, 0, 0, []string{"dear", "cheap"}, 15, 35},
+		{"sell average price limit", []TrdReq{cheap, dear}, false, 15, 2.5, 0, []string{"dear"}, 5, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PlanMarketOrder(tt.requests, tt.isBuy, tt.amount, tt.limitAvgPrice, tt.maxPylons)
+			var ids []string
+			for _, fill := range plan.Fills {
+				ids = append(ids, fill.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("fills = %v, want %v", ids, tt.wantIDs)
+			}
+			if plan.Amount != tt.wantAmount || plan.Total != tt.wantTotal {
+				t.Errorf("amount, total = %d, %d, want %d, %d", plan.Amount, plan.Total, tt.wantAmount, tt.wantTotal)
+			}
+			if plan.IsBuy != tt.isBuy {
+				t.Errorf("IsBuy = %v, want %v", plan.IsBuy, tt.isBuy)
+			}
+		})
+	}
+}
+
+func TestMarketOrderPlanAvgPrice(t *testing.T) {
+	if avg := (MarketOrderPlan{}).AvgPrice(); avg != 0 {
+		t.Errorf("empty plan AvgPrice = %v, want 0", avg)
+	}
+	if avg := (MarketOrderPlan{Amount: 4, Total: 10}).AvgPrice(); avg != 2.5 {
+		t.Errorf("AvgPrice = %v, want 2.5", avg)
+	}
+}
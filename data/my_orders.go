@@ -0,0 +1,258 @@
+package loud
+
+import (
+	"sort"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+const (
+	MYORD_BUY_GOLD  = "buy gold"
+	MYORD_SELL_GOLD = "sell gold"
+	MYORD_BUY_ITEM  = "buy item"
+	MYORD_SELL_ITEM = "sell item"
+	MYORD_BUY_CHAR  = "buy character"
+	MYORD_SELL_CHAR = "sell character"
+	MYORD_BARTER    = "barter"
+)
+
+// MyOrder is one of my open trade requests in any market
+type MyOrder struct {
+	ID             string
+	Market         string
+	TrdReq         interface{} // TrdReq, ItemBuyTrdReq, ItemSellTrdReq, CharacterBuyTrdReq, CharacterSellTrdReq or BarterTrdReq
+	FirstSeenBlock int64       // chain does not keep creation block of trades
+}
+
+// MyOrderFill is my order which was fulfilled by another user
+type MyOrderFill struct {
+	Order     MyOrder
+	FulFiller string
+	Block     int64
+}
+
+// MyOrderCancelResult is the result of cancelling one of my orders
+type MyOrderCancelResult struct {
+	Order      MyOrder
+	TxHash     string
+	FailReason string
+}
+
+// MyOrderFills is all fills of my orders detected during this session, oldest first
+var MyOrderFills = []MyOrderFill{}
+
+// LastMyOrderFills is fills of my orders detected during the latest sync
+var LastMyOrderFills = []MyOrderFill{}
+
+// MyOrderSnapshot is my open orders of the latest sync, it's stored per user
+// so orders filled while the game was closed are reported on the next start
+type MyOrderSnapshot struct {
+	Orders    []StoredMyOrder
+	FirstSeen map[string]int64 // first seen block height by order ID
+}
+
+// StoredMyOrder is a MyOrder with its trade request in a typed field, so it can be serialized
+type StoredMyOrder struct {
+	ID             string
+	Market         string
+	FirstSeenBlock int64
+	Gold           *TrdReq              `json:",omitempty"`
+	ItemBuy        *ItemBuyTrdReq       `json:",omitempty"`
+	ItemSell       *ItemSellTrdReq      `json:",omitempty"`
+	CharacterBuy   *CharacterBuyTrdReq  `json:",omitempty"`
+	CharacterSell  *CharacterSellTrdReq `json:",omitempty"`
+	Barter         *BarterTrdReq        `json:",omitempty"`
+}
+
+// storedMyOrder converts order to its serializable form
+func storedMyOrder(order MyOrder) StoredMyOrder {
+	stored := StoredMyOrder{
+		ID:             order.ID,
+		Market:         order.Market,
+		FirstSeenBlock: order.FirstSeenBlock,
+	}
+	switch request := order.TrdReq.(type) {
+	case TrdReq:
+		stored.Gold = &request
+	case ItemBuyTrdReq:
+		stored.ItemBuy = &request
+	case ItemSellTrdReq:
+		stored.ItemSell = &request
+	case CharacterBuyTrdReq:
+		stored.CharacterBuy = &request
+	case CharacterSellTrdReq:
+		stored.CharacterSell = &request
+	case BarterTrdReq:
+		stored.Barter = &request
+	}
+	return stored
+}
+
+// MyOrder converts stored order back
+func (stored StoredMyOrder) MyOrder() MyOrder {
+	order := MyOrder{
+		ID:             stored.ID,
+		Market:         stored.Market,
+		FirstSeenBlock: stored.FirstSeenBlock,
+	}
+	switch {
+	case stored.Gold != nil:
+		order.TrdReq = *stored.Gold
+	case stored.ItemBuy != nil:
+		order.TrdReq = *stored.ItemBuy
+	case stored.ItemSell != nil:
+		order.TrdReq = *stored.ItemSell
+	case stored.CharacterBuy != nil:
+		order.TrdReq = *stored.CharacterBuy
+	case stored.CharacterSell != nil:
+		order.TrdReq = *stored.CharacterSell
+	case stored.Barter != nil:
+		order.TrdReq = *stored.Barter
+	}
+	return order
+}
+
+var myOrderSnapshot = map[string]MyOrder{}
+var myOrderSnapshotOwner = ""
+var myOrderFirstSeen = map[string]int64{}
+
+// loadMyOrderSnapshot starts from the snapshot stored for user by the previous session
+func loadMyOrderSnapshot(user User) {
+	myOrderSnapshot = map[string]MyOrder{}
+	myOrderFirstSeen = map[string]int64{}
+	myOrderSnapshotOwner = user.GetAddress()
+	stored := user.GetMyOrderSnapshot()
+	for _, order := range stored.Orders {
+		myOrderSnapshot[order.ID] = order.MyOrder()
+	}
+	for id, height := range stored.FirstSeen {
+		myOrderFirstSeen[id] = height
+	}
+}
+
+// MyOrders collects all my open trade requests from every market, newest first
+func MyOrders() []MyOrder {
+	orders := []MyOrder{}
+	add := func(id string, market string, trdReq interface{}) {
+		orders = append(orders, MyOrder{
+			ID:             id,
+			Market:         market,
+			TrdReq:         trdReq,
+			FirstSeenBlock: myOrderFirstSeen[id],
+		})
+	}
+	for _, request := range BuyTrdReqs {
+		if request.IsMyTrdReq {
+			add(request.ID, MYORD_BUY_GOLD, request)
+		}
+	}
+	for _, request := range SellTrdReqs {
+		if request.IsMyTrdReq {
+			add(request.ID, MYORD_SELL_GOLD, request)
+		}
+	}
+	for _, request := range ItemBuyTrdReqs {
+		if request.IsMyTrdReq {
+			add(request.ID, MYORD_BUY_ITEM, request)
+		}
+	}
+	for _, request := range ItemSellTrdReqs {
+		if request.IsMyTrdReq {
+			add(request.ID, MYORD_SELL_ITEM, request)
+		}
+	}
+	for _, request := range CharacterBuyTrdReqs {
+		if request.IsMyTrdReq {
+			add(request.ID, MYORD_BUY_CHAR, request)
+		}
+	}
+	for _, request := range CharacterSellTrdReqs {
+		if request.IsMyTrdReq {
+			add(request.ID, MYORD_SELL_CHAR, request)
+		}
+	}
+	for _, request := range BarterTrdReqs {
+		if request.IsMyTrdReq {
+			add(request.ID, MYORD_BARTER, request)
+		}
+	}
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].FirstSeenBlock > orders[j].FirstSeenBlock
+	})
+	return orders
+}
+
+// UpdateMyOrders compares my open orders with the snapshot of previous sync
+// and records orders which disappeared because they were completed
+func UpdateMyOrders(user User, trades []types.Trade, blockHeight int64) {
+	LastMyOrderFills = []MyOrderFill{}
+	if myOrderSnapshotOwner != user.GetAddress() {
+		// do not report orders of previous user as filled, and report the ones filled while the game was closed
+		loadMyOrderSnapshot(user)
+	}
+	tradesByID := make(map[string]types.Trade)
+	for _, trade := range trades {
+		tradesByID[trade.ID] = trade
+	}
+	current := make(map[string]MyOrder)
+	for _, order := range MyOrders() {
+		if _, ok := myOrderFirstSeen[order.ID]; !ok {
+			myOrderFirstSeen[order.ID] = blockHeight
+			order.FirstSeenBlock = blockHeight
+		}
+		current[order.ID] = order
+	}
+	for id, order := range myOrderSnapshot {
+		if _, ok := current[id]; ok {
+			continue
+		}
+		trade, ok := tradesByID[id]
+		if !ok || !trade.Completed {
+			continue // cancelled
+		}
+		fill := MyOrderFill{
+			Order:     order,
+			FulFiller: trade.FulFiller.String(),
+			Block:     blockHeight,
+		}
+		LastMyOrderFills = append(LastMyOrderFills, fill)
+	}
+	sort.SliceStable(LastMyOrderFills, func(i, j int) bool {
+		return LastMyOrderFills[i].Order.FirstSeenBlock < LastMyOrderFills[j].Order.FirstSeenBlock
+	})
+	MyOrderFills = append(MyOrderFills, LastMyOrderFills...)
+	myOrderSnapshot = current
+
+	stored := MyOrderSnapshot{FirstSeen: make(map[string]int64)}
+	for id, order := range current {
+		stored.Orders = append(stored.Orders, storedMyOrder(order))
+		stored.FirstSeen[id] = myOrderFirstSeen[id]
+	}
+	user.SetMyOrderSnapshot(stored)
+}
+
+// CancelMyOrders cancels orders and waits for all of them to be processed
+func CancelMyOrders(user User, orders []MyOrder) []MyOrderCancelResult {
+	results := []MyOrderCancelResult{}
+	txhashes := []string{}
+	for _, order := range orders {
+		txhash, err := CancelTrade(user, order.ID)
+		result := MyOrderCancelResult{
+			Order:  order,
+			TxHash: txhash,
+		}
+		if err != nil {
+			result.FailReason = err.Error()
+		} else {
+			txhashes = append(txhashes, txhash)
+		}
+		results = append(results, result)
+	}
+	failReasons := ProcessTxResults(user, txhashes)
+	for idx := range results {
+		if reason, ok := failReasons[results[idx].TxHash]; ok {
+			results[idx].FailReason = reason
+		}
+	}
+	return results
+}
@@ -1,5 +1,9 @@
 package loud
 
+import (
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
 type TrdReq struct {
 	ID         string
 	Price      float64
@@ -18,6 +22,7 @@ type ItemSellTrdReq struct {
 type ItemBuyTrdReq struct {
 	ID         string
 	TItem      ItemSpec
+	ItemInputs types.ItemInputList // raw item inputs to match inventory items on fulfill
 	Price      int
 	IsMyTrdReq bool
 }
@@ -32,13 +37,29 @@ type CharacterSellTrdReq struct {
 type CharacterBuyTrdReq struct {
 	ID         string
 	TCharacter CharacterSpec
+	ItemInputs types.ItemInputList // raw item inputs to match inventory characters on fulfill
 	Price      int
 	IsMyTrdReq bool
 }
 
+// BarterTrdReq is a trade request exchanging several items and coins on each side
+// creator offers OfferItems and offer coins, and wants WantItems and want coins in return
+type BarterTrdReq struct {
+	ID         string
+	OfferItems []Item
+	OfferPylon int
+	OfferGold  int
+	WantItems  []ItemSpec
+	ItemInputs types.ItemInputList // raw item inputs for WantItems
+	WantPylon  int
+	WantGold   int
+	IsMyTrdReq bool
+}
+
 var BuyTrdReqs = []TrdReq{}
 var SellTrdReqs = []TrdReq{}
 var ItemBuyTrdReqs = []ItemBuyTrdReq{}
 var ItemSellTrdReqs = []ItemSellTrdReq{}
 var CharacterBuyTrdReqs = []CharacterBuyTrdReq{}
 var CharacterSellTrdReqs = []CharacterSellTrdReq{}
+var BarterTrdReqs = []BarterTrdReq{}
@@ -0,0 +1,97 @@
+package loud
+
+import (
+	"sort"
+)
+
+// OrderBookLevel is the sum of all gold/pylon trade requests at one price
+type OrderBookLevel struct {
+	Price       float64
+	Amount      int // gold on this level
+	Total       int // pylons on this level
+	Depth       int // cumulative gold from the best price to this level
+	NumTrdReqs  int
+	HasMyTrdReq bool
+}
+
+// OrderBook is the aggregated view of BuyTrdReqs (bids) and SellTrdReqs (asks)
+// bids are sorted from the highest price, asks from the lowest price
+type OrderBook struct {
+	Bids []OrderBookLevel
+	Asks []OrderBookLevel
+}
+
+func aggregateTrdReqs(requests []TrdReq, highFirst bool) []OrderBookLevel {
+	levels := []OrderBookLevel{}
+	levelIdx := map[float64]int{}
+	for _, request := range requests {
+		idx, ok := levelIdx[request.Price]
+		if !ok {
+			idx = len(levels)
+			levelIdx[request.Price] = idx
+			levels = append(levels, OrderBookLevel{Price: request.Price})
+		}
+		levels[idx].Amount += request.Amount
+		levels[idx].Total += request.Total
+		levels[idx].NumTrdReqs++
+		levels[idx].HasMyTrdReq = levels[idx].HasMyTrdReq || request.IsMyTrdReq
+	}
+	sort.SliceStable(levels, func(i, j int) bool {
+		if highFirst {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+	depth := 0
+	for idx := range levels {
+		depth += levels[idx].Amount
+		levels[idx].Depth = depth
+	}
+	return levels
+}
+
+// BuildOrderBook aggregates gold buy requests and gold sell requests by price level
+func BuildOrderBook(buyTrdReqs []TrdReq, sellTrdReqs []TrdReq) OrderBook {
+	return OrderBook{
+		Bids: aggregateTrdReqs(buyTrdReqs, true),
+		Asks: aggregateTrdReqs(sellTrdReqs, false),
+	}
+}
+
+// BestBid returns the highest price someone is paying for gold
+func (ob OrderBook) BestBid() (float64, bool) {
+	if len(ob.Bids) == 0 {
+		return 0, false
+	}
+	return ob.Bids[0].Price, true
+}
+
+// BestAsk returns the lowest price someone is selling gold at
+func (ob OrderBook) BestAsk() (float64, bool) {
+	if len(ob.Asks) == 0 {
+		return 0, false
+	}
+	return ob.Asks[0].Price, true
+}
+
+// Spread returns the gap between best ask and best bid
+func (ob OrderBook) Spread() (float64, bool) {
+	bid, bidOk := ob.BestBid()
+	ask, askOk := ob.BestAsk()
+	if !bidOk || !askOk {
+		return 0, false
+	}
+	return ask - bid, true
+}
+
+// MaxDepth returns the biggest cumulative depth of both sides, used to scale depth bars
+func (ob OrderBook) MaxDepth() int {
+	maxDepth := 0
+	if len(ob.Bids) > 0 {
+		maxDepth = ob.Bids[len(ob.Bids)-1].Depth
+	}
+	if len(ob.Asks) > 0 && ob.Asks[len(ob.Asks)-1].Depth > maxDepth {
+		maxDepth = ob.Asks[len(ob.Asks)-1].Depth
+	}
+	return maxDepth
+}
@@ -0,0 +1,134 @@
+package loud
+
+import (
+	"errors"
+	"math"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// ProgressionRecipes is the forest recipes shown on progression panel, from the easiest one
+var ProgressionRecipes = []string{
+	RCP_HUNT_RABBITS_NOSWORD,
+	RCP_FIGHT_GOBLIN,
+	RCP_FIGHT_WOLF,
+	RCP_FIGHT_TROLL,
+	RCP_FIGHT_GIANT,
+	RCP_FIGHT_DRAGONFIRE,
+	RCP_FIGHT_DRAGONICE,
+	RCP_FIGHT_DRAGONACID,
+	RCP_FIGHT_DRAGONUNDEAD,
+}
+
+// RecipeProgress is what a forest recipe would give to the character and whether it can be run
+type RecipeProgress struct {
+	Action      string // recipe name
+	Synced      bool
+	XP          float64 // XP granted by the upgrader when the character survives
+	LevelAfter  int
+	CharacterOK bool
+	NeedsWeapon bool
+	WeaponOK    bool
+}
+
+// Progression is the progression panel data of a character
+type Progression struct {
+	Level        int
+	XP           float64
+	NextLevelXP  float64 // XP at which the next fight levels up the character
+	HasNextLevel bool
+	Recipes      []RecipeProgress
+}
+
+// characterUpgrader returns the modify params applied to the character when it survives the recipe
+func characterUpgrader(rcp types.Recipe) (types.ModifyItemType, bool) {
+	for _, entry := range rcp.Entries {
+		if io, ok := entry.(types.ItemOutput); ok && io.ModifyItem.ItemInputRef == 0 {
+			return io.ModifyItem, true
+		}
+	}
+	return types.ModifyItemType{}, false
+}
+
+func upgraderLevelProgram(modify types.ModifyItemType) string {
+	for _, param := range modify.Longs {
+		if param.Key == "level" {
+			return param.Program
+		}
+	}
+	return ""
+}
+
+func evalLevel(program string, vars ProgramVars, xp float64) (int, error) {
+	nVars := ProgramVars{}
+	for k, v := range vars {
+		nVars[k] = v
+	}
+	nVars["XP"] = ProgramValue{xp, false}
+	nVars["input0.XP"] = ProgramValue{xp, false}
+	level, err := EvalProgram(program, nVars)
+	return int(level), err
+}
+
+// nextLevelXP finds the lowest XP which makes level program return a higher level
+func nextLevelXP(program string, vars ProgramVars, character *Character) (float64, bool) {
+	maxXP := 10000000.0 // max XP of character item input
+	if level, err := evalLevel(program, vars, maxXP); err != nil || level <= character.Level {
+		return 0, false
+	}
+	if level, err := evalLevel(program, vars, character.XP); err == nil && level > character.Level {
+		return character.XP, true
+	}
+	lo, hi := character.XP, maxXP
+	for hi-lo > 0.5 {
+		mid := (lo + hi) / 2
+		if level, _ := evalLevel(program, vars, mid); level > character.Level {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	if level, _ := evalLevel(program, vars, math.Floor(hi)); level > character.Level {
+		return math.Floor(hi), true
+	}
+	return math.Ceil(hi), true
+}
+
+// CharacterProgression reads upgrader programs of deployed forest recipes to compute progression of the character
+func CharacterProgression(character *Character, weapon *Item) (Progression, error) {
+	progression := Progression{}
+	if character == nil {
+		return progression, errors.New("character is required")
+	}
+	progression.Level = character.Level
+	progression.XP = character.XP
+	vars := recipeProgramVars([]ProgramVars{characterProgramVars(character)})
+
+	for _, rcpName := range ProgressionRecipes {
+		rp := RecipeProgress{Action: rcpName, LevelAfter: character.Level}
+		rcp, ok := LoudRecipes[RcpIDs[rcpName]]
+		if ok {
+			rp.Synced = true
+			rp.CharacterOK = len(rcp.ItemInputs) > 0 && CharacterMatchesInput(*character, rcp.ItemInputs[0])
+			rp.NeedsWeapon = len(rcp.ItemInputs) > 1
+			rp.WeaponOK = rp.NeedsWeapon && weapon != nil && ItemMatchesInput(*weapon, rcp.ItemInputs[1])
+			if modify, ok := characterUpgrader(rcp); ok {
+				xp, _, err := characterModifyResult(modify, characterProgramVars(character), vars)
+				if err != nil {
+					return progression, err
+				}
+				rp.XP = xp
+				if program := upgraderLevelProgram(modify); len(program) > 0 {
+					if level, err := evalLevel(program, vars, character.XP); err == nil {
+						rp.LevelAfter = level
+					}
+					if !progression.HasNextLevel {
+						progression.NextLevelXP, progression.HasNextLevel = nextLevelXP(program, vars, character)
+					}
+				}
+			}
+		}
+		progression.Recipes = append(progression.Recipes, rp)
+	}
+	return progression, nil
+}
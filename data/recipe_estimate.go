@@ -0,0 +1,284 @@
+package loud
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Pylons-tech/LOUD/log"
+	pylonSDK "github.com/Pylons-tech/pylons_sdk/cmd/test"
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// LoudRecipes is the deployed LOUD recipes keyed by recipe ID, loaded on the first sync and
+// reloaded only after MarkLoudRecipesStale
+var LoudRecipes = map[string]types.Recipe{}
+
+// loudRecipesStale is set when LoudRecipes needs to be loaded again on next sync
+var loudRecipesStale = true
+
+// FightOutcome is a single weighted output of a recipe
+type FightOutcome struct {
+	Chance        float64
+	Gold          float64
+	XP            float64
+	CharacterLost bool
+	WeaponLost    bool
+	ArmorLost     bool
+	Drop          string
+	Special       bool
+	Revived       bool // revive scroll is used instead of losing the character
+}
+
+// FightEstimate is the outcome distribution of a recipe for a character, weapon and armor
+type FightEstimate struct {
+	Outcomes       []FightOutcome
+	ExpectedGold   float64
+	ExpectedXP     float64
+	DropName       string
+	DropChance     float64
+	SpecialChance  float64
+	SpecialChances map[int]float64
+	HasWeapon      bool
+	HasArmor       bool
+	Consumable     string
+}
+
+// UpdateLoudRecipes loads the recipes of LOUD cookbook from the node
+func UpdateLoudRecipes() {
+	rawRecipes, err := pylonSDK.ListRecipesViaCLI("")
+	if err != nil {
+		log.Println("couldn't list recipes", err)
+		return
+	}
+	nRecipes := map[string]types.Recipe{}
+	for _, rcp := range rawRecipes {
+		if rcp.CookbookID == LOUD_CBID && !rcp.Disabled {
+			nRecipes[rcp.ID] = rcp
+		}
+	}
+	LoudRecipes = nRecipes
+	loudRecipesStale = false
+}
+
+// MarkLoudRecipesStale makes next sync reload the recipes, used when the cookbook changes or on explicit refresh
+func MarkLoudRecipesStale() {
+	loudRecipesStale = true
+}
+
+func characterProgramVars(ch *Character) ProgramVars {
+	return ProgramVars{
+		"XP":                {ch.XP, false},
+		"level":             {float64(ch.Level), true},
+		"GiantKill":         {float64(ch.GiantKill), true},
+		"Special":           {float64(ch.Special), true},
+		"SpecialDragonKill": {float64(ch.SpecialDragonKill), true},
+		"UndeadDragonKill":  {float64(ch.UndeadDragonKill), true},
+	}
+}
+
+func weaponProgramVars(item *Item) ProgramVars {
+	return ProgramVars{
+		"attack": {float64(item.Attack), false},
+		"level":  {float64(item.Level), true},
+	}
+}
+
+func armorProgramVars(item *Item) ProgramVars {
+	return ProgramVars{
+		"defense": {float64(item.Defense), true},
+		"level":   {float64(item.Level), true},
+	}
+}
+
+// recipeProgramVars binds inputN.key for every input and bare keys for the first input
+func recipeProgramVars(inputs []ProgramVars) ProgramVars {
+	vars := ProgramVars{}
+	for idx, input := range inputs {
+		for k, v := range input {
+			if idx == 0 {
+				vars[k] = v
+			}
+			vars[fmt.Sprintf("input%d.%s", idx, k)] = v
+		}
+	}
+	return vars
+}
+
+// EstimateRecipe computes the outcome odds, expected gold and XP of a hunt or fight recipe
+func EstimateRecipe(rcpName string, character *Character, weapon *Item, armor *Item) (FightEstimate, error) {
+	estimate := FightEstimate{SpecialChances: map[int]float64{}}
+	rcp, ok := LoudRecipes[RcpIDs[rcpName]]
+	if !ok {
+		return estimate, errors.New("recipe is not synced yet")
+	}
+	if len(rcp.ItemInputs) == 0 {
+		return estimate, errors.New("recipe has no character input")
+	}
+	if character == nil {
+		return estimate, errors.New("character is required")
+	}
+	inputs := []ProgramVars{characterProgramVars(character)}
+	weaponRef, armorRef, consumableRef := -1, -1, -1
+	for idx, ii := range rcp.ItemInputs[1:] {
+		name, itemType := itemInputType(ii)
+		switch itemType {
+		case CONSUMABLE_TYPE:
+			consumableRef = idx + 1
+			estimate.Consumable = name
+			inputs = append(inputs, ProgramVars{})
+		case ARMOR_TYPE:
+			if armor == nil {
+				return estimate, errors.New("armor is required")
+			}
+			armorRef = idx + 1
+			inputs = append(inputs, armorProgramVars(armor))
+			estimate.HasArmor = true
+		default:
+			if weapon == nil {
+				return estimate, errors.New("weapon is required")
+			}
+			weaponRef = idx + 1
+			inputs = append(inputs, weaponProgramVars(weapon))
+			estimate.HasWeapon = true
+		}
+	}
+	vars := recipeProgramVars(inputs)
+
+	weights := []float64{}
+	totalWeight := 0.0
+	for _, wo := range rcp.Outputs {
+		w, err := EvalProgram(wo.Weight, vars)
+		if err != nil {
+			return estimate, err
+		}
+		if w < 0 {
+			w = 0
+		}
+		weights = append(weights, w)
+		totalWeight += w
+	}
+	if totalWeight <= 0 {
+		return estimate, errors.New("recipe has no weighted outputs")
+	}
+
+	for idx, wo := range rcp.Outputs {
+		outcome := FightOutcome{
+			Chance:        weights[idx] / totalWeight,
+			CharacterLost: true,
+			WeaponLost:    estimate.HasWeapon,
+			ArmorLost:     estimate.HasArmor,
+		}
+		consumableKept := false
+		for _, entryIdx := range wo.ResultEntries {
+			if entryIdx < 0 || entryIdx >= len(rcp.Entries) {
+				continue
+			}
+			switch entry := rcp.Entries[entryIdx].(type) {
+			case types.CoinOutput:
+				if entry.Coin != "loudcoin" {
+					continue
+				}
+				gold, err := EvalProgram(entry.Count, vars)
+				if err != nil {
+					return estimate, err
+				}
+				outcome.Gold += gold
+			case types.ItemOutput:
+				switch entry.ModifyItem.ItemInputRef {
+				case -1:
+					outcome.Drop = itemOutputName(entry)
+				case weaponRef:
+					outcome.WeaponLost = false
+				case armorRef:
+					outcome.ArmorLost = false
+				case consumableRef:
+					consumableKept = true
+				case 0:
+					outcome.CharacterLost = false
+					xp, special, err := characterModifyResult(entry.ModifyItem, inputs[0], vars)
+					if err != nil {
+						return estimate, err
+					}
+					outcome.XP = xp
+					for sp, w := range special {
+						outcome.Special = true
+						estimate.SpecialChances[sp] += outcome.Chance * w
+					}
+				}
+			}
+		}
+		outcome.Revived = estimate.Consumable == REVIVE_SCROLL && !consumableKept && !outcome.CharacterLost
+		estimate.Outcomes = append(estimate.Outcomes, outcome)
+		estimate.ExpectedGold += outcome.Chance * outcome.Gold
+		estimate.ExpectedXP += outcome.Chance * outcome.XP
+		if len(outcome.Drop) > 0 {
+			estimate.DropName = outcome.Drop
+			estimate.DropChance += outcome.Chance
+		}
+		if outcome.Special {
+			estimate.SpecialChance += outcome.Chance
+		}
+	}
+	return estimate, nil
+}
+
+// itemInputType returns the name and the type strings which item input requires
+func itemInputType(ii types.ItemInput) (string, string) {
+	name, itemType := "", ""
+	for _, param := range ii.Strings {
+		switch param.Key {
+		case "Name":
+			name = param.Value
+		case "Type":
+			itemType = param.Value
+		}
+	}
+	return name, itemType
+}
+
+func itemOutputName(io types.ItemOutput) string {
+	for _, param := range io.Strings {
+		if param.Key == "Name" {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+// characterModifyResult returns the XP gained and the chance of each Special value set by a character modify output
+func characterModifyResult(modify types.ModifyItemType, character ProgramVars, inputVars ProgramVars) (float64, map[int]float64, error) {
+	vars := ProgramVars{}
+	for k, v := range inputVars {
+		vars[k] = v
+	}
+	for k, v := range character {
+		vars[k] = v
+	}
+	xp := 0.0
+	for _, param := range modify.Doubles {
+		if param.Key != "XP" || len(param.Program) == 0 {
+			continue
+		}
+		newXP, err := EvalProgram(param.Program, vars)
+		if err != nil {
+			return 0, nil, err
+		}
+		xp = newXP - character["XP"].Value
+	}
+	special := map[int]float64{}
+	for _, param := range modify.Longs {
+		if param.Key != "Special" || len(param.Program) > 0 {
+			continue
+		}
+		totalWeight := 0
+		for _, wr := range param.WeightRanges {
+			totalWeight += wr.Weight
+		}
+		for _, wr := range param.WeightRanges {
+			if totalWeight > 0 && wr.Lower == wr.Upper {
+				special[wr.Lower] += float64(wr.Weight) / float64(totalWeight)
+			}
+		}
+	}
+	return xp, special, nil
+}
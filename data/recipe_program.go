@@ -0,0 +1,206 @@
+package loud
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ProgramValue is a number used while evaluating recipe programs
+type ProgramValue struct {
+	Value float64
+	IsInt bool
+}
+
+// ProgramVars maps program variable names (eg. XP, input1.attack) to values
+type ProgramVars map[string]ProgramValue
+
+type programParser struct {
+	tokens []string
+	pos    int
+	vars   ProgramVars
+}
+
+// EvalProgram evaluates the arithmetic subset of recipe programs (weights, coin counts, item modify params).
+// Random functions evaluate to their expected value.
+func EvalProgram(program string, vars ProgramVars) (float64, error) {
+	tokens, err := tokenizeProgram(program)
+	if err != nil {
+		return 0, err
+	}
+	if len(tokens) == 0 {
+		return 0, errors.New("empty program")
+	}
+	p := &programParser{tokens: tokens, vars: vars}
+	v, err := p.expr()
+	if err != nil {
+		return 0, err
+	}
+	if p.pos < len(p.tokens) {
+		return 0, fmt.Errorf("unexpected token %s in program %s", p.tokens[p.pos], program)
+	}
+	return v.Value, nil
+}
+
+func tokenizeProgram(program string) ([]string, error) {
+	tokens := []string{}
+	runes := []rune(program)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("+-*/(),", r):
+			tokens = append(tokens, string(r))
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		default:
+			return nil, fmt.Errorf("unsupported character %q in program %s", r, program)
+		}
+	}
+	return tokens, nil
+}
+
+func (p *programParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *programParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *programParser) expect(t string) error {
+	if p.next() != t {
+		return fmt.Errorf("%s expected", t)
+	}
+	return nil
+}
+
+func (p *programParser) expr() (ProgramValue, error) {
+	left, err := p.term()
+	if err != nil {
+		return left, err
+	}
+	for p.peek() == "+" || p.peek() == "-" {
+		op := p.next()
+		right, err := p.term()
+		if err != nil {
+			return right, err
+		}
+		if op == "+" {
+			left = ProgramValue{left.Value + right.Value, left.IsInt && right.IsInt}
+		} else {
+			left = ProgramValue{left.Value - right.Value, left.IsInt && right.IsInt}
+		}
+	}
+	return left, nil
+}
+
+func (p *programParser) term() (ProgramValue, error) {
+	left, err := p.unary()
+	if err != nil {
+		return left, err
+	}
+	for p.peek() == "*" || p.peek() == "/" {
+		op := p.next()
+		right, err := p.unary()
+		if err != nil {
+			return right, err
+		}
+		isInt := left.IsInt && right.IsInt
+		if op == "*" {
+			left = ProgramValue{left.Value * right.Value, isInt}
+			continue
+		}
+		if right.Value == 0 {
+			return left, errors.New("division by zero")
+		}
+		v := left.Value / right.Value
+		if isInt {
+			v = math.Trunc(v)
+		}
+		left = ProgramValue{v, isInt}
+	}
+	return left, nil
+}
+
+func (p *programParser) unary() (ProgramValue, error) {
+	if p.peek() == "-" {
+		p.next()
+		v, err := p.unary()
+		return ProgramValue{-v.Value, v.IsInt}, err
+	}
+	return p.primary()
+}
+
+func (p *programParser) primary() (ProgramValue, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return ProgramValue{}, errors.New("unexpected end of program")
+	case t == "(":
+		v, err := p.expr()
+		if err != nil {
+			return v, err
+		}
+		return v, p.expect(")")
+	case unicode.IsDigit([]rune(t)[0]) || t[0] == '.':
+		v, err := strconv.ParseFloat(t, 64)
+		return ProgramValue{v, !strings.Contains(t, ".")}, err
+	case p.peek() == "(":
+		return p.call(t)
+	}
+	v, ok := p.vars[t]
+	if !ok {
+		return v, fmt.Errorf("unknown variable %s", t)
+	}
+	return v, nil
+}
+
+func (p *programParser) call(fn string) (ProgramValue, error) {
+	p.next()
+	args := []ProgramValue{}
+	for p.peek() != ")" {
+		arg, err := p.expr()
+		if err != nil {
+			return arg, err
+		}
+		args = append(args, arg)
+		if p.peek() == "," {
+			p.next()
+		}
+	}
+	p.next()
+	switch {
+	case fn == "int" && len(args) == 1:
+		return ProgramValue{math.Trunc(args[0].Value), true}, nil
+	case fn == "double" && len(args) == 1:
+		return ProgramValue{args[0].Value, false}, nil
+	case fn == "rand_int" && len(args) == 1:
+		return ProgramValue{(args[0].Value - 1) / 2, false}, nil
+	case fn == "rand" && len(args) == 0:
+		return ProgramValue{0.5, false}, nil
+	}
+	return ProgramValue{}, fmt.Errorf("unsupported function %s", fn)
+}
@@ -0,0 +1,205 @@
+package loud
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+// testRecipeVars is the character and inputs the expected values below are computed for
+var testRecipeVars = ProgramVars{
+	"level":             {3, true},
+	"XP":                {100, false},
+	"attack":            {2.5, false},
+	"GiantKill":         {2, true},
+	"SpecialDragonKill": {0, true},
+	"UndeadDragonKill":  {1, true},
+	"input2.defense":    {5, true},
+}
+
+// testRecipeValues is hand computed values of every weight and program in test/recipes
+var testRecipeValues = map[string]float64{
+	"0":      0,
+	"0.0":    0,
+	"1":      1,
+	"2":      2,
+	"3":      3,
+	"3.0":    3,
+	"4":      4,
+	"5":      5,
+	"6.0":    6,
+	"10":     10,
+	"10.0":   10,
+	"20":     20,
+	"20.0":   20,
+	"30.0":   30,
+	"50.0":   50,
+	"80":     80,
+	"81":     81,
+	"82":     82,
+	"83":     83,
+	"84":     84,
+	"85":     85,
+	"86":     86,
+	"95":     95,
+	"96":     96,
+	"99":     99,
+	"100":    100,
+	"100.0":  100,
+	"800":    800,
+	"810":    810,
+	"820":    820,
+	"830":    830,
+	"840":    840,
+	"850":    850,
+	"860":    860,
+	"1000.0": 1000,
+	// 3 + int(100 / 32.0) = 3 + int(3.125)
+	"level + int(XP / double(level * level * level + 5))": 6,
+	"level + 1":               4,
+	"attack * 2.0":            5,
+	"GiantKill+1":             3,
+	"SpecialDragonKill+1":     1,
+	"UndeadDragonKill+1":      2,
+	"XP + 1.0":                101,
+	"XP + double(10 * 1)":     110,
+	"XP + double(15 * 3)":     145,
+	"XP + double(20 * 5)":     200,
+	"XP + double(100 * 10)":   1100,
+	"XP + double(300 * 30)":   9100,
+	"XP + double(1000 * 100)": 100100,
+	// integer division truncates, eg. 200 / 15 = 13
+	"int(10 * 10 / (10 + input2.defense))": 6,
+	"int(20 * 10 / (10 + input2.defense))": 13,
+	"int(30 * 10 / (10 + input2.defense))": 20,
+	"int(40 * 10 / (10 + input2.defense))": 26,
+	"int(50 * 10 / (10 + input2.defense))": 33,
+}
+
+// collectPrograms appends Weight and Program strings found anywhere in a recipe json
+func collectPrograms(v interface{}, programs map[string]bool) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if s, ok := field.(string); ok && (key == "Weight" || key == "Program") {
+				programs[s] = true
+				continue
+			}
+			collectPrograms(field, programs)
+		}
+	case []interface{}:
+		for _, field := range value {
+			collectPrograms(field, programs)
+		}
+	}
+}
+
+func TestEvalProgramRecipes(t *testing.T) {
+	programs := map[string]bool{}
+	err := filepath.Walk("test/recipes", func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".json") {
+			return err
+		}
+		bytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var recipe interface{}
+		if err := json.Unmarshal(bytes, &recipe); err != nil {
+			t.Errorf("%s: %v", path, err)
+			return nil
+		}
+		collectPrograms(recipe, programs)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(programs) == 0 {
+		t.Fatal("no programs found in test/recipes")
+	}
+	for program := range programs {
+		want, ok := testRecipeValues[program]
+		if !ok {
+			t.Errorf("no expected value for %q, add it to testRecipeValues", program)
+			continue
+		}
+		got, err := EvalProgram(program, testRecipeVars)
+		if err != nil {
+			t.Errorf("EvalProgram(%q) error: %v", program, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("EvalProgram(%q) = %v, want %v", program, got, want)
+		}
+	}
+}
+
+func TestEvalProgram(t *testing.T) {
+	vars := ProgramVars{
+		"level":          {4, true},
+		"XP":             {10, false},
+		"input2.defense": {0, true},
+	}
+	tests := []struct {
+		program string
+		want    float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"7 / 2", 3},
+		{"7.0 / 2", 3.5},
+		{"7 / double(2)", 3.5},
+		{"-7 / 2", -3},
+		{"int(-3.5)", -3},
+		{"- -2", 2},
+		{"int(30 * 10 / (10 + input2.defense))", 30},
+		{"XP / level", 2.5},
+		{"level / 3 * 3", 3},
+		{"rand_int(11)", 5},
+		{"rand()", 0.5},
+	}
+	for _, tt := range tests {
+		got, err := EvalProgram(tt.program, vars)
+		if err != nil {
+			t.Errorf("EvalProgram(%q) error: %v", tt.program, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("EvalProgram(%q) = %v, want %v", tt.program, got, tt.want)
+		}
+	}
+}
+
+func TestEvalProgramErrors(t *testing.T) {
+	for _, program := range []string{
+		"",
+		"level",
+		"1 +",
+		"(1 + 2",
+		"1 2",
+		"1 / 0",
+		"pow(2, 3)",
+		"1 % 2",
+	} {
+		if got, err := EvalProgram(program, ProgramVars{}); err == nil {
+			t.Errorf("EvalProgram(%q) = %v, want error", program, got)
+		}
+	}
+}
+
+func TestEstimateRecipeWithoutItemInputs(t *testing.T) {
+	prevRecipes := LoudRecipes
+	defer func() { LoudRecipes = prevRecipes }()
+	LoudRecipes = map[string]types.Recipe{RcpIDs[RCP_HUNT_RABBITS_NOSWORD]: {ID: RcpIDs[RCP_HUNT_RABBITS_NOSWORD]}}
+
+	if _, err := EstimateRecipe(RCP_HUNT_RABBITS_NOSWORD, &Character{Level: 1}, nil, nil); err == nil {
+		t.Error("EstimateRecipe of a recipe without item inputs should fail")
+	}
+}
@@ -0,0 +1,122 @@
+package loud
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// RepeatHuntRule is the stop conditions of repeat hunt mode
+// repeat hunt always stops when the character or the sword is lost
+type RepeatHuntRule struct {
+	Query     string // raw rule entered by user
+	MaxRuns   int    // 0 means no limit
+	GoldGoal  int    // 0 means no limit
+	LevelGoal int    // 0 means no limit
+	DropName  string
+}
+
+// RepeatHuntProgress is the running totals of repeat hunt mode
+type RepeatHuntProgress struct {
+	Rule          RepeatHuntRule
+	Runs          int
+	Gold          int64
+	XP            float64
+	Drops         []string
+	CharacterLost bool
+	WeaponLost    bool
+	StopRequested bool
+	StopReason    string
+}
+
+func parsePositiveInt(value string) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v <= 0 {
+		return 0, errors.New(value + " is not a positive integer")
+	}
+	return v, nil
+}
+
+// ParseRepeatHuntRule parses repeat rule, eg. "20 gold:5000 lv:3 drop:goblin ear"
+// a number without a key is the run count and words without a key continue the drop name
+func ParseRepeatHuntRule(query string) (RepeatHuntRule, error) {
+	rule := RepeatHuntRule{Query: strings.TrimSpace(query)}
+	dropNames := []string{}
+	for _, word := range strings.Fields(query) {
+		kv := strings.SplitN(word, ":", 2)
+		var err error
+		if len(kv) == 1 {
+			if _, convErr := strconv.Atoi(word); convErr == nil {
+				rule.MaxRuns, err = parsePositiveInt(word)
+			} else {
+				dropNames = append(dropNames, word)
+			}
+		} else {
+			switch strings.ToLower(kv[0]) {
+			case "runs", "x":
+				rule.MaxRuns, err = parsePositiveInt(kv[1])
+			case "gold":
+				rule.GoldGoal, err = parsePositiveInt(kv[1])
+			case "lv", "level":
+				rule.LevelGoal, err = parsePositiveInt(kv[1])
+			case "drop":
+				dropNames = append(dropNames, kv[1])
+			default:
+				err = errors.New("unknown repeat rule key " + kv[0])
+			}
+		}
+		if err != nil {
+			return rule, err
+		}
+	}
+	rule.DropName = strings.Join(dropNames, " ")
+	if rule.MaxRuns == 0 && rule.GoldGoal == 0 && rule.LevelGoal == 0 && len(rule.DropName) == 0 {
+		return rule, errors.New("repeat rule needs a run count or a goal")
+	}
+	return rule, nil
+}
+
+// Add updates running totals from the battle report of each run and decides whether to stop
+func (p *RepeatHuntProgress) Add(user User, report BattleReport, failReason string) {
+	if len(failReason) > 0 {
+		p.StopReason = Sprintf("transaction failed: %s", failReason)
+		return
+	}
+	p.Runs++
+	p.Gold += report.Gold
+	p.XP += report.XPAfter - report.XPBefore
+	p.Drops = append(p.Drops, report.Drops...)
+	p.CharacterLost = report.CharacterLost
+	p.WeaponLost = report.WeaponLost
+
+	switch {
+	case p.CharacterLost:
+		p.StopReason = Localize("character was lost")
+	case p.WeaponLost:
+		p.StopReason = Localize("sword was lost")
+	case len(p.Rule.DropName) > 0 && containsDrop(report.Drops, p.Rule.DropName):
+		p.StopReason = Sprintf("got %s", p.Rule.DropName)
+	case p.Rule.GoldGoal > 0 && user.GetGold() >= p.Rule.GoldGoal:
+		p.StopReason = Sprintf("gold reached %d", p.Rule.GoldGoal)
+	case p.Rule.LevelGoal > 0 && report.LevelAfter >= p.Rule.LevelGoal:
+		p.StopReason = Sprintf("character reached level %d", p.Rule.LevelGoal)
+	case p.Rule.MaxRuns > 0 && p.Runs >= p.Rule.MaxRuns:
+		p.StopReason = Sprintf("finished %d runs", p.Rule.MaxRuns)
+	case p.StopRequested:
+		p.StopReason = Localize("stopped by user")
+	}
+}
+
+func containsDrop(drops []string, name string) bool {
+	for _, drop := range drops {
+		if strings.Contains(strings.ToLower(drop), strings.ToLower(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Stopped returns true when one of the stop conditions is met
+func (p *RepeatHuntProgress) Stopped() bool {
+	return len(p.StopReason) > 0
+}
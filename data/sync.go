@@ -10,6 +10,13 @@ import (
 
 var IsSyncingFromNode = false
 
+var syncListeners = []func(User){}
+
+// AddSyncListener registers a function which is called whenever SyncFromNode finishes
+func AddSyncListener(fn func(User)) {
+	syncListeners = append(syncListeners, fn)
+}
+
 func SyncFromNode(user User) {
 	if IsSyncingFromNode {
 		return
@@ -47,6 +54,7 @@ func SyncFromNode(user User) {
 		Name, _ := rawItem.FindString("Name")
 		itemType, _ := rawItem.FindString("Type")
 		Attack, _ := rawItem.FindDouble("attack")
+		Defense, _ := rawItem.FindLong("defense")
 		LastUpdate := rawItem.LastUpdate
 
 		if itemType == "Character" {
@@ -66,7 +74,9 @@ func SyncFromNode(user User) {
 				Level:      Level,
 				Name:       Name,
 				Attack:     int(Attack),
+				Defense:    Defense,
 				ID:         rawItem.ID,
+				Type:       itemType,
 				LastUpdate: LastUpdate,
 			})
 		}
@@ -82,9 +92,19 @@ func SyncFromNode(user User) {
 	nSellItemTrdReqs := []ItemSellTrdReq{}
 	nBuyCharacterTrdReqs := []CharacterBuyTrdReq{}
 	nSellCharacterTrdReqs := []CharacterSellTrdReq{}
+	nBarterTrdReqs := []BarterTrdReq{}
 	rawTrades, _ := pylonSDK.ListTradeViaCLI("")
+	ds, dsErr := pylonSDK.GetDaemonStatus()
+	if dsErr == nil {
+		user.SetLatestBlockHeight(ds.SyncInfo.LatestBlockHeight)
+	}
+	UpdateTradeHistory(user, rawTrades, user.GetLatestBlockHeight())
 	for _, tradeItem := range rawTrades {
-		if tradeItem.Completed == false && tradeItem.Disabled == false && strings.Contains(tradeItem.ExtraInfo, CR8BY_LOUD) {
+		if tradeItem.Completed == false && tradeItem.Disabled == false && tradeItem.ExtraInfo == BARTER_TRDINFO {
+			barterTrdReq := BarterTrdReqFromTrade(tradeItem)
+			barterTrdReq.IsMyTrdReq = tradeItem.Sender.String() == accAddr
+			nBarterTrdReqs = append(nBarterTrdReqs, barterTrdReq)
+		} else if tradeItem.Completed == false && tradeItem.Disabled == false && strings.Contains(tradeItem.ExtraInfo, CR8BY_LOUD) {
 			inputCoin := ""
 			if len(tradeItem.CoinInputs) > 0 {
 				inputCoin = tradeItem.CoinInputs[0].Coin
@@ -132,6 +152,7 @@ func SyncFromNode(user User) {
 					nBuyItemTrdReqs = append(nBuyItemTrdReqs, ItemBuyTrdReq{
 						ID:         tradeItem.ID,
 						TItem:      tItem,
+						ItemInputs: tradeItem.ItemInputs,
 						Price:      int(pylonOutputAmount),
 						IsMyTrdReq: isMyTrdReq,
 					})
@@ -150,6 +171,7 @@ func SyncFromNode(user User) {
 					nBuyCharacterTrdReqs = append(nBuyCharacterTrdReqs, CharacterBuyTrdReq{
 						ID:         tradeItem.ID,
 						TCharacter: tCharacter,
+						ItemInputs: tradeItem.ItemInputs,
 						Price:      int(pylonOutputAmount),
 						IsMyTrdReq: isMyTrdReq,
 					})
@@ -176,11 +198,13 @@ func SyncFromNode(user User) {
 					})
 				} else if tradeItem.ExtraInfo == CHAR_SELREQ_TRDINFO { // character sell request created by loud game
 					XP, _ := firstItemOutput.FindDouble("XP")
+					Special, _ := firstItemOutput.FindLong("Special")
 					tCharacter := Character{
-						ID:    firstItemOutput.ID,
-						Level: level,
-						Name:  name,
-						XP:    XP,
+						ID:      firstItemOutput.ID,
+						Level:   level,
+						Name:    name,
+						XP:      XP,
+						Special: Special,
 					}
 					nSellCharacterTrdReqs = append(nSellCharacterTrdReqs, CharacterSellTrdReq{
 						ID:         tradeItem.ID,
@@ -206,11 +230,17 @@ func SyncFromNode(user User) {
 	ItemSellTrdReqs = nSellItemTrdReqs
 	CharacterBuyTrdReqs = nBuyCharacterTrdReqs
 	CharacterSellTrdReqs = nSellCharacterTrdReqs
+	BarterTrdReqs = nBarterTrdReqs
+	UpdateLockedTrdReqIDs()
+	UpdateMyOrders(user, rawTrades, user.GetLatestBlockHeight())
+	UpdateWatchAlerts(user)
+	if loudRecipesStale {
+		UpdateLoudRecipes()
+	}
 	log.Println("BuyTrdReqs=", BuyTrdReqs)
 	log.Println("SellTrdReqs=", SellTrdReqs)
 
-	ds, err := pylonSDK.GetDaemonStatus()
-	if err == nil {
-		user.SetLatestBlockHeight(ds.SyncInfo.LatestBlockHeight)
+	for _, fn := range syncListeners {
+		fn(user)
 	}
 }
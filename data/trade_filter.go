@@ -0,0 +1,242 @@
+package loud
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	TRDSORT_NONE       = ""
+	TRDSORT_PRICE_ASC  = "price"
+	TRDSORT_PRICE_DESC = "-price"
+	TRDSORT_LEVEL      = "level"
+	TRDSORT_XP         = "xp"
+)
+
+// TrdReqSortOrders is the order sort keys are switched in trade request tables
+var TrdReqSortOrders = []string{TRDSORT_NONE, TRDSORT_PRICE_ASC, TRDSORT_PRICE_DESC, TRDSORT_LEVEL, TRDSORT_XP}
+
+// TrdReqFilter is the filter and sort condition of item and character trade request tables
+type TrdReqFilter struct {
+	Query      string // raw query entered by user
+	Name       string
+	Level      [2]int // 0 means no limit
+	Price      [2]int // 0 means no limit
+	MineOnly   bool
+	CanFulfill bool
+	Special    int
+	HasSpecial bool
+	SortBy     string
+}
+
+var specialNames = map[string]int{
+	"none": NO_SPECIAL,
+	"fire": FIRE_SPECIAL,
+	"ice":  ICE_SPECIAL,
+	"acid": ACID_SPECIAL,
+}
+
+func parseIntRange(value string) ([2]int, error) {
+	r := [2]int{}
+	parts := strings.SplitN(value, "-", 2)
+	for idx, part := range parts {
+		if len(part) == 0 {
+			continue
+		}
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return r, fmt.Errorf("%s is not a valid range", value)
+		}
+		r[idx] = v
+	}
+	if len(parts) == 1 {
+		r[1] = r[0]
+	}
+	return r, nil
+}
+
+// ParseTrdReqFilter parses filter query, eg. "name:sword lv:1-2 price:-100 mine fulfill special:fire"
+// words without a key are used as name filter
+func ParseTrdReqFilter(query string) (TrdReqFilter, error) {
+	filter := TrdReqFilter{Query: strings.TrimSpace(query)}
+	names := []string{}
+	for _, word := range strings.Fields(query) {
+		kv := strings.SplitN(word, ":", 2)
+		key := strings.ToLower(kv[0])
+		if len(kv) == 1 {
+			switch key {
+			case "mine":
+				filter.MineOnly = true
+			case "fulfill":
+				filter.CanFulfill = true
+			default:
+				names = append(names, word)
+			}
+			continue
+		}
+		value := kv[1]
+		var err error
+		switch key {
+		case "name":
+			names = append(names, value)
+		case "lv", "level":
+			filter.Level, err = parseIntRange(value)
+		case "price":
+			filter.Price, err = parseIntRange(value)
+		case "special":
+			special, ok := specialNames[strings.ToLower(value)]
+			if !ok {
+				err = fmt.Errorf("%s is not a valid special", value)
+			}
+			filter.Special = special
+			filter.HasSpecial = true
+		default:
+			err = errors.New("unknown filter key " + key)
+		}
+		if err != nil {
+			return filter, err
+		}
+	}
+	filter.Name = strings.Join(names, " ")
+	return filter, nil
+}
+
+// IsEmpty returns true when filter is not limiting or sorting anything
+func (f TrdReqFilter) IsEmpty() bool {
+	return len(f.Query) == 0 && f.SortBy == TRDSORT_NONE
+}
+
+// NextSort returns the filter with next sort key
+func (f TrdReqFilter) NextSort() TrdReqFilter {
+	for idx, sortBy := range TrdReqSortOrders {
+		if sortBy == f.SortBy {
+			f.SortBy = TrdReqSortOrders[(idx+1)%len(TrdReqSortOrders)]
+			return f
+		}
+	}
+	f.SortBy = TRDSORT_NONE
+	return f
+}
+
+func inRange(r [2]int, v int) bool {
+	return (r[0] == 0 || v >= r[0]) && (r[1] == 0 || v <= r[1])
+}
+
+func overlapRange(r [2]int, v [2]int) bool {
+	return (r[0] == 0 || v[1] >= r[0]) && (r[1] == 0 || v[0] <= r[1])
+}
+
+func (f TrdReqFilter) matchBasic(name string, level [2]int, price int, isMyTrdReq bool) bool {
+	if len(f.Name) > 0 && !strings.Contains(strings.ToLower(name), strings.ToLower(f.Name)) {
+		return false
+	}
+	if !overlapRange(f.Level, level) || !inRange(f.Price, price) {
+		return false
+	}
+	if f.MineOnly && !isMyTrdReq {
+		return false
+	}
+	return true
+}
+
+// sortTrdReqs sorts trade requests using price, level and xp getters for index
+func (f TrdReqFilter) sortTrdReqs(slice interface{}, price func(int) int, level func(int) int, xp func(int) float64) {
+	switch f.SortBy {
+	case TRDSORT_PRICE_ASC:
+		sort.SliceStable(slice, func(i, j int) bool { return price(i) < price(j) })
+	case TRDSORT_PRICE_DESC:
+		sort.SliceStable(slice, func(i, j int) bool { return price(i) > price(j) })
+	case TRDSORT_LEVEL:
+		sort.SliceStable(slice, func(i, j int) bool { return level(i) > level(j) })
+	case TRDSORT_XP:
+		sort.SliceStable(slice, func(i, j int) bool { return xp(i) > xp(j) })
+	}
+}
+
+// FilterItemBuyTrdReqs returns item buy requests matching filter
+// requests which user holds a matching item other than active weapon for can be fulfilled
+func FilterItemBuyTrdReqs(user User, requests []ItemBuyTrdReq, f TrdReqFilter) []ItemBuyTrdReq {
+	filtered := []ItemBuyTrdReq{}
+	for _, request := range requests {
+		if !f.matchBasic(request.TItem.Name, request.TItem.Level, request.Price, request.IsMyTrdReq) {
+			continue
+		}
+		if f.CanFulfill && (request.IsMyTrdReq || len(ItemBuyTrdReqCandidates(user, request)) == 0) {
+			continue
+		}
+		filtered = append(filtered, request)
+	}
+	f.sortTrdReqs(filtered,
+		func(i int) int { return filtered[i].Price },
+		func(i int) int { return filtered[i].TItem.Level[0] },
+		func(i int) float64 { return 0 })
+	return filtered
+}
+
+// FilterItemSellTrdReqs returns item sell requests matching filter
+// requests which user has enough pylons for can be fulfilled
+func FilterItemSellTrdReqs(user User, requests []ItemSellTrdReq, f TrdReqFilter) []ItemSellTrdReq {
+	filtered := []ItemSellTrdReq{}
+	for _, request := range requests {
+		level := [2]int{request.TItem.Level, request.TItem.Level}
+		if !f.matchBasic(request.TItem.Name, level, request.Price, request.IsMyTrdReq) {
+			continue
+		}
+		if f.CanFulfill && (request.IsMyTrdReq || request.Price > user.GetPylonAmount()) {
+			continue
+		}
+		filtered = append(filtered, request)
+	}
+	f.sortTrdReqs(filtered,
+		func(i int) int { return filtered[i].Price },
+		func(i int) int { return filtered[i].TItem.Level },
+		func(i int) float64 { return 0 })
+	return filtered
+}
+
+// FilterCharacterBuyTrdReqs returns character buy requests matching filter
+// requests which user holds a matching character other than active character for can be fulfilled
+func FilterCharacterBuyTrdReqs(user User, requests []CharacterBuyTrdReq, f TrdReqFilter) []CharacterBuyTrdReq {
+	filtered := []CharacterBuyTrdReq{}
+	for _, request := range requests {
+		if !f.matchBasic(request.TCharacter.Name, request.TCharacter.Level, request.Price, request.IsMyTrdReq) {
+			continue
+		}
+		if f.CanFulfill && (request.IsMyTrdReq || len(CharacterBuyTrdReqCandidates(user, request)) == 0) {
+			continue
+		}
+		filtered = append(filtered, request)
+	}
+	f.sortTrdReqs(filtered,
+		func(i int) int { return filtered[i].Price },
+		func(i int) int { return filtered[i].TCharacter.Level[0] },
+		func(i int) float64 { return filtered[i].TCharacter.XP[0] })
+	return filtered
+}
+
+// FilterCharacterSellTrdReqs returns character sell requests matching filter
+// requests which user has enough pylons for can be fulfilled
+func FilterCharacterSellTrdReqs(user User, requests []CharacterSellTrdReq, f TrdReqFilter) []CharacterSellTrdReq {
+	filtered := []CharacterSellTrdReq{}
+	for _, request := range requests {
+		level := [2]int{request.TCharacter.Level, request.TCharacter.Level}
+		if !f.matchBasic(request.TCharacter.Name, level, request.Price, request.IsMyTrdReq) {
+			continue
+		}
+		if f.HasSpecial && request.TCharacter.Special != f.Special {
+			continue
+		}
+		if f.CanFulfill && (request.IsMyTrdReq || request.Price > user.GetPylonAmount()) {
+			continue
+		}
+		filtered = append(filtered, request)
+	}
+	f.sortTrdReqs(filtered,
+		func(i int) int { return filtered[i].Price },
+		func(i int) int { return filtered[i].TCharacter.Level },
+		func(i int) float64 { return filtered[i].TCharacter.XP })
+	return filtered
+}
@@ -0,0 +1,97 @@
+package loud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTrdReqFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    TrdReqFilter
+		wantErr bool
+	}{
+		{"", TrdReqFilter{}, false},
+		{"  sword  ", TrdReqFilter{Query: "sword", Name: "sword"}, false},
+		{"copper sword", TrdReqFilter{Query: "copper sword", Name: "copper sword"}, false},
+		{"name:sword", TrdReqFilter{Query: "name:sword", Name: "sword"}, false},
+		{"lv:1-2", TrdReqFilter{Query: "lv:1-2", Level: [2]int{1, 2}}, false},
+		{"level:3", TrdReqFilter{Query: "level:3", Level: [2]int{3, 3}}, false},
+		{"price:-100", TrdReqFilter{Query: "price:-100", Price: [2]int{0, 100}}, false},
+		{"price:50-", TrdReqFilter{Query: "price:50-", Price: [2]int{50, 0}}, false},
+		{"MINE Fulfill", TrdReqFilter{Query: "MINE Fulfill", MineOnly: true, CanFulfill: true}, false},
+		{"special:Fire", TrdReqFilter{Query: "special:Fire", Special: FIRE_SPECIAL, HasSpecial: true}, false},
+		{"special:none", TrdReqFilter{Query: "special:none", Special: NO_SPECIAL, HasSpecial: true}, false},
+		{"Name:sword lv:1-2 price:-100 mine fulfill special:ice", TrdReqFilter{
+			Query:      "Name:sword lv:1-2 price:-100 mine fulfill special:ice",
+			Name:       "sword",
+			Level:      [2]int{1, 2},
+			Price:      [2]int{0, 100},
+			MineOnly:   true,
+			CanFulfill: true,
+			Special:    ICE_SPECIAL,
+			HasSpecial: true,
+		}, false},
+		{"lv:a-2", TrdReqFilter{}, true},
+		{"price:1-x", TrdReqFilter{}, true},
+		{"special:water", TrdReqFilter{}, true},
+		{"color:red", TrdReqFilter{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := ParseTrdReqFilter(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrdReqFilterNextSort(t *testing.T) {
+	f := TrdReqFilter{}
+	for _, want := range []string{TRDSORT_PRICE_ASC, TRDSORT_PRICE_DESC, TRDSORT_LEVEL, TRDSORT_XP, TRDSORT_NONE} {
+		f = f.NextSort()
+		if f.SortBy != want {
+			t.Fatalf("SortBy = %q, want %q", f.SortBy, want)
+		}
+	}
+	if f := (TrdReqFilter{SortBy: "unknown"}).NextSort(); f.SortBy != TRDSORT_NONE {
+		t.Errorf("unknown sort moves to %q, want none", f.SortBy)
+	}
+}
+
+func TestTrdReqFilterMatchBasic(t *testing.T) {
+	tests := []struct {
+		query  string
+		name   string
+		level  [2]int
+		price  int
+		isMine bool
+		want   bool
+	}{
+		{"", "Copper sword", [2]int{1, 1}, 100, false, true},
+		{"SWORD", "Copper sword", [2]int{1, 1}, 100, false, true},
+		{"wolf", "Copper sword", [2]int{1, 1}, 100, false, false},
+		{"lv:2-3", "Copper sword", [2]int{1, 2}, 100, false, true},
+		{"lv:3", "Copper sword", [2]int{1, 2}, 100, false, false},
+		{"price:-99", "Copper sword", [2]int{1, 1}, 100, false, false},
+		{"price:100-", "Copper sword", [2]int{1, 1}, 100, false, true},
+		{"mine", "Copper sword", [2]int{1, 1}, 100, false, false},
+		{"mine", "Copper sword", [2]int{1, 1}, 100, true, true},
+	}
+	for _, tt := range tests {
+		f, err := ParseTrdReqFilter(tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := f.matchBasic(tt.name, tt.level, tt.price, tt.isMine); got != tt.want {
+			t.Errorf("%q matchBasic(%q, %v, %d, %v) = %v, want %v", tt.query, tt.name, tt.level, tt.price, tt.isMine, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package loud
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Pylons-tech/pylons_sdk/x/pylons/types"
+)
+
+const (
+	TRDHIST_GOLD = "gold"
+	TRDHIST_ITEM = "item"
+	TRDHIST_CHAR = "character"
+)
+
+// TradeRecord is a completed LOUD trade kept in local trade history.
+// Chain does not keep completion time of trades, so the block and time when
+// the trade was first seen completed during sync are recorded instead.
+type TradeRecord struct {
+	ID             string
+	Kind           string // gold, item or character
+	Name           string
+	Level          [2]int
+	Amount         int     // gold amount for gold trades
+	Price          float64 // pylon per gold for gold trades, pylons for item and character trades
+	Total          int     // pylons
+	Sender         string
+	FulFiller      string
+	FirstSeenBlock int64
+	FirstSeenTime  int64
+}
+
+// OHLC is open, high, low and close price summary of trades in a period
+type OHLC struct {
+	Label     string
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    int // gold volume for gold trades
+	NumTrades int
+}
+
+// TradeHistory is all completed trades recorded locally, sorted from the oldest one
+var TradeHistory = []TradeRecord{}
+
+// TradeRecordFromTrade converts a completed LOUD trade into trade history record
+func TradeRecordFromTrade(trade types.Trade) (TradeRecord, bool) {
+	if !trade.Completed || !strings.Contains(trade.ExtraInfo, CR8BY_LOUD) || trade.ExtraInfo == BARTER_TRDINFO {
+		return TradeRecord{}, false
+	}
+	record := TradeRecord{
+		ID:        trade.ID,
+		Sender:    trade.Sender.String(),
+		FulFiller: trade.FulFiller.String(),
+	}
+	inputCoin := ""
+	inputCoinAmount := int64(0)
+	if len(trade.CoinInputs) > 0 {
+		inputCoin = trade.CoinInputs[0].Coin
+		inputCoinAmount = trade.CoinInputs[0].Count
+	}
+	loudOutputAmount := trade.CoinOutputs.AmountOf("loudcoin").Int64()
+	pylonOutputAmount := trade.CoinOutputs.AmountOf("pylon").Int64()
+
+	if inputCoin == "loudcoin" && inputCoinAmount > 0 { // gold sold for pylons
+		record.Kind = TRDHIST_GOLD
+		record.Amount = int(inputCoinAmount)
+		record.Total = int(pylonOutputAmount)
+	} else if loudOutputAmount > 0 { // gold bought with pylons
+		record.Kind = TRDHIST_GOLD
+		record.Amount = int(loudOutputAmount)
+		record.Total = int(inputCoinAmount)
+	} else if len(trade.ItemInputs) > 0 { // item or character bought with pylons
+		firstItemInput := trade.ItemInputs[0]
+		if len(firstItemInput.Longs) > 0 {
+			record.Level = [2]int{firstItemInput.Longs[0].MinValue, firstItemInput.Longs[0].MaxValue}
+		}
+		if len(firstItemInput.Strings) > 0 {
+			record.Name = firstItemInput.Strings[0].Value
+		}
+		record.Total = int(pylonOutputAmount)
+	} else if len(trade.ItemOutputs) > 0 { // item or character sold for pylons
+		firstItemOutput := trade.ItemOutputs[0]
+		level, _ := firstItemOutput.FindLong("level")
+		record.Name, _ = firstItemOutput.FindString("Name")
+		record.Level = [2]int{level, level}
+		record.Total = int(inputCoinAmount)
+	} else {
+		return TradeRecord{}, false
+	}
+
+	switch trade.ExtraInfo {
+	case ITEM_BUYREQ_TRDINFO, ITEM_SELREQ_TRDINFO:
+		record.Kind = TRDHIST_ITEM
+	case CHAR_BUYREQ_TRDINFO, CHAR_SELREQ_TRDINFO:
+		record.Kind = TRDHIST_CHAR
+	}
+	if len(record.Kind) == 0 {
+		return TradeRecord{}, false
+	}
+	if record.Kind == TRDHIST_GOLD {
+		if record.Amount == 0 {
+			return TradeRecord{}, false
+		}
+		record.Price = float64(record.Total) / float64(record.Amount)
+	} else {
+		record.Price = float64(record.Total)
+	}
+	return record, true
+}
+
+// UpdateTradeHistory records completed trades which are not yet in user's local trade history
+// and refreshes TradeHistory
+func UpdateTradeHistory(user User, trades []types.Trade, blockHeight int64) {
+	history := user.GetTradeHistory()
+	known := make(map[string]bool)
+	for _, record := range history {
+		known[record.ID] = true
+	}
+	newRecords := []TradeRecord{}
+	now := time.Now().Unix()
+	for _, trade := range trades {
+		if known[trade.ID] {
+			continue
+		}
+		record, ok := TradeRecordFromTrade(trade)
+		if !ok {
+			continue
+		}
+		record.FirstSeenBlock = blockHeight
+		record.FirstSeenTime = now
+		newRecords = append(newRecords, record)
+	}
+	if len(newRecords) > 0 {
+		user.AddTradeHistory(newRecords)
+		history = append(history, newRecords...)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].FirstSeenBlock < history[j].FirstSeenBlock
+	})
+	TradeHistory = history
+}
+
+// TradeHistoryByKind returns trade records of a kind, sorted from the oldest one
+func TradeHistoryByKind(kind string) []TradeRecord {
+	records := []TradeRecord{}
+	for _, record := range TradeHistory {
+		if record.Kind == kind {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// LastSoldPrice returns the latest price of item or character trade with name and overlapping level range
+func LastSoldPrice(kind string, name string, level [2]int) (int, bool) {
+	for idx := len(TradeHistory) - 1; idx >= 0; idx-- {
+		record := TradeHistory[idx]
+		if record.Kind != kind || record.Name != name {
+			continue
+		}
+		if record.Level[0] > level[1] || record.Level[1] < level[0] {
+			continue
+		}
+		return record.Total, true
+	}
+	return 0, false
+}
+
+func groupOHLC(records []TradeRecord, labelFn func(TradeRecord) string) []OHLC {
+	summaries := []OHLC{}
+	for _, record := range records {
+		label := labelFn(record)
+		last := len(summaries) - 1
+		if last < 0 || summaries[last].Label != label {
+			summaries = append(summaries, OHLC{
+				Label: label,
+				Open:  record.Price,
+				High:  record.Price,
+				Low:   record.Price,
+			})
+			last++
+		}
+		summary := &summaries[last]
+		if record.Price > summary.High {
+			summary.High = record.Price
+		}
+		if record.Price < summary.Low {
+			summary.Low = record.Price
+		}
+		summary.Close = record.Price
+		summary.Volume += record.Amount
+		summary.NumTrades++
+	}
+	return summaries
+}
+
+// OHLCByDay summarizes trade records per day they were first seen completed
+func OHLCByDay(records []TradeRecord) []OHLC {
+	return groupOHLC(records, func(record TradeRecord) string {
+		return time.Unix(record.FirstSeenTime, 0).Format("2006-01-02")
+	})
+}
+
+// OHLCByBlockRange summarizes trade records per blockRange blocks
+func OHLCByBlockRange(records []TradeRecord, blockRange int64) []OHLC {
+	return groupOHLC(records, func(record TradeRecord) string {
+		start := record.FirstSeenBlock / blockRange * blockRange
+		return fmt.Sprintf("%d-%d", start, start+blockRange-1)
+	})
+}
+
+// TradeHistoryGroup is price history of items or characters with same name and level range
+type TradeHistoryGroup struct {
+	Kind    string
+	Name    string
+	Level   [2]int
+	Records []TradeRecord
+}
+
+// ItemTradeHistoryGroups groups item and character trade records by kind, name and level range
+func ItemTradeHistoryGroups() []TradeHistoryGroup {
+	groups := []TradeHistoryGroup{}
+	groupIdx := make(map[string]int)
+	for _, record := range TradeHistory {
+		if record.Kind == TRDHIST_GOLD {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s/%v", record.Kind, record.Name, record.Level)
+		idx, ok := groupIdx[key]
+		if !ok {
+			idx = len(groups)
+			groupIdx[key] = idx
+			groups = append(groups, TradeHistoryGroup{
+				Kind:  record.Kind,
+				Name:  record.Name,
+				Level: record.Level,
+			})
+		}
+		groups[idx].Records = append(groups[idx].Records, record)
+	}
+	return groups
+}
@@ -15,6 +15,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	cf "github.com/Pylons-tech/LOUD/config"
 	"github.com/Pylons-tech/LOUD/log"
@@ -58,6 +59,58 @@ const (
 	RCP_FIGHT_DRAGONUNDEAD   = "LOUD's fight with undead dragon with an angel sword recipe"
 
 	RCP_GET_TEST_ITEMS = "LOUD's Dev Get Test Items recipe"
+
+	RCP_BUY_HEALING_POTION = "LOUD's Healing potion buy recipe"
+	RCP_BUY_REVIVE_SCROLL  = "LOUD's Revive scroll buy recipe"
+
+	RCP_HUNT_RABBITS_NOSWORD_POTION = "LOUD's hunt rabbits without sword and a healing potion recipe"
+	RCP_HUNT_RABBITS_NOSWORD_SCROLL = "LOUD's hunt rabbits without sword and a revive scroll recipe"
+	RCP_HUNT_RABBITS_YESWORD_POTION = "LOUD's hunt rabbits with a sword and a healing potion recipe"
+	RCP_HUNT_RABBITS_YESWORD_SCROLL = "LOUD's hunt rabbits with a sword and a revive scroll recipe"
+	RCP_FIGHT_GOBLIN_POTION         = "LOUD's fight with goblin with a sword and a healing potion recipe"
+	RCP_FIGHT_GOBLIN_SCROLL         = "LOUD's fight with goblin with a sword and a revive scroll recipe"
+	RCP_FIGHT_WOLF_POTION           = "LOUD's fight with wolf with a sword and a healing potion recipe"
+	RCP_FIGHT_WOLF_SCROLL           = "LOUD's fight with wolf with a sword and a revive scroll recipe"
+	RCP_FIGHT_TROLL_POTION          = "LOUD's fight with troll with a sword and a healing potion recipe"
+	RCP_FIGHT_TROLL_SCROLL          = "LOUD's fight with troll with a sword and a revive scroll recipe"
+	RCP_FIGHT_GIANT_POTION          = "LOUD's fight with giant with a sword and a healing potion recipe"
+	RCP_FIGHT_GIANT_SCROLL          = "LOUD's fight with giant with a sword and a revive scroll recipe"
+	RCP_FIGHT_DRAGONFIRE_POTION     = "LOUD's fight with fire dragon with an iron sword and a healing potion recipe"
+	RCP_FIGHT_DRAGONFIRE_SCROLL     = "LOUD's fight with fire dragon with an iron sword and a revive scroll recipe"
+	RCP_FIGHT_DRAGONICE_POTION      = "LOUD's fight with ice dragon with an iron sword and a healing potion recipe"
+	RCP_FIGHT_DRAGONICE_SCROLL      = "LOUD's fight with ice dragon with an iron sword and a revive scroll recipe"
+	RCP_FIGHT_DRAGONACID_POTION     = "LOUD's fight with acid dragon with an iron sword and a healing potion recipe"
+	RCP_FIGHT_DRAGONACID_SCROLL     = "LOUD's fight with acid dragon with an iron sword and a revive scroll recipe"
+	RCP_FIGHT_DRAGONUNDEAD_POTION   = "LOUD's fight with undead dragon with an angel sword and a healing potion recipe"
+	RCP_FIGHT_DRAGONUNDEAD_SCROLL   = "LOUD's fight with undead dragon with an angel sword and a revive scroll recipe"
+
+	RCP_BUY_LEATHER_ARMOR = "LOUD's Leather armor buy recipe"
+	RCP_BUY_IRON_SHIELD   = "LOUD's Iron shield buy recipe"
+
+	RCP_FIGHT_GOBLIN_ARMOR              = "LOUD's fight with goblin with a sword and an armor recipe"
+	RCP_FIGHT_GOBLIN_ARMOR_POTION       = "LOUD's fight with goblin with a sword and an armor and a healing potion recipe"
+	RCP_FIGHT_GOBLIN_ARMOR_SCROLL       = "LOUD's fight with goblin with a sword and an armor and a revive scroll recipe"
+	RCP_FIGHT_WOLF_ARMOR                = "LOUD's fight with wolf with a sword and an armor recipe"
+	RCP_FIGHT_WOLF_ARMOR_POTION         = "LOUD's fight with wolf with a sword and an armor and a healing potion recipe"
+	RCP_FIGHT_WOLF_ARMOR_SCROLL         = "LOUD's fight with wolf with a sword and an armor and a revive scroll recipe"
+	RCP_FIGHT_TROLL_ARMOR               = "LOUD's fight with troll with a sword and an armor recipe"
+	RCP_FIGHT_TROLL_ARMOR_POTION        = "LOUD's fight with troll with a sword and an armor and a healing potion recipe"
+	RCP_FIGHT_TROLL_ARMOR_SCROLL        = "LOUD's fight with troll with a sword and an armor and a revive scroll recipe"
+	RCP_FIGHT_GIANT_ARMOR               = "LOUD's fight with giant with a sword and an armor recipe"
+	RCP_FIGHT_GIANT_ARMOR_POTION        = "LOUD's fight with giant with a sword and an armor and a healing potion recipe"
+	RCP_FIGHT_GIANT_ARMOR_SCROLL        = "LOUD's fight with giant with a sword and an armor and a revive scroll recipe"
+	RCP_FIGHT_DRAGONFIRE_ARMOR          = "LOUD's fight with fire dragon with an iron sword and an armor recipe"
+	RCP_FIGHT_DRAGONFIRE_ARMOR_POTION   = "LOUD's fight with fire dragon with an iron sword and an armor and a healing potion recipe"
+	RCP_FIGHT_DRAGONFIRE_ARMOR_SCROLL   = "LOUD's fight with fire dragon with an iron sword and an armor and a revive scroll recipe"
+	RCP_FIGHT_DRAGONICE_ARMOR           = "LOUD's fight with ice dragon with an iron sword and an armor recipe"
+	RCP_FIGHT_DRAGONICE_ARMOR_POTION    = "LOUD's fight with ice dragon with an iron sword and an armor and a healing potion recipe"
+	RCP_FIGHT_DRAGONICE_ARMOR_SCROLL    = "LOUD's fight with ice dragon with an iron sword and an armor and a revive scroll recipe"
+	RCP_FIGHT_DRAGONACID_ARMOR          = "LOUD's fight with acid dragon with an iron sword and an armor recipe"
+	RCP_FIGHT_DRAGONACID_ARMOR_POTION   = "LOUD's fight with acid dragon with an iron sword and an armor and a healing potion recipe"
+	RCP_FIGHT_DRAGONACID_ARMOR_SCROLL   = "LOUD's fight with acid dragon with an iron sword and an armor and a revive scroll recipe"
+	RCP_FIGHT_DRAGONUNDEAD_ARMOR        = "LOUD's fight with undead dragon with an angel sword and an armor recipe"
+	RCP_FIGHT_DRAGONUNDEAD_ARMOR_POTION = "LOUD's fight with undead dragon with an angel sword and an armor and a healing potion recipe"
+	RCP_FIGHT_DRAGONUNDEAD_ARMOR_SCROLL = "LOUD's fight with undead dragon with an angel sword and an armor and a revive scroll recipe"
 )
 
 var RcpIDs map[string]string = map[string]string{
@@ -85,6 +138,124 @@ var RcpIDs map[string]string = map[string]string{
 	RCP_FIGHT_DRAGONUNDEAD:   "LOUD-fight-undead-dragon-with-angel-sword-recipe-v0.1.0-1589223853",
 
 	RCP_GET_TEST_ITEMS: "LOUD-dev-get-test-items-recipe-v0.1.0-1589223853",
+
+	RCP_BUY_HEALING_POTION: "LOUD-healing-potion-buy-recipe-v0.1.0-1589223853",
+	RCP_BUY_REVIVE_SCROLL:  "LOUD-revive-scroll-buy-recipe-v0.1.0-1589223853",
+
+	RCP_HUNT_RABBITS_NOSWORD_POTION: "LOUD-hunt-rabbits-with-no-weapon-and-potion-recipe-v0.1.0-1589223853",
+	RCP_HUNT_RABBITS_NOSWORD_SCROLL: "LOUD-hunt-rabbits-with-no-weapon-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_HUNT_RABBITS_YESWORD_POTION: "LOUD-hunt-rabbits-with-a-sword-and-potion-recipe-v0.1.0-1589223853",
+	RCP_HUNT_RABBITS_YESWORD_SCROLL: "LOUD-hunt-rabbits-with-a-sword-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_GOBLIN_POTION:         "LOUD-fight-goblin-with-a-sword-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_GOBLIN_SCROLL:         "LOUD-fight-goblin-with-a-sword-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_WOLF_POTION:           "LOUD-fight-wolf-with-a-sword-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_WOLF_SCROLL:           "LOUD-fight-wolf-with-a-sword-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_TROLL_POTION:          "LOUD-fight-troll-with-a-sword-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_TROLL_SCROLL:          "LOUD-fight-troll-with-a-sword-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_GIANT_POTION:          "LOUD-fight-giant-with-iron-sword-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_GIANT_SCROLL:          "LOUD-fight-giant-with-iron-sword-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONFIRE_POTION:     "LOUD-fight-fire-dragon-with-iron-sword-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONFIRE_SCROLL:     "LOUD-fight-fire-dragon-with-iron-sword-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONICE_POTION:      "LOUD-fight-ice-dragon-with-iron-sword-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONICE_SCROLL:      "LOUD-fight-ice-dragon-with-iron-sword-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONACID_POTION:     "LOUD-fight-acid-dragon-with-iron-sword-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONACID_SCROLL:     "LOUD-fight-acid-dragon-with-iron-sword-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONUNDEAD_POTION:   "LOUD-fight-undead-dragon-with-angel-sword-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONUNDEAD_SCROLL:   "LOUD-fight-undead-dragon-with-angel-sword-and-revive-scroll-recipe-v0.1.0-1589223853",
+
+	RCP_BUY_LEATHER_ARMOR: "LOUD-leather-armor-buy-recipe-v0.1.0-1589223853",
+	RCP_BUY_IRON_SHIELD:   "LOUD-iron-shield-buy-recipe-v0.1.0-1589223853",
+
+	RCP_FIGHT_GOBLIN_ARMOR:              "LOUD-fight-goblin-with-a-sword-and-armor-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_GOBLIN_ARMOR_POTION:       "LOUD-fight-goblin-with-a-sword-and-armor-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_GOBLIN_ARMOR_SCROLL:       "LOUD-fight-goblin-with-a-sword-and-armor-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_WOLF_ARMOR:                "LOUD-fight-wolf-with-a-sword-and-armor-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_WOLF_ARMOR_POTION:         "LOUD-fight-wolf-with-a-sword-and-armor-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_WOLF_ARMOR_SCROLL:         "LOUD-fight-wolf-with-a-sword-and-armor-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_TROLL_ARMOR:               "LOUD-fight-troll-with-a-sword-and-armor-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_TROLL_ARMOR_POTION:        "LOUD-fight-troll-with-a-sword-and-armor-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_TROLL_ARMOR_SCROLL:        "LOUD-fight-troll-with-a-sword-and-armor-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_GIANT_ARMOR:               "LOUD-fight-giant-with-iron-sword-and-armor-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_GIANT_ARMOR_POTION:        "LOUD-fight-giant-with-iron-sword-and-armor-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_GIANT_ARMOR_SCROLL:        "LOUD-fight-giant-with-iron-sword-and-armor-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONFIRE_ARMOR:          "LOUD-fight-fire-dragon-with-iron-sword-and-armor-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONFIRE_ARMOR_POTION:   "LOUD-fight-fire-dragon-with-iron-sword-and-armor-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONFIRE_ARMOR_SCROLL:   "LOUD-fight-fire-dragon-with-iron-sword-and-armor-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONICE_ARMOR:           "LOUD-fight-ice-dragon-with-iron-sword-and-armor-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONICE_ARMOR_POTION:    "LOUD-fight-ice-dragon-with-iron-sword-and-armor-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONICE_ARMOR_SCROLL:    "LOUD-fight-ice-dragon-with-iron-sword-and-armor-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONACID_ARMOR:          "LOUD-fight-acid-dragon-with-iron-sword-and-armor-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONACID_ARMOR_POTION:   "LOUD-fight-acid-dragon-with-iron-sword-and-armor-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONACID_ARMOR_SCROLL:   "LOUD-fight-acid-dragon-with-iron-sword-and-armor-and-revive-scroll-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONUNDEAD_ARMOR:        "LOUD-fight-undead-dragon-with-angel-sword-and-armor-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONUNDEAD_ARMOR_POTION: "LOUD-fight-undead-dragon-with-angel-sword-and-armor-and-potion-recipe-v0.1.0-1589223853",
+	RCP_FIGHT_DRAGONUNDEAD_ARMOR_SCROLL: "LOUD-fight-undead-dragon-with-angel-sword-and-armor-and-revive-scroll-recipe-v0.1.0-1589223853",
+}
+
+// ConsumableRcpNames maps a hunt or fight recipe to its variant which takes a consumable as the last item input
+var ConsumableRcpNames = map[string]map[string]string{
+	RCP_HUNT_RABBITS_NOSWORD: {HEALING_POTION: RCP_HUNT_RABBITS_NOSWORD_POTION, REVIVE_SCROLL: RCP_HUNT_RABBITS_NOSWORD_SCROLL},
+	RCP_HUNT_RABBITS_YESWORD: {HEALING_POTION: RCP_HUNT_RABBITS_YESWORD_POTION, REVIVE_SCROLL: RCP_HUNT_RABBITS_YESWORD_SCROLL},
+	RCP_FIGHT_GOBLIN:         {HEALING_POTION: RCP_FIGHT_GOBLIN_POTION, REVIVE_SCROLL: RCP_FIGHT_GOBLIN_SCROLL},
+	RCP_FIGHT_WOLF:           {HEALING_POTION: RCP_FIGHT_WOLF_POTION, REVIVE_SCROLL: RCP_FIGHT_WOLF_SCROLL},
+	RCP_FIGHT_TROLL:          {HEALING_POTION: RCP_FIGHT_TROLL_POTION, REVIVE_SCROLL: RCP_FIGHT_TROLL_SCROLL},
+	RCP_FIGHT_GIANT:          {HEALING_POTION: RCP_FIGHT_GIANT_POTION, REVIVE_SCROLL: RCP_FIGHT_GIANT_SCROLL},
+	RCP_FIGHT_DRAGONFIRE:     {HEALING_POTION: RCP_FIGHT_DRAGONFIRE_POTION, REVIVE_SCROLL: RCP_FIGHT_DRAGONFIRE_SCROLL},
+	RCP_FIGHT_DRAGONICE:      {HEALING_POTION: RCP_FIGHT_DRAGONICE_POTION, REVIVE_SCROLL: RCP_FIGHT_DRAGONICE_SCROLL},
+	RCP_FIGHT_DRAGONACID:     {HEALING_POTION: RCP_FIGHT_DRAGONACID_POTION, REVIVE_SCROLL: RCP_FIGHT_DRAGONACID_SCROLL},
+	RCP_FIGHT_DRAGONUNDEAD:   {HEALING_POTION: RCP_FIGHT_DRAGONUNDEAD_POTION, REVIVE_SCROLL: RCP_FIGHT_DRAGONUNDEAD_SCROLL},
+}
+
+// ArmorRcpNames maps a fight recipe to its variants which take an armor after the sword, keyed by the consumable taken as the last item input
+var ArmorRcpNames = map[string]map[string]string{
+	RCP_FIGHT_GOBLIN:       {"": RCP_FIGHT_GOBLIN_ARMOR, HEALING_POTION: RCP_FIGHT_GOBLIN_ARMOR_POTION, REVIVE_SCROLL: RCP_FIGHT_GOBLIN_ARMOR_SCROLL},
+	RCP_FIGHT_WOLF:         {"": RCP_FIGHT_WOLF_ARMOR, HEALING_POTION: RCP_FIGHT_WOLF_ARMOR_POTION, REVIVE_SCROLL: RCP_FIGHT_WOLF_ARMOR_SCROLL},
+	RCP_FIGHT_TROLL:        {"": RCP_FIGHT_TROLL_ARMOR, HEALING_POTION: RCP_FIGHT_TROLL_ARMOR_POTION, REVIVE_SCROLL: RCP_FIGHT_TROLL_ARMOR_SCROLL},
+	RCP_FIGHT_GIANT:        {"": RCP_FIGHT_GIANT_ARMOR, HEALING_POTION: RCP_FIGHT_GIANT_ARMOR_POTION, REVIVE_SCROLL: RCP_FIGHT_GIANT_ARMOR_SCROLL},
+	RCP_FIGHT_DRAGONFIRE:   {"": RCP_FIGHT_DRAGONFIRE_ARMOR, HEALING_POTION: RCP_FIGHT_DRAGONFIRE_ARMOR_POTION, REVIVE_SCROLL: RCP_FIGHT_DRAGONFIRE_ARMOR_SCROLL},
+	RCP_FIGHT_DRAGONICE:    {"": RCP_FIGHT_DRAGONICE_ARMOR, HEALING_POTION: RCP_FIGHT_DRAGONICE_ARMOR_POTION, REVIVE_SCROLL: RCP_FIGHT_DRAGONICE_ARMOR_SCROLL},
+	RCP_FIGHT_DRAGONACID:   {"": RCP_FIGHT_DRAGONACID_ARMOR, HEALING_POTION: RCP_FIGHT_DRAGONACID_ARMOR_POTION, REVIVE_SCROLL: RCP_FIGHT_DRAGONACID_ARMOR_SCROLL},
+	RCP_FIGHT_DRAGONUNDEAD: {"": RCP_FIGHT_DRAGONUNDEAD_ARMOR, HEALING_POTION: RCP_FIGHT_DRAGONUNDEAD_ARMOR_POTION, REVIVE_SCROLL: RCP_FIGHT_DRAGONUNDEAD_ARMOR_SCROLL},
+}
+
+// HuntRecipeVariant returns the variant of a hunt or fight recipe which takes the armor and the consumable as extra item inputs
+// armor is not used when the recipe has no armor variant
+func HuntRecipeVariant(rcpName string, withArmor bool, consumable string) (string, bool) {
+	if variants, ok := ArmorRcpNames[rcpName]; ok && withArmor {
+		variant, ok := variants[consumable]
+		return variant, ok
+	}
+	if len(consumable) == 0 {
+		return rcpName, true
+	}
+	variant, ok := ConsumableRcpNames[rcpName][consumable]
+	return variant, ok
+}
+
+// BaseHuntRecipe returns the hunt or fight recipe which a recipe variant is made from
+func BaseHuntRecipe(rcpName string) string {
+	for _, variantMap := range []map[string]map[string]string{ConsumableRcpNames, ArmorRcpNames} {
+		for base, variants := range variantMap {
+			for _, variant := range variants {
+				if variant == rcpName {
+					return base
+				}
+			}
+		}
+	}
+	return rcpName
+}
+
+// IsArmorRecipe returns true when the recipe variant takes an armor
+func IsArmorRecipe(rcpName string) bool {
+	for _, variants := range ArmorRcpNames {
+		for _, variant := range variants {
+			if variant == rcpName {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Remote mode
@@ -97,7 +268,15 @@ var useLocalDm bool = false
 var AutomateInput bool = false
 var AutomateRunCnt int = 0
 
-func init() {
+// KeymapConfig is key bindings by action name from app.keymap section of config file
+var KeymapConfig map[string]string
+
+// ThemeConfig is the theme used on start, ThemesConfig is font colors of themes by font name
+var ThemeConfig string
+var ThemesConfig map[string]map[string]string
+
+// LoadConfig reads command line flags and the config file, and points the sdk to the node; it's called once on start
+func LoadConfig() {
 	args := os.Args
 
 	if len(args) > 1 {
@@ -129,6 +308,9 @@ func init() {
 			restEndpoint = cfg.SDK.RestEndpoint
 			customNode = cfg.SDK.CliEndpoint
 			maxWaitBlock = cfg.SDK.MaxWaitBlock
+			KeymapConfig = cfg.App.Keymap
+			ThemeConfig = cfg.App.Theme
+			ThemesConfig = cfg.App.Themes
 		} else {
 			log.Fatal("Couldn't parse config file cfgFileName=", cfgFileName)
 		}
@@ -411,11 +593,9 @@ func LogFullTxResultByHash(txhash string) {
 	log.Println("txhash=", txhash, "txoutput=", string(output), "queryerr=", err)
 }
 
-func ProcessTxResult(user User, txhash string) ([]byte, string) {
+func waitTxResult(txhash string) ([]byte, string) {
 	t := GetTestingT()
 
-	resp := handlers.ExecuteRecipeResp{}
-
 	txHandleResBytes, err := pylonSDK.WaitAndGetTxData(txhash, pylonSDK.GetMaxWaitBlock(), t)
 	if err != nil {
 		errString := fmt.Sprintf("error getting tx result bytes %+v", err)
@@ -430,9 +610,32 @@ func ProcessTxResult(user User, txhash string) ([]byte, string) {
 		log.Println(errString)
 		return []byte{}, errString
 	}
+	return txHandleResBytes, ""
+}
+
+// ProcessTxResults waits for several transactions and syncs once after all of them are processed
+// it returns fail reasons by txhash for failed transactions
+func ProcessTxResults(user User, txhashes []string) map[string]string {
+	failReasons := make(map[string]string)
+	for _, txhash := range txhashes {
+		if _, errString := waitTxResult(txhash); len(errString) > 0 {
+			failReasons[txhash] = errString
+		}
+	}
+	SyncFromNode(user)
+	return failReasons
+}
+
+func ProcessTxResult(user User, txhash string) ([]byte, string) {
+	resp := handlers.ExecuteRecipeResp{}
+
+	txHandleResBytes, errString := waitTxResult(txhash)
+	if len(errString) > 0 {
+		return []byte{}, errString
+	}
 	SyncFromNode(user)
 
-	err = pylonSDK.GetAminoCdc().UnmarshalJSON(txHandleResBytes, &resp)
+	err := pylonSDK.GetAminoCdc().UnmarshalJSON(txHandleResBytes, &resp)
 	if err != nil {
 		errString := fmt.Sprintf("failed to parse transaction result; maybe this is get_pylons then ignore. txhash=%s", txhash)
 		log.Println(errString)
@@ -450,7 +653,6 @@ func GetTestingT() *testing.T {
 }
 
 func ExecuteRecipe(user User, rcpName string, itemIDs []string) (string, error) {
-	t := GetTestingT()
 	if len(rcpName) == 0 {
 		return "", errors.New("Recipe Name does not exist!")
 	}
@@ -461,11 +663,7 @@ func ExecuteRecipe(user User, rcpName string, itemIDs []string) (string, error)
 	addr := pylonSDK.GetAccountAddr(user.GetUserName(), nil)
 	sdkAddr, _ := sdk.AccAddressFromBech32(addr)
 	execMsg := msgs.NewMsgExecuteRecipe(rcpID, sdkAddr, itemIDs)
-	log.Println("started sending transaction", user.GetUserName(), execMsg)
-	txhash := pylonSDK.TestTxWithMsgWithNonce(t, execMsg, user.GetUserName(), false)
-	user.SetLastTransaction(txhash, rcpName)
-	log.Println("ended sending transaction")
-	return txhash, nil
+	return sendTx(user, []sdk.Msg{execMsg}, rcpName)
 }
 
 func GetIndexFromString(key string) int {
@@ -618,10 +816,133 @@ func GetSDKAddrFromUserName(username string) sdk.AccAddress {
 }
 
 func SendTxMsg(user User, txMsg sdk.Msg) (string, error) {
+	return SendTxMsgs(user, []sdk.Msg{txMsg})
+}
+
+// nonceMux guards nonce.json while a tx is signed, every tx of the game is signed by sendTx so that
+// no two txs take the same sequence
+var nonceMux sync.Mutex
+
+// nextNonce reads the signer's sequence from nonce.json, or from the account when it's missing, and stores the next one
+func nextNonce(signer string) (uint64, uint64, error) {
 	t := GetTestingT()
-	log.Println("started sending transaction", user.GetUserName(), txMsg)
-	txhash := pylonSDK.TestTxWithMsgWithNonce(t, txMsg, user.GetUserName(), false)
-	user.SetLastTransaction(txhash, txMsg.Type())
-	log.Println("ended sending transaction")
+	accInfo := pylonSDK.GetAccountInfoFromAddr(signer, t)
+	nonce := accInfo.GetSequence()
+	nonceFile := filepath.Join("./", "nonce.json")
+	nonceMap := make(map[string]uint64)
+	if fileExists(nonceFile) {
+		nonceBytes, err := ioutil.ReadFile(nonceFile)
+		if err != nil {
+			return 0, 0, err
+		}
+		if err := json.Unmarshal(nonceBytes, &nonceMap); err != nil {
+			return 0, 0, err
+		}
+		nonce = nonceMap[signer]
+	}
+	nonceMap[signer] = nonce + 1
+	nonceOutput, err := json.Marshal(nonceMap)
+	if err != nil {
+		return 0, 0, err
+	}
+	return nonce, accInfo.GetAccountNumber(), ioutil.WriteFile(nonceFile, nonceOutput, 0644)
+}
+
+// broadcastSignedTx broadcasts signed tx file through rest endpoint when it's used, otherwise through cli
+func broadcastSignedTx(signedTxFile string) (string, error) {
+	if len(pylonSDK.CLIOpts.RestEndpoint) == 0 {
+		output, err := pylonSDK.RunPylonsCli([]string{"tx", "broadcast", signedTxFile}, "")
+		if err != nil {
+			return "", err
+		}
+		txResp := pylonSDK.SuccessTxResp{}
+		if err := json.Unmarshal(output, &txResp); err != nil {
+			return "", fmt.Errorf("error broadcasting transaction: %s", string(output))
+		}
+		return txResp.TxHash, nil
+	}
+	signedTx, err := ioutil.ReadFile(signedTxFile)
+	if err != nil {
+		return "", err
+	}
+	postBodyJSON := make(map[string]interface{})
+	json.Unmarshal(signedTx, &postBodyJSON)
+	postBodyJSON["tx"] = postBodyJSON["value"]
+	postBodyJSON["value"] = nil
+	postBodyJSON["mode"] = "sync"
+	postBody, err := json.Marshal(postBodyJSON)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.Post(pylonSDK.CLIOpts.RestEndpoint+"/txs", "application/json", bytes.NewBuffer(postBody))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result map[string]string
+	json.NewDecoder(resp.Body).Decode(&result)
+	if len(result["txhash"]) == 0 {
+		return "", fmt.Errorf("error broadcasting transaction: %v", result)
+	}
+	return result["txhash"], nil
+}
+
+// SendTxMsgs sends several messages in one transaction, they succeed or fail together
+func SendTxMsgs(user User, txMsgs []sdk.Msg) (string, error) {
+	return sendTx(user, txMsgs, txMsgs[0].Type())
+}
+
+// sendTx signs and broadcasts a transaction of the messages and stores it as the last transaction with the meta data
+func sendTx(user User, txMsgs []sdk.Msg, txMetaData string) (string, error) {
+	log.Println("started sending transaction", user.GetUserName(), txMsgs)
+	signer := pylonSDK.GetAccountAddr(user.GetUserName(), nil)
+
+	txModel, err := pylonSDK.GenTxWithMsg(txMsgs)
+	if err != nil {
+		return "", err
+	}
+	output, err := pylonSDK.GetAminoCdc().MarshalJSON(txModel)
+	if err != nil {
+		return "", err
+	}
+	tmpDir, err := ioutil.TempDir("", "pylons")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	rawTxFile := filepath.Join(tmpDir, "raw_tx.json")
+	signedTxFile := filepath.Join(tmpDir, "signed_tx.json")
+	if err := ioutil.WriteFile(rawTxFile, output, 0644); err != nil {
+		return "", err
+	}
+
+	nonceMux.Lock()
+	nonce, accNum, err := nextNonce(signer)
+	if err != nil {
+		nonceMux.Unlock()
+		return "", err
+	}
+	txSignArgs := []string{"tx", "sign", rawTxFile,
+		"--from", signer,
+		"--offline",
+		"--chain-id", "pylonschain",
+		"--sequence", strconv.FormatUint(nonce, 10),
+		"--account-number", strconv.FormatUint(accNum, 10),
+	}
+	signedTx, err := pylonSDK.RunPylonsCli(txSignArgs, "11111111\n")
+	nonceMux.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("error signing transaction: %s %s", string(signedTx), err.Error())
+	}
+	if err := ioutil.WriteFile(signedTxFile, signedTx, 0644); err != nil {
+		return "", err
+	}
+
+	txhash, err := broadcastSignedTx(signedTxFile)
+	if err != nil {
+		return "", err
+	}
+	user.SetLastTransaction(txhash, txMetaData)
+	log.Println("ended sending transaction", txhash)
 	return txhash, nil
 }
@@ -18,6 +18,7 @@ const ITEM_BUYREQ_TRDINFO = "sword buy request created by loud game"
 const CHAR_BUYREQ_TRDINFO = "character buy request created by loud game"
 const ITEM_SELREQ_TRDINFO = "sword sell request created by loud game"
 const CHAR_SELREQ_TRDINFO = "character sell request created by loud game"
+const BARTER_TRDINFO = "barter request created by loud game"
 
 func CreateCookbook(user User) (string, error) { // This is for afti develop mode automation test is only using
 	t := GetTestingT()
@@ -37,6 +38,7 @@ func CreateCookbook(user User) (string, error) { // This is for afti develop mod
 	)
 
 	txhash, _ := SendTxMsg(user, ccbMsg)
+	MarkLoudRecipesStale()
 	if AutomateInput {
 		ok, err := CheckSignatureMatchWithAftiCli(t, txhash, user.GetPrivKey(), ccbMsg, username, false)
 		if !ok || err != nil {
@@ -76,7 +78,27 @@ func RunHuntRecipe(rcpName string, user User) (string, error) {
 		itemIDs = []string{activeCharacterID, activeWeapon.ID}
 	}
 
-	return ExecuteRecipe(user, rcpName, itemIDs)
+	activeArmor := user.GetActiveArmor()
+	if _, ok := ArmorRcpNames[rcpName]; ok && activeArmor != nil {
+		itemIDs = append(itemIDs, activeArmor.ID)
+	} else {
+		activeArmor = nil
+	}
+
+	consumable := user.GetActiveConsumable()
+	if len(consumable) > 0 {
+		consumableItem := user.GetActiveConsumableItem()
+		if consumableItem == nil {
+			return "", fmt.Errorf("you don't have %s", consumable)
+		}
+		itemIDs = append(itemIDs, consumableItem.ID)
+	}
+	variantRcpName, ok := HuntRecipeVariant(rcpName, activeArmor != nil, consumable)
+	if !ok {
+		return "", fmt.Errorf("%s can't be used for this action", consumable)
+	}
+
+	return ExecuteRecipe(user, variantRcpName, itemIDs)
 }
 
 func HuntRabbits(user User) (string, error) {
@@ -135,15 +157,10 @@ func BuyCharacter(user User, ch Character) (string, error) {
 }
 
 func RenameCharacter(user User, ch Character, newName string) (string, error) {
-	t := GetTestingT()
 	addr := pylonSDK.GetAccountAddr(user.GetUserName(), nil)
 	sdkAddr, _ := sdk.AccAddressFromBech32(addr)
 	renameMsg := msgs.NewMsgUpdateItemString(ch.ID, "Name", newName, sdkAddr)
-	log.Println("started sending transaction", user.GetUserName(), renameMsg)
-	txhash := pylonSDK.TestTxWithMsgWithNonce(t, renameMsg, user.GetUserName(), false)
-	user.SetLastTransaction(txhash, Sprintf("rename character from %s to %s", ch.Name, newName))
-	log.Println("ended sending transaction")
-	return txhash, nil
+	return sendTx(user, []sdk.Msg{renameMsg}, Sprintf("rename character from %s to %s", ch.Name, newName))
 }
 
 func Buy(user User, item Item) (string, error) {
@@ -173,6 +190,14 @@ func Buy(user User, item Item) (string, error) {
 			rcpName = RCP_BUY_IRON_SWORD
 			itemIDs = []string{user.InventoryItemIDByName(TROLL_TOES)}
 		}
+	case HEALING_POTION:
+		rcpName = RCP_BUY_HEALING_POTION
+	case REVIVE_SCROLL:
+		rcpName = RCP_BUY_REVIVE_SCROLL
+	case LEATHER_ARMOR:
+		rcpName = RCP_BUY_LEATHER_ARMOR
+	case IRON_SHIELD:
+		rcpName = RCP_BUY_IRON_SHIELD
 	case ANGEL_SWORD:
 		if item.Level == 1 {
 			rcpName = RCP_BUY_ANGEL_SWORD
@@ -381,9 +406,61 @@ func CreateSellCharacterTrdReq(user User, activeCharacter Character, pylonEnterV
 	return SendTxMsg(user, createTrdMsg)
 }
 
-func FulfillTrade(user User, tradeID string) (string, error) {
+func CreateBarterTrdReq(user User, offerItems []Item, offerPylon, offerGold int, wantItems []ItemSpec, wantPylon, wantGold int) (string, error) {
+	// trade creator will get wanted items and coins from offered items and coins
+	if len(offerItems) == 0 && offerPylon == 0 && offerGold == 0 {
+		return "", errors.New("You should offer at least one item, pylons or gold")
+	}
+	if len(wantItems) == 0 && wantPylon == 0 && wantGold == 0 {
+		return "", errors.New("You should want at least one item, pylons or gold")
+	}
+	if offerPylon > user.GetPylonAmount() {
+		return "", errors.New("You don't have enough pylons to offer")
+	}
+	if offerGold > user.GetGold() {
+		return "", errors.New("You don't have enough gold to offer")
+	}
+
+	sdkAddr := GetSDKAddrFromUserName(user.GetUserName())
+
+	var inputCoinList types.CoinInputList
+	if wantPylon > 0 {
+		inputCoinList = append(inputCoinList, types.GenCoinInputList("pylon", int64(wantPylon))...)
+	}
+	if wantGold > 0 {
+		inputCoinList = append(inputCoinList, types.GenCoinInputList("loudcoin", int64(wantGold))...)
+	}
+	var itemInputs types.ItemInputList
+	for _, itspec := range wantItems {
+		itemInputs = append(itemInputs, GetItemInputsFromItemSpec(itspec)...)
+	}
+	// NewCoins sorts coins by denom and drops zero amounts
+	outputCoins := sdk.NewCoins(sdk.NewInt64Coin("loudcoin", int64(offerGold)), sdk.NewInt64Coin("pylon", int64(offerPylon)))
+	var itemOutputList types.ItemList
+	for _, item := range offerItems {
+		itemOutputs, err := GetItemOutputFromActiveItem(item)
+		if err != nil {
+			return "", err
+		}
+		itemOutputList = append(itemOutputList, itemOutputs...)
+	}
+
+	extraInfo := BARTER_TRDINFO
+
+	createTrdMsg := msgs.NewMsgCreateTrade(
+		inputCoinList,
+		itemInputs,
+		outputCoins,
+		itemOutputList,
+		extraInfo,
+		sdkAddr)
+	return SendTxMsg(user, createTrdMsg)
+}
+
+// FulfillTrade fulfills a trade giving items of itemIDs for trade's item inputs
+func FulfillTrade(user User, tradeID string, itemIDs []string) (string, error) {
 	sdkAddr := GetSDKAddrFromUserName(user.GetUserName())
-	ffTrdMsg := msgs.NewMsgFulfillTrade(tradeID, sdkAddr, []string{})
+	ffTrdMsg := msgs.NewMsgFulfillTrade(tradeID, sdkAddr, itemIDs)
 
 	return SendTxMsg(user, ffTrdMsg)
 }
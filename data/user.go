@@ -11,6 +11,9 @@ const (
 	DEVELOP
 )
 
+// NO_ACTIVE_ID is the active character, weapon or armor ID when it's deselected
+const NO_ACTIVE_ID = "none"
+
 // User represents an active user in the system.
 type User interface {
 	SetAddress(string)
@@ -18,8 +21,10 @@ type User interface {
 	SetPylonAmount(int)
 	SetItems([]Item)
 	SetCharacters([]Character)
-	SetActiveWeaponIndex(idx int)
-	SetActiveCharacterIndex(idx int)
+	SetActiveWeaponID(id string)
+	SetActiveCharacterID(id string)
+	SetActiveArmorID(id string)
+	SetActiveConsumable(string)
 	SetLocation(UserLocation)
 	SetLastTransaction(string, string)
 	SetLatestBlockHeight(int64)
@@ -28,15 +33,21 @@ type User interface {
 	InventoryItemIDByName(string) string
 	InventoryIronSwords() []Item
 	InventorySwords() []Item
+	InventoryArmors() []Item
+	InventoryConsumables() []Item
 	InventoryCharacters() []Character
 	InventoryUpgradableItems() []Item
 	InventorySellableItems() []Item
 	GetLocation() UserLocation
 	GetPrivKey() string
-	GetActiveWeaponIndex() int
-	GetActiveCharacterIndex() int
+	GetActiveWeaponID() string
+	GetActiveCharacterID() string
 	GetActiveCharacter() *Character
 	GetActiveWeapon() *Item
+	GetActiveArmorID() string
+	GetActiveArmor() *Item
+	GetActiveConsumable() string
+	GetActiveConsumableItem() *Item
 	GetAddress() string
 	GetGold() int
 	GetPylonAmount() int
@@ -44,6 +55,21 @@ type User interface {
 	GetLastTxHash() string
 	GetLastTxMetaData() string
 	GetLatestBlockHeight() int64
+	GetTradeHistory() []TradeRecord
+	AddTradeHistory([]TradeRecord)
+	GetBattleHistory() []BattleReport
+	AddBattleReport(BattleReport)
+	GetLeaderboard() Leaderboard
+	SetLeaderboard(Leaderboard)
+	GetKnownUsernames() map[string]string
+	GetMyOrderSnapshot() MyOrderSnapshot
+	SetMyOrderSnapshot(MyOrderSnapshot)
+	GetWatchRules() []WatchRule
+	SetWatchRules([]WatchRule)
+	GetWatchBell() bool
+	SetWatchBell(bool)
+	GetAchievements() map[string]int64
+	UnlockAchievement(string, int64)
 	Reload()
 	Save()
 }
@@ -0,0 +1,190 @@
+package loud
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WatchRule alerts when a trade request of Kind matching name and level is listed
+// at MaxPrice or lower, or requested at MinPrice or higher
+type WatchRule struct {
+	Query    string // raw rule entered by user
+	Kind     string // gold, item or character
+	Name     string
+	Level    [2]int  // 0 means no limit
+	MaxPrice float64 // 0 means no limit, pylons per gold for gold rules
+	MinPrice float64 // 0 means no limit, pylons per gold for gold rules
+}
+
+// WatchAlert is a trade request matching a watch rule
+type WatchAlert struct {
+	Rule     WatchRule
+	Market   string // creator side of matching trade request, same as MyOrder.Market
+	TrdReqID string
+	Label    string // name and level of item or character, gold amount for gold
+	Price    float64
+}
+
+// WatchAlerts is all trade requests matching user's watch rules on latest sync
+var WatchAlerts = []WatchAlert{}
+
+// LastWatchAlerts is matches which were not alerted before the latest sync
+var LastWatchAlerts = []WatchAlert{}
+
+var watchAlerted = map[string]bool{}
+
+var watchKinds = map[string]string{
+	"gold":      TRDHIST_GOLD,
+	"item":      TRDHIST_ITEM,
+	"character": TRDHIST_CHAR,
+	"char":      TRDHIST_CHAR,
+}
+
+func parsePrice(value string) (float64, error) {
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price <= 0 {
+		return 0, fmt.Errorf("%s is not a valid price", value)
+	}
+	return price, nil
+}
+
+// ParseWatchRule parses watch rule, eg. "item copper sword lv:2 max:300" or "gold below:0.01"
+// first word is the kind and words without a key are used as name
+func ParseWatchRule(query string) (WatchRule, error) {
+	rule := WatchRule{Query: strings.TrimSpace(query)}
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return rule, errors.New("watch rule is empty")
+	}
+	kind, ok := watchKinds[strings.ToLower(words[0])]
+	if !ok {
+		return rule, errors.New("watch rule should start with gold, item or character")
+	}
+	rule.Kind = kind
+	names := []string{}
+	for _, word := range words[1:] {
+		kv := strings.SplitN(word, ":", 2)
+		if len(kv) == 1 {
+			names = append(names, word)
+			continue
+		}
+		var err error
+		switch strings.ToLower(kv[0]) {
+		case "name":
+			names = append(names, kv[1])
+		case "lv", "level":
+			rule.Level, err = parseIntRange(kv[1])
+		case "max", "below":
+			rule.MaxPrice, err = parsePrice(kv[1])
+		case "min", "above":
+			rule.MinPrice, err = parsePrice(kv[1])
+		default:
+			err = errors.New("unknown watch rule key " + kv[0])
+		}
+		if err != nil {
+			return rule, err
+		}
+	}
+	rule.Name = strings.Join(names, " ")
+	if rule.MaxPrice == 0 && rule.MinPrice == 0 {
+		return rule, errors.New("watch rule needs max or min price")
+	}
+	if rule.Kind == TRDHIST_GOLD && (len(rule.Name) > 0 || rule.Level != [2]int{}) {
+		return rule, errors.New("gold watch rule can't have name or level")
+	}
+	return rule, nil
+}
+
+func (rule WatchRule) matchItem(name string, level [2]int) bool {
+	if len(rule.Name) > 0 && !strings.Contains(strings.ToLower(name), strings.ToLower(rule.Name)) {
+		return false
+	}
+	return overlapRange(rule.Level, level)
+}
+
+// Matches returns all trade requests of other users matching the rule
+func (rule WatchRule) Matches() []WatchAlert {
+	alerts := []WatchAlert{}
+	add := func(market string, id string, label string, price float64) {
+		alerts = append(alerts, WatchAlert{
+			Rule:     rule,
+			Market:   market,
+			TrdReqID: id,
+			Label:    label,
+			Price:    price,
+		})
+	}
+	listed := func(price float64) bool { return rule.MaxPrice > 0 && price <= rule.MaxPrice }
+	requested := func(price float64) bool { return rule.MinPrice > 0 && price >= rule.MinPrice }
+
+	switch rule.Kind {
+	case TRDHIST_GOLD:
+		for _, request := range SellTrdReqs {
+			if !request.IsMyTrdReq && listed(request.Price) {
+				add(MYORD_SELL_GOLD, request.ID, fmt.Sprintf("%d", request.Amount), request.Price)
+			}
+		}
+		for _, request := range BuyTrdReqs {
+			if !request.IsMyTrdReq && requested(request.Price) {
+				add(MYORD_BUY_GOLD, request.ID, fmt.Sprintf("%d", request.Amount), request.Price)
+			}
+		}
+	case TRDHIST_ITEM:
+		for _, request := range ItemSellTrdReqs {
+			level := [2]int{request.TItem.Level, request.TItem.Level}
+			if !request.IsMyTrdReq && rule.matchItem(request.TItem.Name, level) && listed(float64(request.Price)) {
+				add(MYORD_SELL_ITEM, request.ID, fmt.Sprintf("%s Lv%d", request.TItem.Name, request.TItem.Level), float64(request.Price))
+			}
+		}
+		for _, request := range ItemBuyTrdReqs {
+			if !request.IsMyTrdReq && rule.matchItem(request.TItem.Name, request.TItem.Level) && requested(float64(request.Price)) {
+				add(MYORD_BUY_ITEM, request.ID, fmt.Sprintf("%s Lv%d-%d", request.TItem.Name, request.TItem.Level[0], request.TItem.Level[1]), float64(request.Price))
+			}
+		}
+	case TRDHIST_CHAR:
+		for _, request := range CharacterSellTrdReqs {
+			level := [2]int{request.TCharacter.Level, request.TCharacter.Level}
+			if !request.IsMyTrdReq && rule.matchItem(request.TCharacter.Name, level) && listed(float64(request.Price)) {
+				add(MYORD_SELL_CHAR, request.ID, fmt.Sprintf("%s Lv%d", request.TCharacter.Name, request.TCharacter.Level), float64(request.Price))
+			}
+		}
+		for _, request := range CharacterBuyTrdReqs {
+			if !request.IsMyTrdReq && rule.matchItem(request.TCharacter.Name, request.TCharacter.Level) && requested(float64(request.Price)) {
+				add(MYORD_BUY_CHAR, request.ID, fmt.Sprintf("%s Lv%d-%d", request.TCharacter.Name, request.TCharacter.Level[0], request.TCharacter.Level[1]), float64(request.Price))
+			}
+		}
+	}
+	return alerts
+}
+
+// UpdateWatchAlerts evaluates user's watch rules against trade requests
+// a trade request is alerted only once per rule
+func UpdateWatchAlerts(user User) {
+	alerts := []WatchAlert{}
+	newAlerts := []WatchAlert{}
+	for _, rule := range user.GetWatchRules() {
+		for _, alert := range rule.Matches() {
+			alerts = append(alerts, alert)
+			key := rule.Query + "/" + alert.TrdReqID
+			if !watchAlerted[key] {
+				watchAlerted[key] = true
+				newAlerts = append(newAlerts, alert)
+			}
+		}
+	}
+	WatchAlerts = alerts
+	LastWatchAlerts = newAlerts
+}
+
+// WatchAlertsForRule returns current matches of a rule
+func WatchAlertsForRule(rule WatchRule) []WatchAlert {
+	alerts := []WatchAlert{}
+	for _, alert := range WatchAlerts {
+		if alert.Rule.Query == rule.Query {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
@@ -21,7 +21,7 @@ require (
 	github.com/libp2p/go-buffer-pool v0.0.2 // indirect
 	github.com/mattn/go-colorable v0.1.4 // indirect
 	github.com/mattn/go-isatty v0.0.11 // indirect
-	github.com/mattn/go-runewidth v0.0.7 // indirect
+	github.com/mattn/go-runewidth v0.0.7
 	github.com/mgutz/ansi v0.0.0-20170206155736-9520e82c474b
 	github.com/nicksnyder/go-i18n/v2 v2.0.3
 	github.com/nsf/termbox-go v0.0.0-20191229070316-58d4fcbce2a7
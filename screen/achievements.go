@@ -0,0 +1,41 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+// pushAchievementToasts unlocks achievements passing their checks and announces new ones
+func (screen *GameScreen) pushAchievementToasts() bool {
+	newAchievements := loud.UpdateAchievements(screen.user)
+	if len(newAchievements) == 0 {
+		return false
+	}
+	for _, achievement := range newAchievements {
+		screen.toasts = append(screen.toasts, loud.Sprintf("Achievement unlocked: %s", loud.Localize(achievement.Title)))
+	}
+	screen.SaveGame()
+	return true
+}
+
+// achievementsDesc lists all achievements with the unlock time of unlocked ones
+func (screen *GameScreen) achievementsDesc() string {
+	unlocked := screen.user.GetAchievements()
+	lines := []string{
+		loud.Sprintf("Achievements: %d/%d unlocked", len(unlocked), len(loud.Achievements)),
+		"",
+	}
+	for _, achievement := range loud.Achievements {
+		line := fmt.Sprintf("%s - %s", loud.Localize(achievement.Title), loud.Localize(achievement.Desc))
+		if at, ok := unlocked[achievement.ID]; ok {
+			line = fmt.Sprintf("🏆 %s (%s)", line, time.Unix(at, 0).Format("2006-01-02"))
+		} else {
+			line = "🔒 " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -26,16 +26,36 @@ func (screen *GameScreen) RunTxProcess(waitStatus ScreenStatus, resultStatus Scr
 	}()
 }
 
-func (screen *GameScreen) RunActiveCharacterSelect(index int) {
-	screen.user.SetActiveCharacterIndex(index)
+func (screen *GameScreen) RunActiveCharacterSelect(id string) {
+	for _, char := range screen.user.InventoryCharacters() {
+		if char.ID == id && screen.offerUnlock(char.ID, formatCharacter(char)) {
+			return
+		}
+	}
+	screen.user.SetActiveCharacterID(id)
 	screen.SetScreenStatusAndRefresh(RSLT_SEL_ACT_CHAR)
 }
 
-func (screen *GameScreen) RunActiveWeaponSelect(index int) {
-	screen.user.SetActiveWeaponIndex(index)
+func (screen *GameScreen) RunActiveWeaponSelect(id string) {
+	for _, sword := range screen.user.InventorySwords() {
+		if sword.ID == id && screen.offerUnlock(sword.ID, formatItem(sword)) {
+			return
+		}
+	}
+	screen.user.SetActiveWeaponID(id)
 	screen.SetScreenStatusAndRefresh(RSLT_SEL_ACT_WEAPON)
 }
 
+func (screen *GameScreen) RunActiveArmorSelect(id string) {
+	for _, armor := range screen.user.InventoryArmors() {
+		if armor.ID == id && screen.offerUnlock(armor.ID, formatItem(armor)) {
+			return
+		}
+	}
+	screen.user.SetActiveArmorID(id)
+	screen.SetScreenStatusAndRefresh(RSLT_SEL_ACT_ARMOR)
+}
+
 func (screen *GameScreen) RunCharacterRename(newName string) {
 	screen.RunTxProcess(W8_RENAME_CHAR, RSLT_RENAME_CHAR, func() (string, error) {
 		return loud.RenameCharacter(screen.user, screen.activeCharacter, newName)
@@ -72,7 +92,7 @@ func (screen *GameScreen) RunActiveItemUpgrade() {
 }
 
 func (screen *GameScreen) RunHuntRabbits() {
-	screen.RunTxProcess(W8_HUNT_RABBITS, RSLT_HUNT_RABBITS, func() (string, error) {
+	screen.RunBattleTxProcess(W8_HUNT_RABBITS, RSLT_HUNT_RABBITS, func() (string, error) {
 		return loud.HuntRabbits(screen.user)
 	})
 }
@@ -84,7 +104,7 @@ func (screen *GameScreen) RunFightGiant() {
 		screen.Render()
 		return
 	}
-	screen.RunTxProcess(W8_FIGHT_GIANT, RSLT_FIGHT_GIANT, func() (string, error) {
+	screen.RunBattleTxProcess(W8_FIGHT_GIANT, RSLT_FIGHT_GIANT, func() (string, error) {
 		return loud.FightGiant(screen.user)
 	})
 }
@@ -96,7 +116,7 @@ func (screen *GameScreen) RunFightDragonFire() {
 		screen.Render()
 		return
 	}
-	screen.RunTxProcess(W8_FIGHT_DRAGONFIRE, RSLT_FIGHT_DRAGONFIRE, func() (string, error) {
+	screen.RunBattleTxProcess(W8_FIGHT_DRAGONFIRE, RSLT_FIGHT_DRAGONFIRE, func() (string, error) {
 		return loud.FightDragonFire(screen.user)
 	})
 }
@@ -108,7 +128,7 @@ func (screen *GameScreen) RunFightDragonIce() {
 		screen.Render()
 		return
 	}
-	screen.RunTxProcess(W8_FIGHT_DRAGONICE, RSLT_FIGHT_DRAGONICE, func() (string, error) {
+	screen.RunBattleTxProcess(W8_FIGHT_DRAGONICE, RSLT_FIGHT_DRAGONICE, func() (string, error) {
 		return loud.FightDragonIce(screen.user)
 	})
 }
@@ -120,7 +140,7 @@ func (screen *GameScreen) RunFightDragonAcid() {
 		screen.Render()
 		return
 	}
-	screen.RunTxProcess(W8_FIGHT_DRAGONACID, RSLT_FIGHT_DRAGONACID, func() (string, error) {
+	screen.RunBattleTxProcess(W8_FIGHT_DRAGONACID, RSLT_FIGHT_DRAGONACID, func() (string, error) {
 		return loud.FightDragonAcid(screen.user)
 	})
 }
@@ -132,25 +152,25 @@ func (screen *GameScreen) RunFightDragonUndead() {
 		screen.Render()
 		return
 	}
-	screen.RunTxProcess(W8_FIGHT_DRAGONUNDEAD, RSLT_FIGHT_DRAGONUNDEAD, func() (string, error) {
+	screen.RunBattleTxProcess(W8_FIGHT_DRAGONUNDEAD, RSLT_FIGHT_DRAGONUNDEAD, func() (string, error) {
 		return loud.FightDragonUndead(screen.user)
 	})
 }
 
 func (screen *GameScreen) RunFightTroll() {
-	screen.RunTxProcess(W8_FIGHT_TROLL, RSLT_FIGHT_TROLL, func() (string, error) {
+	screen.RunBattleTxProcess(W8_FIGHT_TROLL, RSLT_FIGHT_TROLL, func() (string, error) {
 		return loud.FightTroll(screen.user)
 	})
 }
 
 func (screen *GameScreen) RunFightWolf() {
-	screen.RunTxProcess(W8_FIGHT_WOLF, RSLT_FIGHT_WOLF, func() (string, error) {
+	screen.RunBattleTxProcess(W8_FIGHT_WOLF, RSLT_FIGHT_WOLF, func() (string, error) {
 		return loud.FightWolf(screen.user)
 	})
 }
 
 func (screen *GameScreen) RunFightGoblin() {
-	screen.RunTxProcess(W8_FIGHT_GOBLIN, RSLT_FIGHT_GOBLIN, func() (string, error) {
+	screen.RunBattleTxProcess(W8_FIGHT_GOBLIN, RSLT_FIGHT_GOBLIN, func() (string, error) {
 		return loud.FightGoblin(screen.user)
 	})
 }
@@ -168,7 +188,7 @@ func (screen *GameScreen) RunSelectedLoudBuyTrdReq() {
 			})
 		} else {
 			screen.RunTxProcess(W8_FULFILL_BUY_LOUD_TRDREQ, RSLT_FULFILL_BUY_LOUD_TRDREQ, func() (string, error) {
-				return loud.FulfillTrade(screen.user, screen.activeTrdReq.ID)
+				return loud.FulfillTrade(screen.user, screen.activeTrdReq.ID, []string{})
 			})
 		}
 	}
@@ -186,37 +206,56 @@ func (screen *GameScreen) RunSelectedLoudSellTrdReq() {
 			})
 		} else {
 			screen.RunTxProcess(W8_FULFILL_SELL_LOUD_TRDREQ, RSLT_FULFILL_SELL_LOUD_TRDREQ, func() (string, error) {
-				return loud.FulfillTrade(screen.user, screen.activeTrdReq.ID)
+				return loud.FulfillTrade(screen.user, screen.activeTrdReq.ID, []string{})
 			})
 		}
 	}
 }
 
 func (screen *GameScreen) RunSelectedItemBuyTrdReq() {
-	if len(loud.ItemBuyTrdReqs) <= screen.activeLine || screen.activeLine < 0 {
+	requests := screen.filteredItemBuyTrdReqs()
+	if len(requests) <= screen.activeLine || screen.activeLine < 0 {
 		screen.txFailReason = loud.Localize("you haven't selected any buy item request")
 		screen.SetScreenStatusAndRefresh(RSLT_FULFILL_BUYITM_TRDREQ)
 	} else {
-		atir := loud.ItemBuyTrdReqs[screen.activeLine]
+		atir := requests[screen.activeLine]
 		screen.activeItemTrdReq = atir
 		if atir.IsMyTrdReq {
 			screen.RunTxProcess(W8_CANCEL_TRDREQ, RSLT_CANCEL_TRDREQ, func() (string, error) {
 				return loud.CancelTrade(screen.user, atir.ID)
 			})
+		} else if len(loud.ItemBuyTrdReqCandidates(screen.user, atir)) == 0 {
+			screen.txFailReason = loud.Localize("you don't have an item matching this request")
+			screen.SetScreenStatusAndRefresh(RSLT_FULFILL_BUYITM_TRDREQ)
 		} else {
-			screen.RunTxProcess(W8_FULFILL_BUYITM_TRDREQ, RSLT_FULFILL_BUYITM_TRDREQ, func() (string, error) {
-				return loud.FulfillTrade(screen.user, atir.ID)
-			})
+			// lowest value candidate is selected by default
+			screen.SetScreenStatus(FULFILL_BUYITM_TRDREQ_SEL_ITEM)
+			screen.activeLine = 0
+			screen.Render()
 		}
 	}
 }
 
+func (screen *GameScreen) RunFulfillItemBuyTrdReq() {
+	atir := screen.activeItemTrdReq.(loud.ItemBuyTrdReq)
+	candidates := loud.ItemBuyTrdReqCandidates(screen.user, atir)
+	if len(candidates) <= screen.activeLine || screen.activeLine < 0 {
+		return
+	}
+	screen.activeItem = candidates[screen.activeLine]
+	itemIDs := []string{screen.activeItem.ID}
+	screen.RunTxProcess(W8_FULFILL_BUYITM_TRDREQ, RSLT_FULFILL_BUYITM_TRDREQ, func() (string, error) {
+		return loud.FulfillTrade(screen.user, atir.ID, itemIDs)
+	})
+}
+
 func (screen *GameScreen) RunSelectedItemSellTrdReq() {
-	if len(loud.ItemSellTrdReqs) <= screen.activeLine || screen.activeLine < 0 {
+	requests := screen.filteredItemSellTrdReqs()
+	if len(requests) <= screen.activeLine || screen.activeLine < 0 {
 		screen.txFailReason = loud.Localize("you haven't selected any sell item request")
 		screen.SetScreenStatusAndRefresh(RSLT_FULFILL_SELLITM_TRDREQ)
 	} else {
-		sstr := loud.ItemSellTrdReqs[screen.activeLine]
+		sstr := requests[screen.activeLine]
 		screen.activeItemTrdReq = sstr
 		if sstr.IsMyTrdReq {
 			screen.RunTxProcess(W8_CANCEL_TRDREQ, RSLT_CANCEL_TRDREQ, func() (string, error) {
@@ -224,37 +263,56 @@ func (screen *GameScreen) RunSelectedItemSellTrdReq() {
 			})
 		} else {
 			screen.RunTxProcess(W8_FULFILL_SELLITM_TRDREQ, RSLT_FULFILL_SELLITM_TRDREQ, func() (string, error) {
-				return loud.FulfillTrade(screen.user, sstr.ID)
+				return loud.FulfillTrade(screen.user, sstr.ID, []string{})
 			})
 		}
 	}
 }
 
 func (screen *GameScreen) RunSelectedCharacterBuyTrdReq() {
-	if len(loud.CharacterBuyTrdReqs) <= screen.activeLine || screen.activeLine < 0 {
+	requests := screen.filteredCharacterBuyTrdReqs()
+	if len(requests) <= screen.activeLine || screen.activeLine < 0 {
 		screen.txFailReason = loud.Localize("you haven't selected any buy character request")
 		screen.SetScreenStatusAndRefresh(RSLT_FULFILL_BUYCHR_TRDREQ)
 	} else {
-		cbtr := loud.CharacterBuyTrdReqs[screen.activeLine]
+		cbtr := requests[screen.activeLine]
 		screen.activeItemTrdReq = cbtr
 		if cbtr.IsMyTrdReq {
 			screen.RunTxProcess(W8_CANCEL_TRDREQ, RSLT_CANCEL_TRDREQ, func() (string, error) {
 				return loud.CancelTrade(screen.user, cbtr.ID)
 			})
+		} else if len(loud.CharacterBuyTrdReqCandidates(screen.user, cbtr)) == 0 {
+			screen.txFailReason = loud.Localize("you don't have a character matching this request")
+			screen.SetScreenStatusAndRefresh(RSLT_FULFILL_BUYCHR_TRDREQ)
 		} else {
-			screen.RunTxProcess(W8_FULFILL_BUYCHR_TRDREQ, RSLT_FULFILL_BUYCHR_TRDREQ, func() (string, error) {
-				return loud.FulfillTrade(screen.user, cbtr.ID)
-			})
+			// lowest value candidate is selected by default
+			screen.SetScreenStatus(FULFILL_BUYCHR_TRDREQ_SEL_CHR)
+			screen.activeLine = 0
+			screen.Render()
 		}
 	}
 }
 
+func (screen *GameScreen) RunFulfillCharacterBuyTrdReq() {
+	cbtr := screen.activeItemTrdReq.(loud.CharacterBuyTrdReq)
+	candidates := loud.CharacterBuyTrdReqCandidates(screen.user, cbtr)
+	if len(candidates) <= screen.activeLine || screen.activeLine < 0 {
+		return
+	}
+	screen.activeCharacter = candidates[screen.activeLine]
+	itemIDs := []string{screen.activeCharacter.ID}
+	screen.RunTxProcess(W8_FULFILL_BUYCHR_TRDREQ, RSLT_FULFILL_BUYCHR_TRDREQ, func() (string, error) {
+		return loud.FulfillTrade(screen.user, cbtr.ID, itemIDs)
+	})
+}
+
 func (screen *GameScreen) RunSelectedCharacterSellTrdReq() {
-	if len(loud.CharacterSellTrdReqs) <= screen.activeLine || screen.activeLine < 0 {
+	requests := screen.filteredCharacterSellTrdReqs()
+	if len(requests) <= screen.activeLine || screen.activeLine < 0 {
 		screen.txFailReason = loud.Localize("you haven't selected any sell character request")
 		screen.SetScreenStatusAndRefresh(RSLT_FULFILL_SELLCHR_TRDREQ)
 	} else {
-		cstr := loud.CharacterSellTrdReqs[screen.activeLine]
+		cstr := requests[screen.activeLine]
 		screen.activeItemTrdReq = cstr
 		if cstr.IsMyTrdReq {
 			screen.RunTxProcess(W8_CANCEL_TRDREQ, RSLT_CANCEL_TRDREQ, func() (string, error) {
@@ -262,8 +320,22 @@ func (screen *GameScreen) RunSelectedCharacterSellTrdReq() {
 			})
 		} else {
 			screen.RunTxProcess(W8_FULFILL_SELLCHR_TRDREQ, RSLT_FULFILL_SELLCHR_TRDREQ, func() (string, error) {
-				return loud.FulfillTrade(screen.user, cstr.ID)
+				return loud.FulfillTrade(screen.user, cstr.ID, []string{})
 			})
 		}
 	}
 }
+
+func (screen *GameScreen) RunMarketOrder() {
+	screen.SetScreenStatusAndRefresh(W8_MKTORD)
+
+	log.Println("started sending requests for market order", len(screen.marketOrder.Fills))
+	go func() {
+		screen.marketOrderRes = loud.ExecuteMarketOrder(screen.user, screen.marketOrder)
+		screen.txFailReason = ""
+		if filledAmount, _ := loud.MarketOrderFilled(screen.marketOrderRes); filledAmount == 0 {
+			screen.txFailReason = loud.Localize("none of the market order trades were fulfilled")
+		}
+		screen.SetScreenStatusAndRefresh(RSLT_MKTORD)
+	}()
+}
@@ -97,26 +97,37 @@ func (screen *GameScreen) sellCharacterDesc(activeCharacter loud.Character, pylo
 	return desc
 }
 
-func (screen *GameScreen) sellItemSpecDesc(activeItem loud.ItemSpec, pylonValue interface{}) string {
-	var desc = strings.Join([]string{
-		"\n",
-		formatItemSpec(activeItem),
-		"\n  ↓\n",
-		screen.pylonIcon(),
-		fmt.Sprintf("%v", pylonValue),
-	}, "")
-	return desc
-}
+func (screen *GameScreen) marketOrderResultDesc() (string, FontType) {
+	results := screen.marketOrderRes
+	filledAmount, filledTotal := loud.MarketOrderFilled(results)
+	numFilled := 0
+	for _, result := range results {
+		if len(result.FailReason) == 0 {
+			numFilled++
+		}
+	}
+	font := REGULAR
+	if numFilled < len(results) {
+		font = YELLOW
+	}
 
-func (screen *GameScreen) sellCharacterSpecDesc(activeCharacter loud.CharacterSpec, pylonValue interface{}) string {
-	var desc = strings.Join([]string{
-		"\n",
-		formatCharacterSpec(activeCharacter),
-		"\n  ↓\n",
-		screen.pylonIcon(),
-		fmt.Sprintf("%v", pylonValue),
-	}, "")
-	return desc
+	desc := loud.Sprintf("market order filled %d of %d trade requests", numFilled, len(results))
+	if screen.marketOrder.IsBuy {
+		desc += screen.buyLoudDesc(filledAmount, filledTotal)
+	} else {
+		desc += screen.sellLoudDesc(filledAmount, filledTotal)
+	}
+	desc += "\n"
+	for _, result := range results {
+		line := fmt.Sprintf("\n%s%d @ %.4f", screen.goldIcon(), result.TrdReq.Amount, result.TrdReq.Price)
+		if len(result.FailReason) > 0 {
+			line += ": " + loud.Localize(result.FailReason)
+		} else {
+			line += ": " + loud.Localize("fulfilled")
+		}
+		desc += line
+	}
+	return desc, font
 }
 
 func (screen *GameScreen) tradeTableColorDesc(width int) []string {
@@ -128,3 +139,12 @@ func (screen *GameScreen) tradeTableColorDesc(width int) []string {
 	infoLines = append(infoLines, screen.brownFont()(fillSpace(loud.Localize("brown trade line desc"), width)))
 	return infoLines
 }
+
+func (screen *GameScreen) orderBookColorDesc(width int) []string {
+	var infoLines = []string{screen.regularFont()(fillSpace("", width))}
+
+	infoLines = append(infoLines, screen.greenFont()(fillSpace(loud.Localize("green order book desc"), width)))
+	infoLines = append(infoLines, screen.redFont()(fillSpace(loud.Localize("red order book desc"), width)))
+	infoLines = append(infoLines, screen.brownFont()(fillSpace(loud.Localize("brown order book desc"), width)))
+	return infoLines
+}
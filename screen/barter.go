@@ -0,0 +1,257 @@
+package screen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+func (screen *GameScreen) barterOfferLabels(request loud.BarterTrdReq) []string {
+	labels := []string{}
+	for _, item := range request.OfferItems {
+		labels = append(labels, formatItem(item))
+	}
+	if request.OfferPylon > 0 {
+		labels = append(labels, fmt.Sprintf("%s%d", screen.pylonIcon(), request.OfferPylon))
+	}
+	if request.OfferGold > 0 {
+		labels = append(labels, fmt.Sprintf("%s%d", screen.goldIcon(), request.OfferGold))
+	}
+	return labels
+}
+
+func (screen *GameScreen) barterWantLabels(request loud.BarterTrdReq) []string {
+	labels := []string{}
+	for _, itspec := range request.WantItems {
+		labels = append(labels, formatItemSpec(itspec))
+	}
+	if request.WantPylon > 0 {
+		labels = append(labels, fmt.Sprintf("%s%d", screen.pylonIcon(), request.WantPylon))
+	}
+	if request.WantGold > 0 {
+		labels = append(labels, fmt.Sprintf("%s%d", screen.goldIcon(), request.WantGold))
+	}
+	return labels
+}
+
+// barterDraft is the barter request being created from selected items and entered pylons and gold
+func (screen *GameScreen) barterDraft() loud.BarterTrdReq {
+	request := loud.BarterTrdReq{
+		OfferPylon: screen.barterOfferPylon,
+		WantPylon:  screen.barterWantPylon,
+		OfferGold:  screen.barterOfferGold,
+		WantGold:   screen.barterWantGold,
+	}
+	for _, item := range loud.BarterOfferItems(screen.user) {
+		if screen.barterOfferIDs[item.ID] {
+			request.OfferItems = append(request.OfferItems, item)
+		}
+	}
+	for idx, itspec := range loud.WorldItemSpecs {
+		if screen.barterWantIdxs[idx] {
+			request.WantItems = append(request.WantItems, itspec)
+		}
+	}
+	return request
+}
+
+func (screen *GameScreen) startBarterCreation() {
+	screen.barterOfferIDs = make(map[string]bool)
+	screen.barterWantIdxs = make(map[int]bool)
+	screen.barterOfferPylon = 0
+	screen.barterWantPylon = 0
+	screen.barterOfferGold = 0
+	screen.barterWantGold = 0
+	screen.SetScreenStatus(CR8_BARTER_SEL_OFFER_ITEMS)
+	screen.activeLine = 0
+}
+
+func (screen *GameScreen) toggleBarterSelection() bool {
+	switch screen.scrStatus {
+	case CR8_BARTER_SEL_OFFER_ITEMS:
+		items := loud.BarterOfferItems(screen.user)
+		if len(items) <= screen.activeLine || screen.activeLine < 0 {
+			return false
+		}
+		itemID := items[screen.activeLine].ID
+		if screen.offerUnlock(itemID, formatItem(items[screen.activeLine])) {
+			return true
+		}
+		screen.barterOfferIDs[itemID] = !screen.barterOfferIDs[itemID]
+	case CR8_BARTER_SEL_WANT_ITEMS:
+		if len(loud.WorldItemSpecs) <= screen.activeLine || screen.activeLine < 0 {
+			return false
+		}
+		screen.barterWantIdxs[screen.activeLine] = !screen.barterWantIdxs[screen.activeLine]
+	default:
+		return false
+	}
+	screen.Render()
+	return true
+}
+
+// parseOptionalCoinValue parses pylon or gold amount of a barter, empty is none
+func parseOptionalCoinValue(text string) (int, error) {
+	if len(strings.TrimSpace(text)) == 0 {
+		return 0, nil
+	}
+	return strconv.Atoi(text)
+}
+
+func (screen *GameScreen) RunBarterTrdReqCreation() {
+	request := screen.barterDraft()
+	screen.activeBarterTrdReq = request
+	screen.RunTxProcess(W8_BARTER_TRDREQ_CREATION, RSLT_BARTER_TRDREQ_CREATION, func() (string, error) {
+		return loud.CreateBarterTrdReq(screen.user, request.OfferItems, request.OfferPylon, request.OfferGold, request.WantItems, request.WantPylon, request.WantGold)
+	})
+}
+
+func (screen *GameScreen) RunSelectedBarterTrdReq() {
+	if len(loud.BarterTrdReqs) <= screen.activeLine || screen.activeLine < 0 {
+		screen.txFailReason = loud.Localize("you haven't selected any barter request")
+		screen.SetScreenStatusAndRefresh(RSLT_FULFILL_BARTER_TRDREQ)
+		return
+	}
+	request := loud.BarterTrdReqs[screen.activeLine]
+	screen.activeBarterTrdReq = request
+	if request.IsMyTrdReq {
+		screen.RunTxProcess(W8_CANCEL_TRDREQ, RSLT_CANCEL_TRDREQ, func() (string, error) {
+			return loud.CancelTrade(screen.user, request.ID)
+		})
+	} else if !loud.CanFulfillBarterTrdReq(screen.user, request) {
+		screen.txFailReason = loud.Localize("you don't have all the wanted items and coins of this barter request")
+		screen.SetScreenStatusAndRefresh(RSLT_FULFILL_BARTER_TRDREQ)
+	} else {
+		itemIDs, _ := loud.MatchBarterItemIDs(screen.user, request)
+		screen.RunTxProcess(W8_FULFILL_BARTER_TRDREQ, RSLT_FULFILL_BARTER_TRDREQ, func() (string, error) {
+			return loud.FulfillTrade(screen.user, request.ID, itemIDs)
+		})
+	}
+}
+
+// barterDesc shows what the creator gives on the top and what the creator gets on the bottom
+func (screen *GameScreen) barterDesc(gives []string, gets []string) string {
+	return strings.Join([]string{
+		"\n",
+		strings.Join(gives, "\n"),
+		"\n  ↓\n",
+		strings.Join(gets, "\n"),
+	}, "")
+}
+
+var barterColumns = []tableColumn{{36, 0, false}, {36, 0, false}}
+
+func (screen *GameScreen) renderBarterLine(text1 string, text2 string, isActiveLine bool, isDisabledLine bool, width int) string {
+	calcText := tableRow(barterColumns, width, loud.Localize(text1), loud.Localize(text2))
+	onColor := screen.regularFont()
+	if isActiveLine && isDisabledLine {
+		onColor = screen.brownBoldFont()
+	} else if isActiveLine {
+		onColor = screen.blueBoldFont()
+	} else if isDisabledLine {
+		onColor = screen.brownFont()
+	}
+	return onColor(fillSpace(calcText, width))
+}
+
+func (screen *GameScreen) renderBarterTable(requests []loud.BarterTrdReq, width int) ([]string, []string) {
+	infoLines := []string{loud.Localize("Barter requests")}
+	if screen.activeLine >= len(requests) {
+		screen.activeLine = len(requests) - 1
+	}
+	activeLine := screen.activeLine
+	if activeLine >= 0 {
+		// full list of the selected request, since table cells are truncated
+		active := requests[activeLine]
+		infoLines = append(infoLines,
+			fmt.Sprintf("%s: %s", loud.Localize("offer"), strings.Join(screen.barterOfferLabels(active), " + ")),
+			fmt.Sprintf("%s: %s", loud.Localize("want"), strings.Join(screen.barterWantLabels(active), " + ")))
+	}
+
+	tableLines := []string{}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(barterColumns, width, "╭┬╮"), width)))
+	tableLines = append(tableLines, screen.renderBarterLine("Offer", "Want", false, false, width))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(barterColumns, width, "├┼┤"), width)))
+	numLines := screen.GetSituationBox().H - 5 - len(infoLines)
+	startLine := activeLine - numLines + 1
+	if startLine < 0 {
+		startLine = 0
+	}
+	endLine := startLine + numLines
+	if endLine > len(requests) {
+		endLine = len(requests)
+	}
+	screen.setTableRows(len(tableLines), startLine, endLine)
+	for li, request := range requests[startLine:endLine] {
+		tableLines = append(tableLines, screen.renderBarterLine(
+			strings.Join(screen.barterOfferLabels(request), " + "),
+			strings.Join(screen.barterWantLabels(request), " + "),
+			startLine+li == activeLine,
+			request.IsMyTrdReq,
+			width,
+		))
+	}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(barterColumns, width, "╰┴╯"), width)))
+	return infoLines, tableLines
+}
+
+func (screen *GameScreen) renderMultiSelectTable(header string, th string, labels []string, selected []bool, width int) ([]string, []string) {
+	if screen.activeLine >= len(labels) {
+		screen.activeLine = len(labels) - 1
+	}
+	infoLines := strings.Split(loud.Localize(header), "\n")
+	infoLines = append(infoLines, selectPositionDesc(screen.activeLine, len(labels)))
+	numLines := screen.GetSituationBox().H - 5 - len(infoLines)
+	fmtFunc := screen.regularFont()
+
+	tableLines := []string{}
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(itemColumns, width, "╭┬╮"), width)))
+	tableLines = append(tableLines, screen.renderItemTableLine(th, false, width))
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(itemColumns, width, "├┼┤"), width)))
+	activeLine := screen.activeLine
+	startLine := activeLine - numLines + 1
+	if startLine < 0 {
+		startLine = 0
+	}
+	endLine := startLine + numLines
+	if endLine > len(labels) {
+		endLine = len(labels)
+	}
+	screen.setTableRows(len(tableLines), startLine, endLine)
+	for li, label := range labels[startLine:endLine] {
+		mark := "☐ "
+		if selected[startLine+li] {
+			mark = "☑ "
+		}
+		tableLines = append(tableLines, screen.renderItemTableLine(mark+label+"  ", startLine+li == activeLine, width))
+	}
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(itemColumns, width, "╰┴╯"), width)))
+	return infoLines, tableLines
+}
+
+func (screen *GameScreen) renderBarterOfferSelect(width int) ([]string, []string) {
+	labels := []string{}
+	selected := []bool{}
+	for _, item := range loud.BarterOfferItems(screen.user) {
+		labels = append(labels, formatItem(item)+lockedMark(item.ID))
+		selected = append(selected, screen.barterOfferIDs[item.ID])
+	}
+	return screen.renderMultiSelectTable("Select items to offer, active weapon and armor are not listed", "Item", labels, selected, width)
+}
+
+func (screen *GameScreen) renderBarterWantSelect(width int) ([]string, []string) {
+	labels := []string{}
+	selected := []bool{}
+	for idx, itspec := range loud.WorldItemSpecs {
+		labels = append(labels, formatItemSpec(itspec))
+		selected = append(selected, screen.barterWantIdxs[idx])
+	}
+	return screen.renderMultiSelectTable("Select items to get", "Item", labels, selected, width)
+}
+
+func (screen *GameScreen) barterDraftDesc() string {
+	draft := screen.barterDraft()
+	return screen.barterDesc(screen.barterOfferLabels(draft), screen.barterWantLabels(draft))
+}
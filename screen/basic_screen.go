@@ -5,7 +5,6 @@ import (
 	"io"
 	"math"
 	"os"
-	"strings"
 
 	"github.com/ahmetb/go-cursor"
 	"github.com/gliderlabs/ssh"
@@ -33,6 +32,15 @@ func (screen *GameScreen) FakeSync() {
 	screen.Render()
 }
 
+func (screen *GameScreen) OnSyncFinished(user loud.User) {
+	screen.orderBook = loud.BuildOrderBook(loud.BuyTrdReqs, loud.SellTrdReqs)
+	screen.pushMyOrderFillToasts()
+	screen.pushWatchAlertToasts()
+	screen.pushAchievementToasts()
+	// synced data can change any box, only changed cells are drawn
+	screen.Render()
+}
+
 func (screen *GameScreen) GetTxFailReason() string {
 	return screen.txFailReason
 }
@@ -85,33 +93,33 @@ func (screen *GameScreen) drawProgressMeter(min, max, fgcolor, bgcolor, width ui
 }
 
 func (screen *GameScreen) drawFill(x, y, width, height int) {
-	color := ansi.ColorCode(fmt.Sprintf("0:%v", bgcolor))
+	color := screen.colorCode(FILL)
 
 	midString := fmt.Sprintf("%%s%%s%%%vs", width)
 	for i := 0; i <= height; i++ {
-		io.WriteString(os.Stdout, fmt.Sprintf(midString, cursor.MoveTo(y+i, x), color, " "))
+		io.WriteString(screen.frame, fmt.Sprintf(midString, cursor.MoveTo(y+i, x), color, " "))
 	}
 }
 
 func (screen *GameScreen) drawBox(x, y, width, height int) {
-	color := ansi.ColorCode(fmt.Sprintf("255:%v", bgcolor))
+	color := screen.colorCode(BORDER)
 
 	for i := 1; i < width; i++ {
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s─", cursor.MoveTo(y, x+i), color))
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s─", cursor.MoveTo(y+height, x+i), color))
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s─", cursor.MoveTo(y, x+i), color))
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s─", cursor.MoveTo(y+height, x+i), color))
 	}
 
 	for i := 1; i < height; i++ {
 		midString := fmt.Sprintf("%%s%%s│%%%vs│", (width - 1))
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s│", cursor.MoveTo(y+i, x), color))
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s│", cursor.MoveTo(y+i, x+width), color))
-		io.WriteString(os.Stdout, fmt.Sprintf(midString, cursor.MoveTo(y+i, x), color, " "))
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s│", cursor.MoveTo(y+i, x), color))
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s│", cursor.MoveTo(y+i, x+width), color))
+		io.WriteString(screen.frame, fmt.Sprintf(midString, cursor.MoveTo(y+i, x), color, " "))
 	}
 
-	io.WriteString(os.Stdout, fmt.Sprintf("%s%s╭", cursor.MoveTo(y, x), color))
-	io.WriteString(os.Stdout, fmt.Sprintf("%s%s╰", cursor.MoveTo(y+height, x), color))
-	io.WriteString(os.Stdout, fmt.Sprintf("%s%s╮", cursor.MoveTo(y, x+width), color))
-	io.WriteString(os.Stdout, fmt.Sprintf("%s%s╯", cursor.MoveTo(y+height, x+width), color))
+	io.WriteString(screen.frame, fmt.Sprintf("%s%s╭", cursor.MoveTo(y, x), color))
+	io.WriteString(screen.frame, fmt.Sprintf("%s%s╰", cursor.MoveTo(y+height, x), color))
+	io.WriteString(screen.frame, fmt.Sprintf("%s%s╮", cursor.MoveTo(y, x+width), color))
+	io.WriteString(screen.frame, fmt.Sprintf("%s%s╯", cursor.MoveTo(y+height, x+width), color))
 }
 
 func (screen *GameScreen) SetScreenSize(Width, Height int) {
@@ -141,17 +149,26 @@ func (screen *GameScreen) IsWaitScreen() bool {
 }
 
 func (screen *GameScreen) IsWaitScreenCmd(input termbox.Event) bool {
-	if input.Key == termbox.KeyEsc {
-		return true
-	}
-	Key := strings.ToUpper(string(input.Ch))
-	switch Key {
-	case "E", "M", "L": // Refresh, Cosmos address copy, TxHash copy
+	switch screen.keys.Action(input) {
+	case ACT_EXIT, ACT_REFRESH, ACT_COPY_ADDRESS, ACT_COPY_TXHASH, ACT_SWITCH_PANE:
 		return true
+	case ACT_STOP_REPEAT_HUNT: // Stop repeat hunt after current fight
+		return screen.scrStatus == W8_REPEAT_HUNT
 	}
 	return false
 }
 
+// KeyAction returns the action bound to the key on active keymap
+func (screen *GameScreen) KeyAction(input termbox.Event) string {
+	return screen.keys.Action(input)
+}
+
+// ActionEvent returns the key event bound to the action, eg. for automated input
+func (screen *GameScreen) ActionEvent(action string) termbox.Event {
+	ev, _ := screen.keys.Event(action)
+	return ev
+}
+
 func (screen *GameScreen) IsEndGameConfirmScreen() bool {
 	return screen.scrStatus == CONFIRM_ENDGAME
 }
@@ -166,6 +183,16 @@ func (screen *GameScreen) InputActive() bool {
 		CR8_BUYITM_TRDREQ_ENT_PYLVAL,
 		CR8_SELLCHR_TRDREQ_ENT_PYLVAL,
 		CR8_BUYCHR_TRDREQ_ENT_PYLVAL,
+		CR8_MKTORD_ENT_LUDVAL,
+		CR8_MKTORD_ENT_PRICE,
+		FILTER_TRDREQ_ENT_QUERY,
+		JUMP_ENT_NUMBER,
+		WATCH_ENT_RULE,
+		CR8_REPEAT_HUNT_ENT_RULE,
+		CR8_BARTER_ENT_OFFER_PYLVAL,
+		CR8_BARTER_ENT_WANT_PYLVAL,
+		CR8_BARTER_ENT_OFFER_GOLDVAL,
+		CR8_BARTER_ENT_WANT_GOLDVAL,
 		RENAME_CHAR_ENT_NEWNAME:
 		return true
 	}
@@ -186,11 +213,11 @@ func (screen *GameScreen) GetScreenStatus() ScreenStatus {
 	return screen.scrStatus
 }
 
-func (screen *GameScreen) SetScreenStatus(newStatus ScreenStatus) {
-	screen.scrStatus = newStatus
-}
-
+// Reset stops rendering and resets the terminal
 func (screen *GameScreen) Reset() {
+	screen.renderMu.Lock()
+	defer screen.renderMu.Unlock()
+	screen.closed = true
 	io.WriteString(os.Stdout, fmt.Sprintf("%s👋\n", resetScreen))
 }
 
@@ -0,0 +1,199 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+	"github.com/Pylons-tech/LOUD/log"
+)
+
+// recordBattle builds the battle report of a processed tx and saves it into battle history
+func (screen *GameScreen) recordBattle(txhash string, loadout loud.Loadout, txResult []byte) loud.BattleReport {
+	report := loud.BuildBattleReport(screen.user, txhash, screen.user.GetLastTxMetaData(), loadout, txResult)
+	screen.user.AddBattleReport(report)
+	screen.pushAchievementToasts()
+	return report
+}
+
+// RunBattleTxProcess is RunTxProcess for hunt and fight recipes which keeps the battle report of the result
+func (screen *GameScreen) RunBattleTxProcess(waitStatus ScreenStatus, resultStatus ScreenStatus, fn func() (string, error)) {
+	screen.SetScreenStatusAndRefresh(waitStatus)
+	loadout := loud.ActiveLoadout(screen.user)
+
+	log.Println("started sending request for ", waitStatus)
+	go func() {
+		txhash, err := fn()
+		log.Println("ended sending request for ", waitStatus)
+		if err != nil {
+			screen.txFailReason = err.Error()
+			screen.SetScreenStatusAndRefresh(resultStatus)
+		} else {
+			time.AfterFunc(1*time.Second, func() {
+				screen.txResult, screen.txFailReason = loud.ProcessTxResult(screen.user, txhash)
+				if len(screen.txFailReason) == 0 {
+					screen.battleReport = screen.recordBattle(txhash, loadout, screen.txResult)
+				}
+				screen.SetScreenStatusAndRefresh(resultStatus)
+			})
+		}
+	}()
+}
+
+// battleReportLog narrates a battle report line by line
+func battleReportLog(report loud.BattleReport) string {
+	lines := []string{}
+	if report.Gold > 0 {
+		lines = append(lines, loud.Sprintf("You earned 💰 %d.", report.Gold))
+	}
+	if report.Revived {
+		lines = append(lines, loud.Sprintf("%s saved your character from death.", loud.Localize(report.Consumable)))
+	} else if report.ConsumableUsed {
+		lines = append(lines, loud.Sprintf("You used %s.", loud.Localize(report.Consumable)))
+	}
+	if len(report.CharacterName) > 0 {
+		if report.CharacterLost {
+			lines = append(lines, loud.Sprintf("%s was lost in the battle.", report.CharacterName))
+		} else {
+			lines = append(lines, loud.Sprintf("%s's XP went from %.0f to %.0f.", report.CharacterName, report.XPBefore, report.XPAfter))
+			if report.LevelUps() > 0 {
+				lines = append(lines, loud.Sprintf("%s leveled up from %d to %d!", report.CharacterName, report.LevelBefore, report.LevelAfter))
+			}
+			if report.GotSpecial() {
+				lines = append(lines, loud.Sprintf("%s acquired %s.", report.CharacterName, formatSpecial(report.SpecialAfter)))
+			}
+		}
+	}
+	if len(report.WeaponName) > 0 {
+		weaponName := fmt.Sprintf("%s Lv%d", loud.Localize(report.WeaponName), report.WeaponLevel)
+		if report.WeaponLost {
+			lines = append(lines, loud.Sprintf("%s was destroyed.", weaponName))
+		} else {
+			lines = append(lines, loud.Sprintf("%s survived the battle.", weaponName))
+		}
+	}
+	if len(report.ArmorName) > 0 {
+		if report.ArmorLost {
+			lines = append(lines, loud.Sprintf("%s was destroyed.", loud.Localize(report.ArmorName)))
+		} else {
+			lines = append(lines, loud.Sprintf("%s protected your character.", loud.Localize(report.ArmorName)))
+		}
+	}
+	for _, drop := range report.Drops {
+		lines = append(lines, loud.Sprintf("You picked up %s.", loud.Localize(drop)))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, loud.Localize("Nothing happened."))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// battleActionLabels is the short name of each hunt and fight recipe for battle history
+var battleActionLabels = map[string]string{
+	loud.RCP_HUNT_RABBITS_NOSWORD: "Rabbits",
+	loud.RCP_HUNT_RABBITS_YESWORD: "Rabbits",
+	loud.RCP_FIGHT_GOBLIN:         "Goblin",
+	loud.RCP_FIGHT_WOLF:           "Wolf",
+	loud.RCP_FIGHT_TROLL:          "Troll",
+	loud.RCP_FIGHT_GIANT:          "Giant",
+	loud.RCP_FIGHT_DRAGONFIRE:     "Fire Dragon",
+	loud.RCP_FIGHT_DRAGONICE:      "Ice Dragon",
+	loud.RCP_FIGHT_DRAGONACID:     "Acid Dragon",
+	loud.RCP_FIGHT_DRAGONUNDEAD:   "Undead Dragon",
+}
+
+// battleReportTitle summarizes a battle report in a single line for battle history
+func battleReportTitle(report loud.BattleReport) string {
+	result := loud.Localize("win")
+	if report.CharacterLost {
+		result = loud.Localize("character dies")
+	} else if report.WeaponLost {
+		result = loud.Localize("sword lost")
+	}
+	action := loud.BaseHuntRecipe(report.Action)
+	if label, ok := battleActionLabels[action]; ok {
+		action = label
+	}
+	return fmt.Sprintf("%s %s, %s, 💰 %d", time.Unix(report.Time, 0).Format("01-02 15:04"), loud.Localize(action), result, report.Gold)
+}
+
+func (screen *GameScreen) battleReportFont(report loud.BattleReport) FontType {
+	switch {
+	case report.CharacterLost:
+		return RED
+	case report.WeaponLost:
+		return YELLOW
+	}
+	return GREEN
+}
+
+// battleHeadlines is the headline of each battle result screen when the character is lost and when it survived
+var battleHeadlines = map[ScreenStatus][2]string{
+	RSLT_HUNT_RABBITS:       {"Your character is dead while following rabbits accidently", "You did hunt rabbits and earned %d."},
+	RSLT_FIGHT_GOBLIN:       {"You were killed by goblin accidently", "You did fight with goblin and earned %d."},
+	RSLT_FIGHT_TROLL:        {"You were killed by troll accidently", "You did fight with troll and earned %d."},
+	RSLT_FIGHT_WOLF:         {"You were killed by wolf accidently", "You did fight with wolf and earned %d."},
+	RSLT_FIGHT_GIANT:        {"You were killed by giant accidently", "You did fight with giant and earned %d."},
+	RSLT_FIGHT_DRAGONFIRE:   {"You were killed by fire dragon accidently", "You did fight with fire dragon and earned %d."},
+	RSLT_FIGHT_DRAGONICE:    {"You were killed by ice dragon accidently", "You did fight with ice dragon and earned %d."},
+	RSLT_FIGHT_DRAGONACID:   {"You were killed by acid dragon accidently", "You did fight with acid dragon and earned %d."},
+	RSLT_FIGHT_DRAGONUNDEAD: {"You were killed by undead dragon accidently", "You did fight with undead dragon and earned %d."},
+}
+
+func (screen *GameScreen) battleResultDesc(stus ScreenStatus, report loud.BattleReport) (string, FontType) {
+	headlines := battleHeadlines[stus]
+	desc := loud.Sprintf(headlines[1], report.Gold)
+	if report.CharacterLost {
+		desc = loud.Localize(headlines[0])
+	}
+	return desc + "\n\n" + battleReportLog(report), screen.battleReportFont(report)
+}
+
+func (screen *GameScreen) showActiveBattleReport() bool {
+	reports := screen.user.GetBattleHistory()
+	if len(reports) <= screen.activeLine || screen.activeLine < 0 {
+		return false
+	}
+	screen.battleReport = reports[screen.activeLine]
+	screen.SetScreenStatusAndRefresh(SHW_BATTLE_REPORT)
+	return true
+}
+
+func (screen *GameScreen) renderBattleReportLine(text string, isActiveLine bool, width int) string {
+	calcText := tableRow(reportColumns, width, text)
+	onColor := screen.regularFont()
+	if isActiveLine {
+		onColor = screen.blueBoldFont()
+	}
+	return onColor(fillSpace(calcText, width))
+}
+
+func (screen *GameScreen) renderBattleHistory(width int) ([]string, []string) {
+	reports := screen.user.GetBattleHistory()
+	infoLines := []string{
+		loud.Sprintf("Battle history: %d battles", len(reports)),
+	}
+
+	tableLines := []string{}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(reportColumns, width, "╭┬╮"), width)))
+	if screen.activeLine >= len(reports) {
+		screen.activeLine = len(reports) - 1
+	}
+	activeLine := screen.activeLine
+	numLines := screen.GetSituationBox().H - 3 - len(infoLines)
+	startLine := activeLine - numLines + 1
+	if startLine < 0 {
+		startLine = 0
+	}
+	endLine := startLine + numLines
+	if endLine > len(reports) {
+		endLine = len(reports)
+	}
+	screen.setTableRows(len(tableLines), startLine, endLine)
+	for li, report := range reports[startLine:endLine] {
+		tableLines = append(tableLines, screen.renderBattleReportLine(battleReportTitle(report), startLine+li == activeLine, width))
+	}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(reportColumns, width, "╰┴╯"), width)))
+	return infoLines, tableLines
+}
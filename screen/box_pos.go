@@ -1,5 +1,31 @@
 package screen
 
+import (
+	"io"
+
+	"github.com/ahmetb/go-cursor"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+// the full layout needs FULL_LAYOUT_WIDTH x FULL_LAYOUT_HEIGHT, smaller terminals get the compact layout
+const (
+	FULL_LAYOUT_WIDTH  = 120
+	FULL_LAYOUT_HEIGHT = 38
+	MIN_SCREEN_WIDTH   = 60
+	MIN_SCREEN_HEIGHT  = 20
+)
+
+// panes of the compact layout, only one of them is shown at a time
+const (
+	PANE_SITUATION = iota
+	PANE_COMMANDS
+	PANE_CHARACTER_SHEET
+	NUM_PANES
+)
+
+var paneLabels = []string{"Situation", "Commands", "Character"}
+
 type ScreenBox struct {
 	X int
 	Y int
@@ -15,6 +41,16 @@ func (screen *GameScreen) Height() int {
 	return screen.screenSize.Height
 }
 
+// IsCompact returns true when the terminal is too small for the full layout
+func (screen *GameScreen) IsCompact() bool {
+	return screen.Width() < FULL_LAYOUT_WIDTH || screen.Height() < FULL_LAYOUT_HEIGHT
+}
+
+// IsTooSmall returns true when even the compact layout doesn't fit
+func (screen *GameScreen) IsTooSmall() bool {
+	return screen.Width() < MIN_SCREEN_WIDTH || screen.Height() < MIN_SCREEN_HEIGHT
+}
+
 func (screen *GameScreen) GetMenuBox() ScreenBox {
 	return ScreenBox{
 		X: 2,
@@ -24,7 +60,31 @@ func (screen *GameScreen) GetMenuBox() ScreenBox {
 	}
 }
 
+// GetPaneTabBox is the tab bar of the compact layout
+func (screen *GameScreen) GetPaneTabBox() ScreenBox {
+	return ScreenBox{
+		X: 2,
+		Y: 4,
+		W: screen.leftInnerWidth(),
+		H: 1,
+	}
+}
+
+// GetPaneBox is where the active pane is drawn on the compact layout
+func (screen *GameScreen) GetPaneBox() ScreenBox {
+	y := screen.GetPaneTabBox().Y + 1
+	return ScreenBox{
+		X: 2,
+		Y: y,
+		W: screen.leftInnerWidth(),
+		H: screen.situationInputBorderY() - y,
+	}
+}
+
 func (screen *GameScreen) GetSituationBox() ScreenBox {
+	if screen.IsCompact() {
+		return screen.GetPaneBox()
+	}
 	y := screen.situationCmdBorderY() + 1
 	return ScreenBox{
 		X: 2,
@@ -35,6 +95,9 @@ func (screen *GameScreen) GetSituationBox() ScreenBox {
 }
 
 func (screen *GameScreen) GetCmdBox() ScreenBox {
+	if screen.IsCompact() {
+		return screen.GetPaneBox()
+	}
 	return ScreenBox{
 		X: 2,
 		Y: 4,
@@ -44,6 +107,9 @@ func (screen *GameScreen) GetCmdBox() ScreenBox {
 }
 
 func (screen *GameScreen) GetCharacterSheetBox() ScreenBox {
+	if screen.IsCompact() {
+		return screen.GetPaneBox()
+	}
 	return ScreenBox{
 		X: screen.rightInnerStartX(),
 		Y: 4,
@@ -52,7 +118,11 @@ func (screen *GameScreen) GetCharacterSheetBox() ScreenBox {
 	}
 }
 
+// leftRightBorderX is the right edge of the screen on the compact layout, which has no right column
 func (screen *GameScreen) leftRightBorderX() int {
+	if screen.IsCompact() {
+		return screen.Width()
+	}
 	return screen.Width() - 40
 }
 
@@ -79,3 +149,74 @@ func (screen *GameScreen) situationCmdBorderY() int {
 func (screen *GameScreen) situationInputBorderY() int {
 	return screen.Height() - 2
 }
+
+// SwitchPane shows the next pane of the compact layout
+func (screen *GameScreen) SwitchPane() bool {
+	if !screen.IsCompact() {
+		return false
+	}
+	screen.SetPane((screen.pane + 1) % NUM_PANES)
+	return true
+}
+
+func (screen *GameScreen) SetPane(pane int) {
+	screen.pane = pane
+	screen.FreshRender()
+}
+
+// paneTabDisplays lays out the tab bar of the compact layout, used for rendering and mouse hit-testing
+func (screen *GameScreen) paneTabDisplays() []MenuDisplay {
+	scrBox := screen.GetPaneTabBox()
+	tabs := []MenuDisplay{}
+	x := scrBox.X
+	for pane, label := range paneLabels {
+		md := MenuDisplay{
+			text:     loud.Localize(label),
+			isActive: pane == screen.pane,
+			start:    x,
+			split:    true,
+		}
+		md.width = NumberOfSpaces(md.text) + 3
+		tabs = append(tabs, md)
+		x += md.width
+	}
+	return append(tabs, MenuDisplay{
+		text:  screen.keys.Mark(ACT_SWITCH_PANE),
+		start: x,
+		width: scrBox.X + scrBox.W - x,
+	})
+}
+
+func (screen *GameScreen) renderPaneTabs() {
+	y := screen.GetPaneTabBox().Y
+	for _, md := range screen.paneTabDisplays() {
+		font := screen.regularFont()
+		if md.isActive {
+			font = screen.menuActiveFont()
+		}
+		text := fillSpace(md.text, md.width)
+		if md.split {
+			text = centerText(md.text, " ", md.width-1)
+			io.WriteString(screen.frame, cursor.MoveTo(y, md.start+md.width-1)+screen.regularFont()("│"))
+		}
+		io.WriteString(screen.frame, cursor.MoveTo(y, md.start)+font(text))
+	}
+}
+
+// renderPane renders the active pane of the compact layout
+func (screen *GameScreen) renderPane() {
+	// lines of hidden panes can't be clicked
+	screen.cmdActions = nil
+	screen.sheetActions = nil
+	screen.situationRows = nil
+
+	screen.renderPaneTabs()
+	switch screen.pane {
+	case PANE_COMMANDS:
+		screen.renderUserCommands()
+	case PANE_CHARACTER_SHEET:
+		screen.renderCharacterSheet()
+	default:
+		screen.renderUserSituation()
+	}
+}
@@ -0,0 +1,86 @@
+package screen
+
+import (
+	"fmt"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+// consumableCounts returns the number of each consumable in inventory in loud.Consumables order
+func (screen *GameScreen) consumableCounts() []int {
+	counts := make([]int, len(loud.Consumables))
+	for _, item := range screen.user.InventoryConsumables() {
+		for idx, name := range loud.Consumables {
+			if item.Name == name {
+				counts[idx]++
+			}
+		}
+	}
+	return counts
+}
+
+// toggleConsumable switches consumable used on forest actions to the next one in inventory
+func (screen *GameScreen) toggleConsumable() bool {
+	if _, ok := repeatHuntFuncs[screen.scrStatus]; !ok {
+		return false
+	}
+	counts := screen.consumableCounts()
+	current := -1
+	for idx, name := range loud.Consumables {
+		if name == screen.user.GetActiveConsumable() {
+			current = idx
+		}
+	}
+	next := ""
+	for idx := current + 1; idx < len(loud.Consumables); idx++ {
+		if counts[idx] > 0 {
+			next = loud.Consumables[idx]
+			break
+		}
+	}
+	if len(next) == 0 && current < 0 {
+		screen.actionText = loud.Localize("You don't have any consumable, please buy one from the shop")
+	}
+	screen.user.SetActiveConsumable(next)
+	screen.SaveGame()
+	screen.Render()
+	return true
+}
+
+// consumableDesc describes the consumable which will be used on forest action
+func (screen *GameScreen) consumableDesc() string {
+	consumable := screen.user.GetActiveConsumable()
+	if len(consumable) == 0 {
+		return loud.Localize("No consumable is used")
+	}
+	if screen.user.GetActiveConsumableItem() == nil {
+		return loud.Sprintf("You don't have %s any more", loud.Localize(consumable))
+	}
+	return loud.Sprintf("Using %s", loud.Localize(consumable))
+}
+
+// loadoutSheetLines is the active weapon, armor and consumable used on hunts and fights
+func (screen *GameScreen) loadoutSheetLines() []string {
+	lines := []string{}
+	if weapon := screen.user.GetActiveWeapon(); weapon != nil {
+		lines = append(lines, fmt.Sprintf("🗡 %s", formatItemP(weapon)))
+	}
+	if armor := screen.user.GetActiveArmor(); armor != nil {
+		lines = append(lines, fmt.Sprintf("🛡 %s", formatItemP(armor)))
+	}
+	if screen.user.GetActiveConsumableItem() != nil {
+		lines = append(lines, fmt.Sprintf("🧪 %s", loud.Localize(screen.user.GetActiveConsumable())))
+	}
+	return lines
+}
+
+// consumableSheetLines is the consumables section of character sheet
+func (screen *GameScreen) consumableSheetLines() []string {
+	lines := []string{}
+	for idx, count := range screen.consumableCounts() {
+		if count > 0 {
+			lines = append(lines, fmt.Sprintf("%s x%d", loud.Localize(loud.Consumables[idx]), count))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,86 @@
+package screen
+
+import (
+	"fmt"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+func ownedMark(owned int) string {
+	if owned > 0 {
+		return fmt.Sprintf("✔ x%d", owned)
+	}
+	return "✘"
+}
+
+// craftSourceDesc describes where a material drops
+func craftSourceDesc(source *loud.CraftSource) string {
+	if source == nil {
+		return ""
+	}
+	desc := loud.Sprintf("from %s", loud.Localize(battleActionLabels[source.Action]))
+	if len(source.Weight) > 0 {
+		desc += ", " + loud.Sprintf("weight %s", source.Weight)
+	}
+	if source.HasChance {
+		desc += fmt.Sprintf(" (%.1f%%)", source.Chance*100)
+	}
+	return desc
+}
+
+// craftStepDesc describes the next step toward target sword
+func craftStepDesc(step loud.CraftStep) string {
+	switch step.Kind {
+	case loud.CRAFT_STEP_DONE:
+		return loud.Sprintf("You already have %s", loud.Localize(step.Name))
+	case loud.CRAFT_STEP_BUY:
+		return loud.Sprintf("Buy %s in the shop", loud.Localize(step.Name))
+	case loud.CRAFT_STEP_EARN:
+		return loud.Sprintf("Earn 💰 %d more to buy %s", step.Gold, loud.Localize(step.Name))
+	case loud.CRAFT_STEP_CHARACTER:
+		return loud.Sprintf("Get a character eligible to fight %s", loud.Localize(battleActionLabels[step.Action]))
+	}
+	return loud.Sprintf("Fight %s to get %s", loud.Localize(battleActionLabels[step.Action]), loud.Localize(step.Name))
+}
+
+// craftTreeLines renders a sword and its materials as a tree
+func craftTreeLines(node loud.CraftNode) []string {
+	lines := []string{
+		fmt.Sprintf("%s (💰 %d) %s", loud.Localize(node.Name), node.Price, ownedMark(node.Owned)),
+	}
+	for idx, child := range node.Children {
+		branch := "├─"
+		if idx == len(node.Children)-1 {
+			branch = "└─"
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s %s, %s", branch, loud.Localize(child.Name), ownedMark(child.Owned), craftSourceDesc(child.Source)))
+	}
+	return lines
+}
+
+func (screen *GameScreen) renderCrafting(width int) ([]string, []string) {
+	swords := loud.CraftableSwords()
+	if screen.activeLine >= len(swords) {
+		screen.activeLine = len(swords) - 1
+	}
+	if screen.activeLine < 0 {
+		screen.activeLine = 0
+	}
+	target := swords[screen.activeLine]
+	infoLines := []string{
+		loud.Sprintf("Crafting tree, target: %s", loud.Localize(target.Name)),
+		loud.Sprintf("Next step: %s", craftStepDesc(loud.NextCraftStep(screen.user, target))),
+	}
+
+	tableLines := []string{}
+	for idx, sword := range swords {
+		font := screen.regularFont()
+		if idx == screen.activeLine {
+			font = screen.blueBoldFont()
+		}
+		for _, line := range craftTreeLines(loud.BuildCraftTree(screen.user, sword)) {
+			tableLines = append(tableLines, font(fillSpace(line, width)))
+		}
+	}
+	return infoLines, tableLines
+}
@@ -1,18 +1,19 @@
 package screen
 
 import (
-	"fmt"
 	"io"
-	"os"
-
-	"github.com/mgutz/ansi"
 )
 
 func (screen *GameScreen) redrawBorders() {
-	io.WriteString(os.Stdout, ansi.ColorCode(fmt.Sprintf("255:%v", bgcolor)))
+	color := screen.colorCode(BORDER)
+	io.WriteString(screen.frame, color)
 	screen.drawBox(1, 1, screen.Width()-1, screen.Height()-1)
-	drawHorizontalLine(1, 3, screen.Width())
-	drawVerticalLine(screen.leftRightBorderX(), 3, screen.Height())
-	drawHorizontalLine(1, screen.situationCmdBorderY(), screen.leftInnerWidth()+1)
-	drawHorizontalLine(1, screen.situationInputBorderY(), screen.leftInnerWidth()+1)
+	screen.drawHorizontalLine(color, 1, 3, screen.Width())
+	if screen.IsCompact() {
+		screen.drawHorizontalLine(color, 1, screen.situationInputBorderY(), screen.Width())
+		return
+	}
+	screen.drawVerticalLine(color, screen.leftRightBorderX(), 3, screen.Height())
+	screen.drawHorizontalLine(color, 1, screen.situationCmdBorderY(), screen.leftInnerWidth()+1)
+	screen.drawHorizontalLine(color, 1, screen.situationInputBorderY(), screen.leftInnerWidth()+1)
 }
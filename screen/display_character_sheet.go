@@ -3,7 +3,6 @@ package screen
 import (
 	"fmt"
 	"io"
-	"os"
 
 	loud "github.com/Pylons-tech/LOUD/data"
 	"github.com/ahmetb/go-cursor"
@@ -31,11 +30,11 @@ func (screen *GameScreen) renderCharacterSheet() {
 		activeCharacterRestBlocks = screen.BlockSince(activeCharacter.LastUpdate)
 	}
 	activeWeapon := screen.user.GetActiveWeapon()
+	activeArmor := screen.user.GetActiveArmor()
 
-	charBkColor := uint64(bgcolor)
 	warning := ""
 
-	charFunc := screen.colorFunc(fmt.Sprintf("255:%v", charBkColor))
+	charFunc := screen.regularFont()
 	fmtFunc := screen.regularFont()
 
 	infoLines := []string{
@@ -47,33 +46,58 @@ func (screen *GameScreen) renderCharacterSheet() {
 
 	MAX_INVENTORY_LEN := h - 15
 
-	for idx, character := range characters {
-		if len(infoLines) > MAX_INVENTORY_LEN {
-			infoLines = append(infoLines, "...")
-			break
-		}
-		characterInfo := fillSpace(formatCharacter(character), w)
-		if idx == screen.user.GetActiveCharacterIndex() {
+	inventoryLines := []string{}
+	for _, character := range characters {
+		characterInfo := fillSpace(formatCharacter(character)+lockedMark(character.ID), w)
+		if activeCharacter != nil && character.ID == activeCharacter.ID {
 			characterInfo = screen.blueBoldFont()(characterInfo)
 		} else {
 			characterInfo = fmtFunc(characterInfo)
 		}
-		infoLines = append(infoLines, characterInfo)
+		inventoryLines = append(inventoryLines, characterInfo)
 	}
 
 	items := screen.user.InventoryItems()
 	for _, item := range items {
-		if len(infoLines) > MAX_INVENTORY_LEN {
-			infoLines = append(infoLines, "...")
-			break
+		if item.IsConsumable() {
+			continue
 		}
-		itemInfo := fillSpace(formatItem(item), w)
-		if activeWeapon != nil && item.ID == activeWeapon.ID {
+		itemInfo := fillSpace(formatItem(item)+lockedMark(item.ID), w)
+		if (activeWeapon != nil && item.ID == activeWeapon.ID) || (activeArmor != nil && item.ID == activeArmor.ID) {
 			itemInfo = screen.blueBoldFont()(itemInfo)
 		} else {
 			itemInfo = fmtFunc(itemInfo)
 		}
-		infoLines = append(infoLines, itemInfo)
+		inventoryLines = append(inventoryLines, itemInfo)
+	}
+
+	// inventory scrolls with < > keys when it doesn't fit, the last line shows the visible range
+	scrollLineIdx := -1
+	numInventoryLines := MAX_INVENTORY_LEN - len(infoLines) + 1
+	if numInventoryLines < 2 { // keeps scrolling on the compact layout
+		numInventoryLines = 2
+	}
+	if len(inventoryLines) > numInventoryLines && numInventoryLines > 1 {
+		numInventoryLines--
+		maxScroll := len(inventoryLines) - numInventoryLines
+		if screen.sheetScroll > maxScroll {
+			screen.sheetScroll = maxScroll
+		}
+		start := screen.sheetScroll
+		end := start + numInventoryLines
+		infoLines = append(infoLines, inventoryLines[start:end]...)
+		scrollLineIdx = len(infoLines)
+		infoLines = append(infoLines, fmtFunc(fillSpace(fmt.Sprintf("%s %s", loud.Sprintf("%d-%d of %d", start+1, end, len(inventoryLines)), screen.keys.Mark(ACT_SHEET_SCROLL_UP, ACT_SHEET_SCROLL_DOWN)), w)))
+	} else {
+		screen.sheetScroll = 0
+		infoLines = append(infoLines, inventoryLines...)
+	}
+
+	if consumableLines := screen.consumableSheetLines(); len(consumableLines) > 0 {
+		infoLines = append(infoLines, fmtFunc(centerText(fmt.Sprintf(" %s ", loud.Localize("consumables")), "─", w)))
+		for _, line := range consumableLines {
+			infoLines = append(infoLines, fmtFunc(fillSpace(line, w)))
+		}
 	}
 
 	// HP := uint64(100)
@@ -91,47 +115,69 @@ func (screen *GameScreen) renderCharacterSheet() {
 			charFunc(fillSpace(formatCharacterP(activeCharacter), w)),
 			charFunc(fillSpace(fmt.Sprintf("%s: %d", loud.Localize("rest blocks"), activeCharacterRestBlocks), w)),
 		)
+		if p, err := loud.CharacterProgression(activeCharacter, activeWeapon); err == nil && p.HasNextLevel {
+			infoLines = append(infoLines,
+				charFunc(fillSpace(fmt.Sprintf("%s: %.0f/%.0f", loud.Localize("next level XP"), p.XP, p.NextLevelXP), w)),
+			)
+		}
 	}
-	if activeWeapon != nil {
-		infoLines = append(infoLines,
-			fmtFunc(centerText(fmt.Sprintf(" %s ", loud.Localize("Active Weapon")), "─", w)),
-			fmtFunc(fillSpace(formatItemP(activeWeapon), w)),
-		)
+	if loadoutLines := screen.loadoutSheetLines(); len(loadoutLines) > 0 {
+		infoLines = append(infoLines, fmtFunc(centerText(fmt.Sprintf(" %s ", loud.Localize("Loadout")), "─", w)))
+		for _, line := range loadoutLines {
+			infoLines = append(infoLines, fmtFunc(fillSpace(line, w)))
+		}
 	}
 
 	lenInfoLines := len(infoLines)
 
 	for index, line := range infoLines {
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s",
+		if index >= h {
+			break
+		}
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s",
 			cursor.MoveTo(y+index, x),
 			line))
 	}
 
 	nodeLines := []string{
 		fmtFunc(centerText(" "+loud.Localize("pylons network status")+" ", "─", w)),
-		fmtFunc(fillSpace(fmt.Sprintf("%s: %s 📋 (M)", loud.Localize("Address"), truncateRight(screen.user.GetAddress(), 15)), w)),
+		fmtFunc(fillSpace(fmt.Sprintf("%s: %s 📋 %s", loud.Localize("Address"), truncateRight(screen.user.GetAddress(), 15), screen.keys.Mark(ACT_COPY_ADDRESS)), w)),
 		fmtFunc(fillSpace(fmt.Sprintf("%s %s: %v", screen.pylonIcon(), "Pylon", screen.user.GetPylonAmount()), w)),
 	}
+	nodeActions := []string{"", ACT_COPY_ADDRESS, ""}
 
 	if len(screen.user.GetLastTxHash()) > 0 {
-		txHashT := fmt.Sprintf("%s: %s 📋 (L)", loud.Localize("Last TxHash"), truncateRight(screen.user.GetLastTxHash(), 15))
+		txHashT := fmt.Sprintf("%s: %s 📋 %s", loud.Localize("Last TxHash"), truncateRight(screen.user.GetLastTxHash(), 15), screen.keys.Mark(ACT_COPY_TXHASH))
 		nodeLines = append(nodeLines, fmtFunc(fillSpace(txHashT, w)))
+		nodeActions = append(nodeActions, ACT_COPY_TXHASH)
 	}
 
-	blockHeightText := fillSpace(fmt.Sprintf("%s ⟳ (E): %d(%d)", loud.Localize("block height"), screen.blockHeight, screen.fakeBlockHeight), w)
+	blockHeightText := fillSpace(fmt.Sprintf("%s ⟳ %s: %d(%d)", loud.Localize("block height"), screen.keys.Mark(ACT_REFRESH), screen.blockHeight, screen.fakeBlockHeight), w)
 	if screen.syncingData {
 		nodeLines = append(nodeLines, screen.blueBoldFont()(blockHeightText))
 	} else {
 		nodeLines = append(nodeLines, fmtFunc(blockHeightText))
 	}
 	nodeLines = append(nodeLines, fmtFunc(centerText(" ❦ ", "─", w)))
+	nodeActions = append(nodeActions, ACT_REFRESH, "")
 
 	for index, line := range nodeLines {
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s",
+		if lenInfoLines+index >= h {
+			break
+		}
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s",
 			cursor.MoveTo(y+lenInfoLines+index, x),
 			line))
 	}
 
-	lastLine := lenInfoLines + len(nodeLines) + 1
-	screen.drawFill(x, lastLine+1, w, h-lastLine)
+	screen.sheetActions = make([]string, lenInfoLines)
+	if scrollLineIdx >= 0 {
+		screen.sheetActions[scrollLineIdx] = ACT_SHEET_SCROLL_DOWN
+	}
+	screen.sheetActions = append(screen.sheetActions, nodeActions...)
+	if len(screen.sheetActions) > h {
+		screen.sheetActions = screen.sheetActions[:h]
+	}
+	totalLen := len(screen.sheetActions)
+	screen.drawFill(x, y+totalLen, w, h-totalLen-1)
 }
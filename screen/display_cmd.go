@@ -3,61 +3,116 @@ package screen
 import (
 	"fmt"
 	"io"
-	"os"
+	"regexp"
 	"strings"
 
 	loud "github.com/Pylons-tech/LOUD/data"
 	"github.com/ahmetb/go-cursor"
 )
 
+var numberedCmdRe = regexp.MustCompile(`^([0-9])\) ?(.*)$`)
+
+// command hint labels, keys are added from active keymap
+const (
+	SEL_CMD         = "Select"
+	GO_ON_CMD       = "Go on"
+	GO_BACK_CMD     = "Go back"
+	EXIT_GAME_CMD   = "Exit Game"
+	PAGE_CMD        = "Page"
+	JUMP_NUMBER_CMD = "Jump to number"
+)
+
+// typing mode keys are not on keymap
+const (
+	FINISH_ENTER_CMD = "Finish Enter ( ↵ )"
+	GO_BACK_ESC_CMD  = "Go back ( Esc )"
+)
+
+// line actions of typing mode keys, clicks on their lines send the raw keys
 const (
-	SEL_CMD           = "Select ( ↵ )"
-	GO_ON_ENTER_CMD   = "Go on ( ↵ )"
-	FINISH_ENTER_CMD  = "Finish Enter ( ↵ )"
-	GO_BACK_CMD       = "Go back ( ⌫ ) - Backspace Key"
-	GO_BACK_ESC_CMD   = "Go back ( Esc )"
-	EXIT_GAME_ESC_CMD = "Exit Game ( Esc )"
+	TYPING_ENTER_ACT = "typing_enter"
+	TYPING_ESC_ACT   = "typing_esc"
 )
 
-func (tl TextLines) appendDeselectCmd() TextLines {
-	return tl.append(fmt.Sprintf("0) %s", loud.Localize("Deselect")))
+func (tl TextLines) appendDeselectCmd(km Keymap) TextLines {
+	return tl.append(km.NumberedLine(0, loud.Localize("Deselect")))
 }
 
-func (tl TextLines) appendSelectGoBackCmds() TextLines {
-	return tl.appendT(
-		SEL_CMD,
-		GO_BACK_CMD)
+func (tl TextLines) appendSelectGoBackCmds(km Keymap) TextLines {
+	return tl.append(
+		km.HintLine(SEL_CMD, ACT_SELECT),
+		km.HintLine(GO_BACK_CMD, ACT_BACK))
 }
 
-func (tl TextLines) appendGoOnBackCmds() TextLines {
-	return tl.appendT(
-		GO_ON_ENTER_CMD,
-		GO_BACK_CMD)
+func (tl TextLines) appendGoOnBackCmds(km Keymap) TextLines {
+	return tl.append(
+		km.HintLine(GO_ON_CMD, ACT_SELECT),
+		km.HintLine(GO_BACK_CMD, ACT_BACK))
 }
 
-func (tl TextLines) appendSelectCmds(itemsSlice interface{}, fn func(interface{}) string) TextLines {
+// appendSelectCmds lists entries of the page which contains active line
+func (tl TextLines) appendSelectCmds(km Keymap, itemsSlice interface{}, activeLine int, fn func(interface{}) string) TextLines {
 	items := InterfaceSlice(itemsSlice)
-	for idx, item := range items {
-		if idx >= 5 {
-			break
-		}
-		tl = tl.append(fmt.Sprintf("%d) %s  ", idx+1, fn(item)))
+	start, end := selectPageRange(activeLine, len(items))
+	for idx, item := range items[start:end] {
+		tl = tl.append(km.NumberedLine(idx+1, fn(item)+"  "))
 	}
 	return tl
 }
 
-func (tl TextLines) appendCustomFontSelectCmds(itemsSlice interface{}, fn func(interface{}) TextLine) TextLines {
+func (tl TextLines) appendCustomFontSelectCmds(km Keymap, itemsSlice interface{}, activeLine int, fn func(interface{}) TextLine) TextLines {
 	items := InterfaceSlice(itemsSlice)
-	for idx, item := range items {
+	start, end := selectPageRange(activeLine, len(items))
+	for idx, item := range items[start:end] {
 		fni := fn(item)
-		tl = append(tl, TextLine{
-			content: fmt.Sprintf("%d) %s  ", idx+1, fni.content),
-			font:    fni.font,
-		})
+		line := km.NumberedLine(idx+1, fni.content+"  ")
+		line.font = fni.font
+		tl = append(tl, line)
 	}
 	return tl
 }
 
+// appendPageCmds shows paging keys when entries don't fit a page
+func (tl TextLines) appendPageCmds(km Keymap, total int) TextLines {
+	if total <= SELECT_PAGE_SIZE {
+		return tl
+	}
+	line := km.HintLine(PAGE_CMD, ACT_PREV_PAGE, ACT_NEXT_PAGE)
+	line.content += ", " + km.Hint(JUMP_NUMBER_CMD, ACT_JUMP_TO_NUMBER)
+	return tl.append(line)
+}
+
+// locationCmds is the command list of a location, numbered with keys of number actions
+func (screen *GameScreen) locationCmds(location loud.UserLocation) TextLines {
+	km := screen.keys
+	if location == loud.DEVELOP {
+		return TextLines{}.append(
+			km.HintLine("Create cookbook", ACT_CREATE_COOKBOOK),
+			km.HintLine("Switch user", ACT_SWITCH_USER),
+			km.HintLine("Get initial pylons", ACT_GET_PYLONS),
+			km.HintLine("DevMode Test Items", ACT_DEV_ITEMS))
+	}
+	cmdMap := map[loud.UserLocation]string{
+		loud.HOME:     "home",
+		loud.FOREST:   "forest",
+		loud.SHOP:     "shop",
+		loud.PYLCNTRL: "pylons central",
+		loud.SETTINGS: "settings",
+	}
+	cmds := TextLines{}
+	for _, line := range strings.Split(loud.Localize(cmdMap[location]), "\n") {
+		if m := numberedCmdRe.FindStringSubmatch(line); m != nil {
+			cmds = cmds.append(km.NumberedLine(int(m[1][0]-'0'), m[2]))
+		} else {
+			cmds = cmds.append(TextLine{content: line})
+		}
+	}
+	if location == loud.SETTINGS {
+		cmds = cmds.append(screen.themeCmds()...)
+	}
+	return cmds
+}
+
 func (screen *GameScreen) renderUserCommands() {
 	// cmd box start point (x, y)
 	scrBox := screen.GetCmdBox()
@@ -66,26 +121,17 @@ func (screen *GameScreen) renderUserCommands() {
 	w := scrBox.W
 	h := scrBox.H
 
+	km := screen.keys
 	infoLines := TextLines{}
 	tableLines := []string{}
 	switch screen.scrStatus {
 	case CONFIRM_ENDGAME:
 		infoLines = infoLines.
-			appendT(
-				GO_BACK_ESC_CMD,
-				GO_ON_ENTER_CMD)
+			append(
+				km.HintLine(GO_BACK_CMD, ACT_EXIT),
+				km.HintLine(GO_ON_CMD, ACT_SELECT))
 	case SHW_LOCATION:
-		cmdMap := map[loud.UserLocation]string{
-			loud.HOME:     "home",
-			loud.FOREST:   "forest",
-			loud.SHOP:     "shop",
-			loud.PYLCNTRL: "pylons central",
-			loud.SETTINGS: "settings",
-			loud.DEVELOP:  "develop",
-		}
-		cmdString := loud.Localize(cmdMap[screen.user.GetLocation()])
-		infoLines = infoLines.
-			append(strings.Split(cmdString, "\n")...)
+		infoLines = screen.locationCmds(screen.user.GetLocation())
 
 		if screen.user.GetLocation() == loud.FOREST {
 			forestStusMap := map[int]ScreenStatus{
@@ -109,85 +155,200 @@ func (screen *GameScreen) renderUserCommands() {
 		}
 	case SHW_LOUD_BUY_TRDREQS:
 		infoLines = infoLines.
-			appendT(
-				"Sell gold to fulfill selected request( ↵ )",
-				"Place order to buy gold(R)",
-				GO_BACK_CMD)
+			append(
+				km.HintLine("Sell gold to fulfill selected request", ACT_SELECT),
+				km.HintLine("Place order to buy gold", ACT_CREATE),
+				km.HintLine("Sell gold at market", ACT_MARKET_ORDER),
+				km.HintLine("Order book mode", ACT_SWITCH_MODE),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
 		tableLines = screen.tradeTableColorDesc(w)
 	case SHW_LOUD_SELL_TRDREQS:
 		infoLines = infoLines.
-			appendT(
-				"Buy gold to fulfill selected request( ↵ )",
-				"place order to sell gold(R)",
-				GO_BACK_CMD)
+			append(
+				km.HintLine("Buy gold to fulfill selected request", ACT_SELECT),
+				km.HintLine("place order to sell gold", ACT_CREATE),
+				km.HintLine("Buy gold at market", ACT_MARKET_ORDER),
+				km.HintLine("Order book mode", ACT_SWITCH_MODE),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
 		tableLines = screen.tradeTableColorDesc(w)
+	case SHW_LOUD_ORDERBOOK:
+		infoLines = infoLines.
+			append(
+				km.HintLine("Request list mode", ACT_SWITCH_MODE),
+				km.HintLine("Refresh order book", ACT_REFRESH),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
+		tableLines = screen.orderBookColorDesc(w)
+	case SHW_PRICE_HISTORY:
+		periodCmd := km.HintLine("Block range summary", ACT_SWITCH_MODE)
+		if screen.priceHistoryByBlock {
+			periodCmd = km.HintLine("Day summary", ACT_SWITCH_MODE)
+		}
+		infoLines = infoLines.
+			append(
+				periodCmd,
+				km.HintLine("Refresh price history", ACT_REFRESH),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
 	case SHW_BUYITM_TRDREQS:
 		infoLines = infoLines.
-			appendT(
-				"Sell item to fulfill selected request( ↵ )",
-				"Place order to buy item(R)",
-				GO_BACK_CMD)
+			append(
+				km.HintLine("Sell item to fulfill selected request", ACT_SELECT),
+				km.HintLine("Place order to buy item", ACT_CREATE),
+				km.HintLine("Filter requests", ACT_FILTER),
+				km.HintLine("Sort by price, level, XP", ACT_SORT),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
 		tableLines = screen.tradeTableColorDesc(w)
 	case SHW_SELLITM_TRDREQS:
 		infoLines = infoLines.
-			appendT(
-				"Buy item to fulfill selected request( ↵ )",
-				"Place order to sell item(R)",
-				GO_BACK_CMD)
+			append(
+				km.HintLine("Buy item to fulfill selected request", ACT_SELECT),
+				km.HintLine("Place order to sell item", ACT_CREATE),
+				km.HintLine("Filter requests", ACT_FILTER),
+				km.HintLine("Sort by price, level, XP", ACT_SORT),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
 		tableLines = screen.tradeTableColorDesc(w)
 	case SHW_BUYCHR_TRDREQS:
 		infoLines = infoLines.
-			appendT(
-				"Sell character to fulfill selected request( ↵ )",
-				"Place order to buy character(R)",
-				GO_BACK_CMD)
+			append(
+				km.HintLine("Sell character to fulfill selected request", ACT_SELECT),
+				km.HintLine("Place order to buy character", ACT_CREATE),
+				km.HintLine("Filter requests", ACT_FILTER),
+				km.HintLine("Sort by price, level, XP", ACT_SORT),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
 		tableLines = screen.tradeTableColorDesc(w)
 	case SHW_SELLCHR_TRDREQS:
 		infoLines = infoLines.
-			appendT(
-				"Buy character to fulfill selected request( ↵ )",
-				"Place order to sell character(R)",
-				GO_BACK_CMD)
+			append(
+				km.HintLine("Buy character to fulfill selected request", ACT_SELECT),
+				km.HintLine("Place order to sell character", ACT_CREATE),
+				km.HintLine("Filter requests", ACT_FILTER),
+				km.HintLine("Sort by price, level, XP", ACT_SORT),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
 		tableLines = screen.tradeTableColorDesc(w)
+	case SHW_WATCHLIST:
+		infoLines = infoLines.
+			append(
+				km.HintLine("Go to first match of selected rule", ACT_SELECT),
+				km.HintLine("Add watch rule", ACT_CREATE),
+				km.HintLine("Remove selected rule", ACT_SORT),
+				km.HintLine("Turn bell on or off", ACT_SWITCH_MODE),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
+	case SHW_BATTLE_HISTORY:
+		infoLines = infoLines.
+			append(
+				km.HintLine("Show selected battle report", ACT_SELECT),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
+	case SHW_LEADERBOARD:
+		infoLines = infoLines.
+			append(
+				km.HintLine("Refresh leaderboard", ACT_CREATE),
+				km.HintLine("Switch ranking", ACT_SORT),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
+	case SHW_CRAFTING:
+		infoLines = infoLines.
+			append(
+				km.HintLine("Select target sword", ACT_UP, ACT_DOWN),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
+	case SHW_BATTLE_REPORT, SHW_PROGRESSION, SHW_ACHIEVEMENTS:
+		infoLines = infoLines.
+			append(km.HintLine(GO_BACK_CMD, ACT_BACK))
+	case SHW_MY_ORDERS:
+		infoLines = infoLines.
+			append(
+				km.HintLine("Select or unselect order", ACT_SELECT),
+				km.HintLine("Select all orders", ACT_MARKET_ORDER),
+				km.HintLine("Cancel selected orders", ACT_GO_ON),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
+	case SHW_BARTER_TRDREQS:
+		infoLines = infoLines.
+			append(
+				km.HintLine("Barter to fulfill selected request", ACT_SELECT),
+				km.HintLine("Place barter request", ACT_CREATE),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
+		tableLines = screen.tradeTableColorDesc(w)
+	case CR8_BARTER_SEL_OFFER_ITEMS,
+		CR8_BARTER_SEL_WANT_ITEMS:
+		entries, _ := screen.selectEntries(screen.scrStatus)
+		infoLines = infoLines.
+			appendPageCmds(km, len(entries)).
+			append(
+				km.HintLine("Select or unselect item", ACT_SELECT),
+				km.HintLine("Finish selection", ACT_GO_ON),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
 
 	case CR8_BUYCHR_TRDREQ_SEL_CHR,
+		FULFILL_BUYITM_TRDREQ_SEL_ITEM,
+		FULFILL_BUYCHR_TRDREQ_SEL_CHR,
 		CR8_SELLCHR_TRDREQ_SEL_CHR,
 		CR8_SELLITM_TRDREQ_SEL_ITEM,
 		CR8_BUYITM_TRDREQ_SEL_ITEM:
+		entries, _ := screen.selectEntries(screen.scrStatus)
 		infoLines = infoLines.
-			appendT(
-				SEL_CMD,
-				GO_BACK_CMD)
+			appendPageCmds(km, len(entries)).
+			append(
+				km.HintLine(SEL_CMD, ACT_SELECT),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
 	case SEL_RENAME_CHAR:
+		entries, _ := screen.selectEntries(screen.scrStatus)
 		infoLines = infoLines.
 			appendSelectCmds(
-				screen.user.InventoryCharacters(),
+				km,
+				entries,
+				screen.activeLine,
 				func(it interface{}) string {
-					return formatCharacter(it.(loud.Character))
+					char := it.(loud.Character)
+					return formatCharacter(char) + lockedMark(char.ID)
 				}).
-			appendSelectGoBackCmds()
+			appendPageCmds(km, len(entries)).
+			appendSelectGoBackCmds(km)
 	case SEL_ACTIVE_CHAR:
+		entries, _ := screen.selectEntries(screen.scrStatus)
 		infoLines = infoLines.
-			appendDeselectCmd().
+			appendDeselectCmd(km).
 			appendSelectCmds(
-				screen.user.InventoryCharacters(),
+				km,
+				entries,
+				screen.activeLine,
 				func(it interface{}) string {
-					return formatCharacter(it.(loud.Character))
+					char := it.(loud.Character)
+					return formatCharacter(char) + lockedMark(char.ID)
 				}).
-			appendSelectGoBackCmds()
+			appendPageCmds(km, len(entries)).
+			appendSelectGoBackCmds(km)
 	case SEL_ACTIVE_WEAPON:
+		entries, _ := screen.selectEntries(screen.scrStatus)
 		infoLines = infoLines.
-			appendDeselectCmd().
+			appendDeselectCmd(km).
 			appendSelectCmds(
-				screen.user.InventorySwords(),
+				km,
+				entries,
+				screen.activeLine,
 				func(it interface{}) string {
-					return formatItem(it.(loud.Item))
+					item := it.(loud.Item)
+					return formatItem(item) + lockedMark(item.ID)
+				}).
+			appendPageCmds(km, len(entries)).
+			appendSelectGoBackCmds(km)
+	case SEL_ACTIVE_ARMOR:
+		entries, _ := screen.selectEntries(screen.scrStatus)
+		infoLines = infoLines.
+			appendDeselectCmd(km).
+			appendSelectCmds(
+				km,
+				entries,
+				screen.activeLine,
+				func(it interface{}) string {
+					item := it.(loud.Item)
+					return formatItem(item) + lockedMark(item.ID)
 				}).
-			appendSelectGoBackCmds()
+			appendPageCmds(km, len(entries)).
+			appendSelectGoBackCmds(km)
 	case SEL_BUYITM:
+		entries, _ := screen.selectEntries(screen.scrStatus)
 		infoLines = infoLines.
 			appendCustomFontSelectCmds(
-				loud.ShopItems,
+				km,
+				entries,
+				screen.activeLine,
 				func(it interface{}) TextLine {
 					item := it.(loud.Item)
 					preitemOk := screen.user.HasPreItemForAnItem(item)
@@ -213,34 +374,47 @@ func (screen *GameScreen) renderUserCommands() {
 						font:    font,
 					}
 				}).
-			appendSelectGoBackCmds()
+			appendPageCmds(km, len(entries)).
+			appendSelectGoBackCmds(km)
 	case SEL_BUYCHR:
+		entries, _ := screen.selectEntries(screen.scrStatus)
 		infoLines = infoLines.
 			appendSelectCmds(
-				loud.ShopCharacters,
+				km,
+				entries,
+				screen.activeLine,
 				func(it interface{}) string {
 					char := it.(loud.Character)
 					return fmt.Sprintf("%s  %s %d", formatCharacter(char), screen.pylonIcon(), char.Price)
 				}).
-			appendSelectGoBackCmds()
+			appendPageCmds(km, len(entries)).
+			appendSelectGoBackCmds(km)
 	case SEL_SELLITM:
+		entries, _ := screen.selectEntries(screen.scrStatus)
 		infoLines = infoLines.
 			appendSelectCmds(
-				screen.user.InventorySellableItems(),
+				km,
+				entries,
+				screen.activeLine,
 				func(it interface{}) string {
 					item := it.(loud.Item)
 					return formatItem(item) + fmt.Sprintf("💰 %s", item.GetSellPriceRange())
 				}).
-			appendSelectGoBackCmds()
+			appendPageCmds(km, len(entries)).
+			appendSelectGoBackCmds(km)
 	case SEL_UPGITM:
+		entries, _ := screen.selectEntries(screen.scrStatus)
 		infoLines = infoLines.
 			appendSelectCmds(
-				screen.user.InventoryUpgradableItems(),
+				km,
+				entries,
+				screen.activeLine,
 				func(it interface{}) string {
 					item := it.(loud.Item)
 					return formatItem(item) + fmt.Sprintf("💰 %d", item.GetUpgradePrice())
 				}).
-			appendSelectGoBackCmds()
+			appendPageCmds(km, len(entries)).
+			appendSelectGoBackCmds(km)
 	case CONFIRM_HUNT_RABBITS,
 		CONFIRM_FIGHT_GOBLIN,
 		CONFIRM_FIGHT_TROLL,
@@ -251,21 +425,42 @@ func (screen *GameScreen) renderUserCommands() {
 		CONFIRM_FIGHT_DRAGONACID,
 		CONFIRM_FIGHT_DRAGONUNDEAD:
 		infoLines = infoLines.
-			appendGoOnBackCmds()
+			append(
+				km.HintLine(GO_ON_CMD, ACT_SELECT),
+				km.HintLine("Repeat until stop condition", ACT_REPEAT_HUNT),
+				km.HintLine("Use consumable", ACT_USE_CONSUMABLE),
+				km.HintLine(GO_BACK_CMD, ACT_BACK))
+	case W8_REPEAT_HUNT:
+		infoLines = infoLines.
+			append(km.HintLine("Stop after current fight", ACT_STOP_REPEAT_HUNT))
+	case CONFIRM_MKTORD,
+		CONFIRM_UNLOCK_ITEM:
+		infoLines = infoLines.
+			appendGoOnBackCmds(km)
 	default:
 		if screen.IsResultScreen() { // eg. RSLT_BUY_LOUD_TRDREQ_CREATION
-			infoLines = infoLines.appendT(GO_ON_ENTER_CMD)
+			infoLines = infoLines.append(km.HintLine(GO_ON_CMD, ACT_SELECT))
 		} else if screen.InputActive() { // eg. CR8_BUYITM_TRDREQ_ENT_PYLVAL
 			infoLines = infoLines.
-				appendT(
-					FINISH_ENTER_CMD,
-					GO_BACK_ESC_CMD)
+				append(
+					TextLine{content: loud.Localize(FINISH_ENTER_CMD), action: TYPING_ENTER_ACT},
+					TextLine{content: loud.Localize(GO_BACK_ESC_CMD), action: TYPING_ESC_ACT})
+		}
+	}
+
+	if screen.IsCompact() && len(infoLines)+len(tableLines) > h {
+		// the pane has no box below to draw over the rest
+		if len(infoLines) > h {
+			infoLines = infoLines[:h]
 		}
+		tableLines = tableLines[:h-len(infoLines)]
 	}
 
+	screen.cmdActions = []string{}
 	for index, line := range infoLines {
+		screen.cmdActions = append(screen.cmdActions, line.action)
 		lineFont := screen.getFont(line.font)
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s",
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s",
 			cursor.MoveTo(y+index, x),
 			lineFont(fillSpace(line.content, w))))
 	}
@@ -273,7 +468,7 @@ func (screen *GameScreen) renderUserCommands() {
 	infoLen := len(infoLines)
 
 	for index, line := range tableLines {
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s",
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s",
 			cursor.MoveTo(y+infoLen+index, x),
 			line))
 	}
@@ -3,9 +3,10 @@ package screen
 import (
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/ahmetb/go-cursor"
+
+	loud "github.com/Pylons-tech/LOUD/data"
 )
 
 func (screen *GameScreen) renderInputValue() {
@@ -26,7 +27,14 @@ func (screen *GameScreen) renderInputValue() {
 
 	if !screen.InputActive() {
 		inputText = fmt.Sprintf("%s%s", move, chatFunc(fillSpace(screen.actionText, int(inputBoxWidth))))
+		if len(screen.actionText) == 0 && len(screen.toasts) > 0 {
+			toast := "🔔 " + screen.toasts[0]
+			if len(screen.toasts) > 1 {
+				toast += loud.Sprintf(" (+%d more)", len(screen.toasts)-1)
+			}
+			inputText = fmt.Sprintf("%s%s", move, screen.yellowFont()(fillSpace(toast, int(inputBoxWidth))))
+		}
 	}
 
-	io.WriteString(os.Stdout, inputText)
+	io.WriteString(screen.frame, inputText)
 }
@@ -3,9 +3,9 @@ package screen
 import (
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/ahmetb/go-cursor"
+	"github.com/nsf/termbox-go"
 
 	loud "github.com/Pylons-tech/LOUD/data"
 )
@@ -16,22 +16,30 @@ type MenuDisplay struct {
 	start    int
 	width    int
 	split    bool
+	key      termbox.Event // key event of clicking the menu
 }
 
-func (screen *GameScreen) renderMenu() {
+// menuText is a compact hint of menu items, eg. "Home(H)"
+func menuText(label, keyLabel string) string {
+	if keyLabel == "" {
+		return label
+	}
+	return fmt.Sprintf("%s(%s)", label, keyLabel)
+}
+
+// menuDisplays lays out menu bar items, used for rendering and mouse hit-testing
+func (screen *GameScreen) menuDisplays() []MenuDisplay {
 	scrBox := screen.GetMenuBox()
 	x := scrBox.X
-	y := scrBox.Y
 	w := scrBox.W
-	// h := scrBox.H
 
-	cmdMap := map[loud.UserLocation]string{
-		loud.HOME:     "home",
-		loud.FOREST:   "forest",
-		loud.SHOP:     "shop",
-		loud.PYLCNTRL: "pylons central",
-		loud.SETTINGS: "settings",
-		loud.DEVELOP:  "develop",
+	actionMap := map[loud.UserLocation]string{
+		loud.HOME:     ACT_GO_HOME,
+		loud.FOREST:   ACT_GO_FOREST,
+		loud.SHOP:     ACT_GO_SHOP,
+		loud.PYLCNTRL: ACT_GO_PYLONS_CENTRAL,
+		loud.SETTINGS: ACT_GO_SETTINGS,
+		loud.DEVELOP:  ACT_GO_DEVELOP,
 	}
 
 	locations := []loud.UserLocation{
@@ -46,28 +54,48 @@ func (screen *GameScreen) renderMenu() {
 	menuDisplays := []MenuDisplay{}
 	// mw := w / (len(locations) + 1)
 	mx := x
+	compact := screen.IsCompact()
 	for _, loc := range locations {
+		key, _ := screen.keys.Event(actionMap[loc])
 		md := MenuDisplay{
-			text:     loud.Localize("go to " + cmdMap[loc]),
+			text:     menuText(loud.Localize(locationLabels[loc]), screen.keys.Label(actionMap[loc])),
 			isActive: loc == screen.user.GetLocation(),
 			start:    x + mx,
 			split:    true,
+			key:      key,
 		}
 		md.width = len(md.text) + 5
+		if compact { // only the active location is named on the compact layout
+			if !md.isActive {
+				md.text = screen.keys.Label(actionMap[loc])
+			}
+			md.width = NumberOfSpaces(md.text) + 3
+		}
 		md.start = mx
 		menuDisplays = append(menuDisplays, md)
 
 		mx += md.width
 	}
 
+	key, _ := screen.keys.Event(ACT_EXIT)
 	md := MenuDisplay{
-		text:     loud.Localize(EXIT_GAME_ESC_CMD),
+		text:     screen.keys.Hint(EXIT_GAME_CMD, ACT_EXIT),
 		isActive: screen.scrStatus == CONFIRM_ENDGAME,
+		key:      key,
 	}
 	md.width = len(md.text) + 4
+	if compact {
+		md.text = screen.keys.Label(ACT_EXIT)
+		md.width = NumberOfSpaces(md.text) + 2
+	}
 	md.start = w - md.width
 
-	menuDisplays = append(menuDisplays, md)
+	return append(menuDisplays, md)
+}
+
+func (screen *GameScreen) renderMenu() {
+	y := screen.GetMenuBox().Y
+	menuDisplays := screen.menuDisplays()
 
 	for _, md := range menuDisplays {
 		menuFont := screen.menuRegularFont()
@@ -80,11 +108,11 @@ func (screen *GameScreen) renderMenu() {
 		if md.split {
 			text = centerText(md.text, " ", md.width-1)
 			splitText := fmt.Sprintf("%s%s", cursor.MoveTo(y, md.start+md.width-1), screen.regularFont()("│"))
-			io.WriteString(os.Stdout, splitText)
+			io.WriteString(screen.frame, splitText)
 		} else {
 			text = centerText(md.text, " ", md.width)
 		}
 		menuText := fmt.Sprintf("%s%s", move, menuFont(text))
-		io.WriteString(os.Stdout, menuText)
+		io.WriteString(screen.frame, menuText)
 	}
 }
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 	"strings"
 
 	loud "github.com/Pylons-tech/LOUD/data"
@@ -12,10 +11,6 @@ import (
 	"github.com/ahmetb/go-cursor"
 )
 
-func basicHuntResultDesc(format string, earnedAmount int64, res []string) string {
-	return loud.Sprintf(format, earnedAmount) + devDetailedResultDesc(res)
-}
-
 func devDetailedResultDesc(res []string) string {
 	resT := []string{}
 	for _, it := range res {
@@ -37,6 +32,7 @@ func (screen *GameScreen) GetTxResponseOutput() (int64, []handlers.ExecuteRecipe
 func (screen *GameScreen) renderUserSituation() {
 
 	// situation box start point (x, y)
+	screen.situationRows = nil
 	scrBox := screen.GetSituationBox()
 	x := scrBox.X
 	y := scrBox.Y
@@ -73,30 +69,71 @@ func (screen *GameScreen) renderUserSituation() {
 		infoLines, tableLines = screen.renderTRTable(loud.BuyTrdReqs, w)
 	case SHW_LOUD_SELL_TRDREQS:
 		infoLines, tableLines = screen.renderTRTable(loud.SellTrdReqs, w)
+	case SHW_LOUD_ORDERBOOK:
+		infoLines, tableLines = screen.renderOrderBook(screen.orderBook, w)
+	case SHW_PRICE_HISTORY:
+		infoLines, tableLines = screen.renderPriceHistory(w)
 	case SHW_BUYITM_TRDREQS:
 		infoLines, tableLines = screen.renderITRTable(
 			"Buy item requests",
-			[2]string{"Item", "Price (pylon)"},
-			loud.ItemBuyTrdReqs,
+			[3]string{"Item", "Price (pylon)", "Last sold for"},
+			screen.filteredItemBuyTrdReqs(),
 			w)
 	case SHW_SELLITM_TRDREQS:
 		infoLines, tableLines = screen.renderITRTable(
 			"Sell item requests",
-			[2]string{"Item", "Price (pylon)"},
-			loud.ItemSellTrdReqs,
+			[3]string{"Item", "Price (pylon)", "Last sold for"},
+			screen.filteredItemSellTrdReqs(),
 			w)
 	case SHW_SELLCHR_TRDREQS:
 		infoLines, tableLines = screen.renderITRTable(
 			"Sell character requests",
-			[2]string{"Character", "Price (pylon)"},
-			loud.CharacterSellTrdReqs,
+			[3]string{"Character", "Price (pylon)", "Last sold for"},
+			screen.filteredCharacterSellTrdReqs(),
 			w)
 	case SHW_BUYCHR_TRDREQS:
 		infoLines, tableLines = screen.renderITRTable(
 			"Buy character requests",
-			[2]string{"Character", "Price (pylon)"},
-			loud.CharacterBuyTrdReqs,
+			[3]string{"Character", "Price (pylon)", "Last sold for"},
+			screen.filteredCharacterBuyTrdReqs(),
 			w)
+	case SHW_MY_ORDERS:
+		infoLines, tableLines = screen.renderMyOrdersTable(w)
+	case SHW_WATCHLIST:
+		infoLines, tableLines = screen.renderWatchlist(w)
+	case WATCH_ENT_RULE:
+		desc = loud.Localize("watch rule desc")
+	case SHW_BATTLE_HISTORY:
+		infoLines, tableLines = screen.renderBattleHistory(w)
+	case SHW_PROGRESSION:
+		desc = screen.progressionDesc()
+	case SHW_ACHIEVEMENTS:
+		desc = screen.achievementsDesc()
+	case SHW_LEADERBOARD:
+		infoLines, tableLines = screen.renderLeaderboard(w)
+	case SHW_CRAFTING:
+		infoLines, tableLines = screen.renderCrafting(w)
+	case SHW_BATTLE_REPORT:
+		desc = battleReportTitle(screen.battleReport) + "\n\n" + battleReportLog(screen.battleReport)
+		descfont = screen.battleReportFont(screen.battleReport)
+	case SHW_BARTER_TRDREQS:
+		infoLines, tableLines = screen.renderBarterTable(loud.BarterTrdReqs, w)
+	case CR8_BARTER_SEL_OFFER_ITEMS:
+		infoLines, tableLines = screen.renderBarterOfferSelect(w)
+	case CR8_BARTER_SEL_WANT_ITEMS:
+		infoLines, tableLines = screen.renderBarterWantSelect(w)
+	case CR8_BARTER_ENT_OFFER_PYLVAL:
+		desc = loud.Localize("Please enter pylon amount to offer (leave empty for none)")
+		desc += screen.barterDraftDesc()
+	case CR8_BARTER_ENT_WANT_PYLVAL:
+		desc = loud.Localize("Please enter pylon amount to get (leave empty for none)")
+		desc += screen.barterDraftDesc()
+	case CR8_BARTER_ENT_OFFER_GOLDVAL:
+		desc = loud.Localize("Please enter gold amount to offer (leave empty for none)")
+		desc += screen.barterDraftDesc()
+	case CR8_BARTER_ENT_WANT_GOLDVAL:
+		desc = loud.Localize("Please enter gold amount to get (leave empty for none)")
+		desc += screen.barterDraftDesc()
 	case CR8_BUY_LOUD_TRDREQ_ENT_PYLVAL:
 		desc = loud.Localize("Please enter pylon amount to use (should be integer value)")
 	case CR8_SELL_LOUD_TRDREQ_ENT_PYLVAL:
@@ -107,6 +144,29 @@ func (screen *GameScreen) renderUserSituation() {
 		desc = loud.Localize("Please enter new character's name - it's costing pylons per letter.")
 	case CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL:
 		desc = loud.Localize("Please enter gold amount to sell (should be integer value)")
+	case FILTER_TRDREQ_ENT_QUERY:
+		desc = loud.Localize("trade request filter desc")
+	case JUMP_ENT_NUMBER:
+		entries, _ := screen.selectEntries(screen.jumpReturn)
+		desc = loud.Sprintf("Please enter entry number to jump to (1-%d)", len(entries))
+	case CR8_MKTORD_ENT_LUDVAL:
+		if screen.marketOrder.IsBuy {
+			desc = loud.Localize("Please enter gold amount to buy at market (should be integer value)")
+		} else {
+			desc = loud.Localize("Please enter gold amount to sell at market (should be integer value)")
+		}
+	case CR8_MKTORD_ENT_PRICE:
+		if screen.marketOrder.IsBuy {
+			desc = loud.Localize("Please enter max average price in pylon per gold (eg. 0.5)")
+		} else {
+			desc = loud.Localize("Please enter min average price in pylon per gold (eg. 0.5)")
+		}
+	case CR8_REPEAT_HUNT_ENT_RULE:
+		desc = loud.Localize("repeat hunt rule desc")
+	case CONFIRM_UNLOCK_ITEM:
+		desc = loud.Sprintf("%s is listed in your open trade request. Cancel the trade request to unlock it?", screen.unlockItemLabel)
+	case CONFIRM_MKTORD:
+		infoLines, tableLines = screen.renderMarketOrderPlan(screen.marketOrder, screen.loudEnterValue, w)
 
 	case CR8_SELLITM_TRDREQ_SEL_ITEM:
 		infoLines, tableLines = screen.renderITTable(
@@ -136,6 +196,18 @@ func (screen *GameScreen) renderUserSituation() {
 			"Character",
 			loud.WorldCharacterSpecs,
 			w)
+	case FULFILL_BUYITM_TRDREQ_SEL_ITEM:
+		infoLines, tableLines = screen.renderITTable(
+			"Select item to give for the request",
+			"Item",
+			loud.ItemBuyTrdReqCandidates(screen.user, screen.activeItemTrdReq.(loud.ItemBuyTrdReq)),
+			w)
+	case FULFILL_BUYCHR_TRDREQ_SEL_CHR:
+		infoLines, tableLines = screen.renderITTable(
+			"Select character to give for the request",
+			"Character",
+			loud.CharacterBuyTrdReqCandidates(screen.user, screen.activeItemTrdReq.(loud.CharacterBuyTrdReq)),
+			w)
 	case CR8_BUYITM_TRDREQ_ENT_PYLVAL:
 		desc = loud.Localize("Please enter pylon amount to use (should be integer value)")
 	case CR8_BUYCHR_TRDREQ_ENT_PYLVAL:
@@ -158,6 +230,12 @@ func (screen *GameScreen) renderUserSituation() {
 			"Item",
 			screen.user.InventorySwords(),
 			w)
+	case SEL_ACTIVE_ARMOR:
+		infoLines, tableLines = screen.renderITTable(
+			"Please select active armor",
+			"Item",
+			screen.user.InventoryArmors(),
+			w)
 	case SEL_BUYITM:
 		infoLines, tableLines = screen.renderITTable(
 			"select buy item desc",
@@ -211,6 +289,18 @@ func (screen *GameScreen) renderUserSituation() {
 		desc = loud.Localize("undead dragon outcome")
 		desc += carryItemDesc(activeWeapon)
 	}
+	if estimateDesc := screen.fightEstimateDesc(screen.scrStatus); len(estimateDesc) > 0 {
+		desc = strings.TrimRight(desc, "\n")
+		if loud.IsArmorRecipe(screen.forestRecipeName(screen.scrStatus)) {
+			desc += "\n" + loud.Sprintf("Wearing: %s", formatItemP(screen.user.GetActiveArmor()))
+		}
+		desc += "\n\n" + screen.consumableDesc() + "\n" + estimateDesc
+	}
+
+	if screen.InputActive() && len(screen.actionText) > 0 {
+		// action text is not shown on input box while typing, eg. invalid value entered
+		desc += "\n\n" + screen.actionText
+	}
 
 	if screen.IsResultScreen() {
 		desc, descfont = screen.TxResultSituationDesc()
@@ -235,9 +325,17 @@ func (screen *GameScreen) renderUserSituation() {
 		}
 	}
 
+	if screen.IsCompact() && len(infoLines)+len(tableLines) > h {
+		// the pane has no box below to draw over the rest
+		if len(infoLines) > h {
+			infoLines = infoLines[:h]
+		}
+		tableLines = tableLines[:h-len(infoLines)]
+	}
+
 	fmtFunc := screen.regularFont()
 	for index, line := range infoLines {
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s",
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s",
 			cursor.MoveTo(y+index, x),
 			fmtFunc(fillSpace(line, w))))
 		if index+2 > int(screen.Height()) {
@@ -246,8 +344,12 @@ func (screen *GameScreen) renderUserSituation() {
 	}
 	infoLen := len(infoLines)
 
+	if screen.situationRows != nil {
+		screen.situationRows.y = y + infoLen + screen.situationRows.offset
+		tableLines = screen.hoverTableLines(tableLines, w)
+	}
 	for index, line := range tableLines {
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s",
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s",
 			cursor.MoveTo(y+infoLen+index, x),
 			line))
 		if index+2 > int(screen.Height()) {
@@ -268,6 +370,7 @@ func (screen *GameScreen) TxResultSituationDesc() (string, FontType) {
 		RSLT_SEL_ACT_CHAR:              "selecting active character",
 		RSLT_RENAME_CHAR:               "renaming character",
 		RSLT_SEL_ACT_WEAPON:            "selecting active weapon",
+		RSLT_SEL_ACT_ARMOR:             "selecting active armor",
 		RSLT_BUYITM:                    "buy item",
 		RSLT_BUYCHR:                    "buy character",
 		RSLT_HUNT_RABBITS:              "hunt rabbits",
@@ -288,6 +391,11 @@ func (screen *GameScreen) TxResultSituationDesc() (string, FontType) {
 		RSLT_FULFILL_SELLCHR_TRDREQ:    "buy character",
 		RSLT_FULFILL_BUYITM_TRDREQ:     "sell item",
 		RSLT_FULFILL_BUYCHR_TRDREQ:     "sell character",
+		RSLT_MKTORD:                    "market order",
+		RSLT_BARTER_TRDREQ_CREATION:    "barter request creation",
+		RSLT_FULFILL_BARTER_TRDREQ:     "barter",
+		RSLT_CANCEL_MYORDS:             "cancel orders",
+		RSLT_REPEAT_HUNT:               "repeat hunt",
 	}
 	if screen.txFailReason != "" {
 		desc = loud.Localize(resDescMap[screen.scrStatus]+" failure reason") + ": " + loud.Localize(screen.txFailReason)
@@ -312,6 +420,12 @@ func (screen *GameScreen) TxResultSituationDesc() (string, FontType) {
 			} else {
 				desc = loud.Localize("You have successfully set the active weapon!")
 			}
+		case RSLT_SEL_ACT_ARMOR:
+			if screen.user.GetActiveArmor() == nil {
+				desc = loud.Localize("You have successfully unset the active armor!")
+			} else {
+				desc = loud.Localize("You have successfully set the active armor!")
+			}
 		case RSLT_RENAME_CHAR:
 			desc = loud.Sprintf("You have successfully updated character's name to %s!", screen.inputText)
 		case RSLT_BUYITM:
@@ -322,163 +436,16 @@ func (screen *GameScreen) TxResultSituationDesc() (string, FontType) {
 			desc = loud.Sprintf("You have bought %s from Pylons Central", formatCharacter(screen.activeCharacter))
 			desc += "\n"
 			desc += loud.Localize("Please use it for hunting")
-		case RSLT_HUNT_RABBITS:
-			earnedAmount, respOutput := screen.GetTxResponseOutput()
-			resLen := len(respOutput)
-			resultTexts := []string{"gold", "character", "weapon"}
-			if resLen == 0 {
-				desc = loud.Sprintf("Your character is dead while following rabbits accidently")
-				font = RED
-			} else {
-				desc = basicHuntResultDesc("You did hunt rabbits and earned %d.", earnedAmount, resultTexts[:resLen])
-				if resLen == 2 && screen.user.GetLastTxMetaData() == loud.RCP_HUNT_RABBITS_YESWORD {
-					desc += loud.Sprintf("You have lost your weapon accidently")
-					font = YELLOW
-				}
-			}
-		case RSLT_FIGHT_GOBLIN:
-			earnedAmount, respOutput := screen.GetTxResponseOutput()
-			resLen := len(respOutput)
-			resultTexts := []string{"gold", "character", "weapon", loud.GOBLIN_EAR}
-			if resLen == 0 {
-				desc = loud.Sprintf("You were killed by goblin accidently")
-				font = RED
-			} else {
-				desc = basicHuntResultDesc("You did fight with goblin and earned %d.", earnedAmount, resultTexts[:resLen])
-				switch resLen {
-				case 2:
-					desc += loud.Sprintf("You have lost your weapon accidently")
-					font = YELLOW
-				case 4:
-					desc += loud.Sprintf("You got bonus item called %s", loud.GOBLIN_EAR)
-					font = GREEN
-				}
-			}
-		case RSLT_FIGHT_TROLL:
-			earnedAmount, respOutput := screen.GetTxResponseOutput()
-			resLen := len(respOutput)
-			resultTexts := []string{"gold", "character", "weapon", loud.TROLL_TOES}
-			if resLen == 0 {
-				desc = loud.Sprintf("You were killed by troll accidently")
-				font = RED
-			} else {
-				desc = basicHuntResultDesc("You did fight with troll and earned %d.", earnedAmount, resultTexts[:resLen])
-				switch resLen {
-				case 2:
-					desc += loud.Sprintf("You have lost your weapon accidently")
-					font = YELLOW
-				case 4:
-					desc += loud.Sprintf("You got bonus item called %s", loud.TROLL_TOES)
-					font = GREEN
-				}
-			}
-		case RSLT_FIGHT_WOLF:
-			earnedAmount, respOutput := screen.GetTxResponseOutput()
-			resLen := len(respOutput)
-			resultTexts := []string{"gold", "character", "weapon", loud.WOLF_TAIL}
-			if resLen == 0 {
-				desc = loud.Sprintf("You were killed by wolf accidently")
-				font = RED
-			} else {
-				desc = basicHuntResultDesc("You did fight with wolf and earned %d.", earnedAmount, resultTexts[:resLen])
-				switch resLen {
-				case 2:
-					desc += loud.Sprintf("You have lost your weapon accidently")
-					font = YELLOW
-				case 4:
-					desc += loud.Sprintf("You got bonus item called %s", loud.WOLF_TAIL)
-					font = GREEN
-				}
-			}
-		case RSLT_FIGHT_GIANT:
-			earnedAmount, respOutput := screen.GetTxResponseOutput()
-			resLen := len(respOutput)
-			if resLen == 0 {
-				desc = loud.Sprintf("You were killed by giant accidently")
-				font = RED
-			} else {
-				desc = loud.Sprintf("You did fight with giant and earned %d.", earnedAmount)
-				switch resLen {
-				case 2:
-					desc += loud.Sprintf("You have lost your weapon accidently")
-					font = YELLOW
-				case 3:
-					activeCharacter := screen.user.GetActiveCharacter()
-					if activeCharacter.Special != loud.NO_SPECIAL { // Got special from this fight
-						desc += loud.Sprintf("You got %s (special) from the giant!!", formatSpecial(activeCharacter.Special))
-						font = GREEN
-					}
-				}
-			}
-		case RSLT_FIGHT_DRAGONFIRE:
-			earnedAmount, respOutput := screen.GetTxResponseOutput()
-			resLen := len(respOutput)
-			resultTexts := []string{"gold", "character", "weapon", loud.DROP_DRAGONFIRE}
-			if resLen == 0 {
-				desc = loud.Sprintf("You were killed by fire dragon accidently")
-				font = RED
-			} else {
-				desc = basicHuntResultDesc("You did fight with fire dragon and earned %d.", earnedAmount, resultTexts[:resLen])
-				switch resLen {
-				case 2:
-					desc += loud.Sprintf("You have lost your weapon accidently")
-					font = YELLOW
-				case 4:
-					desc += loud.Sprintf("You got bonus item called %s", loud.DROP_DRAGONFIRE)
-					font = GREEN
-				}
-			}
-		case RSLT_FIGHT_DRAGONICE:
-			earnedAmount, respOutput := screen.GetTxResponseOutput()
-			resLen := len(respOutput)
-			resultTexts := []string{"gold", "character", "weapon", loud.DROP_DRAGONICE}
-			if resLen == 0 {
-				desc = loud.Sprintf("You were killed by ice dragon accidently")
-				font = RED
-			} else {
-				desc = basicHuntResultDesc("You did fight with ice dragon and earned %d.", earnedAmount, resultTexts[:resLen])
-				switch resLen {
-				case 2:
-					desc += loud.Sprintf("You have lost your weapon accidently")
-					font = YELLOW
-				case 4:
-					desc += loud.Sprintf("You got bonus item called %s", loud.DROP_DRAGONICE)
-					font = GREEN
-				}
-			}
-		case RSLT_FIGHT_DRAGONACID:
-			earnedAmount, respOutput := screen.GetTxResponseOutput()
-			resLen := len(respOutput)
-			resultTexts := []string{"gold", "character", "weapon", loud.DROP_DRAGONACID}
-			if resLen == 0 {
-				desc = loud.Sprintf("You were killed by acid dragon accidently")
-				font = RED
-			} else {
-				desc = basicHuntResultDesc("You did fight with acid dragon and earned %d.", earnedAmount, resultTexts[:resLen])
-				switch resLen {
-				case 2:
-					desc += loud.Sprintf("You have lost your weapon accidently")
-					font = YELLOW
-				case 4:
-					desc += loud.Sprintf("You got bonus item called %s", loud.DROP_DRAGONACID)
-					font = GREEN
-				}
-			}
-		case RSLT_FIGHT_DRAGONUNDEAD:
-			earnedAmount, respOutput := screen.GetTxResponseOutput()
-			resLen := len(respOutput)
-			resultTexts := []string{"gold", "character", "weapon"}
-			if resLen == 0 {
-				desc = loud.Sprintf("You were killed by undead dragon accidently")
-				font = RED
-			} else {
-				desc = basicHuntResultDesc("You did fight with undead dragon and earned %d.", earnedAmount, resultTexts[:resLen])
-				switch resLen {
-				case 2:
-					desc += loud.Sprintf("You have lost your weapon accidently")
-					font = YELLOW
-				}
-			}
+		case RSLT_HUNT_RABBITS,
+			RSLT_FIGHT_GOBLIN,
+			RSLT_FIGHT_TROLL,
+			RSLT_FIGHT_WOLF,
+			RSLT_FIGHT_GIANT,
+			RSLT_FIGHT_DRAGONFIRE,
+			RSLT_FIGHT_DRAGONICE,
+			RSLT_FIGHT_DRAGONACID,
+			RSLT_FIGHT_DRAGONUNDEAD:
+			desc, font = screen.battleResultDesc(screen.scrStatus, screen.battleReport)
 		case RSLT_BUY_GOLD_WITH_PYLONS:
 			earnedAmount, _ := screen.GetTxResponseOutput()
 			desc = loud.Sprintf("Bought gold with pylons. Amount is %d.", earnedAmount)
@@ -541,11 +508,25 @@ func (screen *GameScreen) TxResultSituationDesc() (string, FontType) {
 		case RSLT_FULFILL_BUYITM_TRDREQ:
 			request := screen.activeItemTrdReq.(loud.ItemBuyTrdReq)
 			desc = loud.Localize("you have sold item successfully from item/pylon market")
-			desc += screen.sellItemSpecDesc(request.TItem, fmt.Sprintf("%d", request.Price))
+			desc += screen.sellItemDesc(screen.activeItem, fmt.Sprintf("%d", request.Price))
 		case RSLT_FULFILL_BUYCHR_TRDREQ:
 			request := screen.activeItemTrdReq.(loud.CharacterBuyTrdReq)
 			desc = loud.Localize("you have sold character successfully from character/pylon market")
-			desc += screen.sellCharacterSpecDesc(request.TCharacter, fmt.Sprintf("%d", request.Price))
+			desc += screen.sellCharacterDesc(screen.activeCharacter, fmt.Sprintf("%d", request.Price))
+		case RSLT_MKTORD:
+			desc, font = screen.marketOrderResultDesc()
+		case RSLT_CANCEL_MYORDS:
+			desc, font = screen.cancelMyOrdersResultDesc()
+		case RSLT_REPEAT_HUNT:
+			desc, font = screen.repeatHuntResultDesc()
+		case RSLT_BARTER_TRDREQ_CREATION:
+			request := screen.activeBarterTrdReq
+			desc = loud.Localize("barter request was successfully created")
+			desc += screen.barterDesc(screen.barterOfferLabels(request), screen.barterWantLabels(request))
+		case RSLT_FULFILL_BARTER_TRDREQ:
+			request := screen.activeBarterTrdReq
+			desc = loud.Localize("you have bartered successfully")
+			desc += screen.barterDesc(screen.barterWantLabels(request), screen.barterOfferLabels(request))
 		}
 	}
 	return desc, font
@@ -637,11 +618,11 @@ func (screen *GameScreen) TxWaitSituationDesc(width int) ([]string, []string) {
 	case W8_FULFILL_BUYITM_TRDREQ:
 		request := screen.activeItemTrdReq.(loud.ItemBuyTrdReq)
 		desc = loud.Sprintf("you are now selling item at %d.", request.Price)
-		desc += screen.sellItemSpecDesc(request.TItem, fmt.Sprintf("%d", request.Price))
+		desc += screen.sellItemDesc(screen.activeItem, fmt.Sprintf("%d", request.Price))
 	case W8_FULFILL_BUYCHR_TRDREQ:
 		request := screen.activeItemTrdReq.(loud.CharacterBuyTrdReq)
 		desc = loud.Sprintf("you are now selling character at %d.", request.Price)
-		desc += screen.sellCharacterSpecDesc(request.TCharacter, fmt.Sprintf("%d", request.Price))
+		desc += screen.sellCharacterDesc(screen.activeCharacter, fmt.Sprintf("%d", request.Price))
 	case W8_FULFILL_BUY_LOUD_TRDREQ:
 		request := screen.activeTrdReq
 		desc = loud.Sprintf("Making pylons from gold")
@@ -650,6 +631,28 @@ func (screen *GameScreen) TxWaitSituationDesc(width int) ([]string, []string) {
 		request := screen.activeTrdReq
 		desc = loud.Sprintf("Making gold from pylons")
 		desc += screen.buyLoudDesc(request.Amount, request.Total)
+	case W8_CANCEL_MYORDS:
+		desc = loud.Sprintf("You are now cancelling %d orders", len(screen.selectedMyOrders()))
+		desc += W8_TO_END
+	case W8_REPEAT_HUNT:
+		desc = loud.Localize("You are now hunting repeatedly") + "\n\n" + screen.repeatHuntProgressDesc()
+	case W8_BARTER_TRDREQ_CREATION:
+		request := screen.activeBarterTrdReq
+		desc = loud.Localize("You are now waiting for barter request creation")
+		desc += screen.barterDesc(screen.barterOfferLabels(request), screen.barterWantLabels(request))
+	case W8_FULFILL_BARTER_TRDREQ:
+		request := screen.activeBarterTrdReq
+		desc = loud.Localize("You are now bartering")
+		desc += screen.barterDesc(screen.barterWantLabels(request), screen.barterOfferLabels(request))
+	case W8_MKTORD:
+		plan := screen.marketOrder
+		desc = loud.Sprintf("You are now fulfilling %d trade requests at market", len(plan.Fills))
+		if plan.IsBuy {
+			desc += screen.buyLoudDesc(plan.Amount, plan.Total)
+		} else {
+			desc += screen.sellLoudDesc(plan.Amount, plan.Total)
+		}
+		desc += W8_TO_END
 	}
 	desc += "\n"
 	return strings.Split(desc, "\n"), []string{
@@ -0,0 +1,33 @@
+package screen
+
+import (
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+// lockedMark marks items and characters listed in my open trade requests
+func lockedMark(itemID string) string {
+	if loud.IsLocked(itemID) {
+		return " 🔒"
+	}
+	return ""
+}
+
+// offerUnlock asks user to cancel the trade request an item is listed in.
+// It returns false when the item is not locked.
+func (screen *GameScreen) offerUnlock(itemID string, label string) bool {
+	trdReqID, ok := loud.LockingTrdReqID(itemID)
+	if !ok {
+		return false
+	}
+	screen.unlockTrdReqID = trdReqID
+	screen.unlockItemLabel = label
+	screen.SetScreenStatusAndRefresh(CONFIRM_UNLOCK_ITEM)
+	return true
+}
+
+func (screen *GameScreen) RunUnlockItem() {
+	trdReqID := screen.unlockTrdReqID
+	screen.RunTxProcess(W8_CANCEL_TRDREQ, RSLT_CANCEL_TRDREQ, func() (string, error) {
+		return loud.CancelTrade(screen.user, trdReqID)
+	})
+}
@@ -0,0 +1,99 @@
+package screen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+var forestRecipeNames = map[ScreenStatus]string{
+	CONFIRM_FIGHT_GOBLIN:       loud.RCP_FIGHT_GOBLIN,
+	CONFIRM_FIGHT_WOLF:         loud.RCP_FIGHT_WOLF,
+	CONFIRM_FIGHT_TROLL:        loud.RCP_FIGHT_TROLL,
+	CONFIRM_FIGHT_GIANT:        loud.RCP_FIGHT_GIANT,
+	CONFIRM_FIGHT_DRAGONFIRE:   loud.RCP_FIGHT_DRAGONFIRE,
+	CONFIRM_FIGHT_DRAGONICE:    loud.RCP_FIGHT_DRAGONICE,
+	CONFIRM_FIGHT_DRAGONACID:   loud.RCP_FIGHT_DRAGONACID,
+	CONFIRM_FIGHT_DRAGONUNDEAD: loud.RCP_FIGHT_DRAGONUNDEAD,
+}
+
+// forestRecipeName returns the recipe which is executed when the forest action is confirmed
+func (screen *GameScreen) forestRecipeName(stus ScreenStatus) string {
+	rcpName := forestRecipeNames[stus]
+	if stus == CONFIRM_HUNT_RABBITS {
+		rcpName = loud.RCP_HUNT_RABBITS_YESWORD
+		if screen.user.GetActiveWeapon() == nil {
+			rcpName = loud.RCP_HUNT_RABBITS_NOSWORD
+		}
+	}
+	withArmor := screen.user.GetActiveArmor() != nil
+	if variantRcpName, ok := loud.HuntRecipeVariant(rcpName, withArmor, screen.user.GetActiveConsumable()); ok {
+		return variantRcpName
+	}
+	return rcpName
+}
+
+func fightOutcomeLabel(o loud.FightOutcome) string {
+	parts := []string{}
+	if o.CharacterLost {
+		parts = append(parts, loud.Localize("character dies"))
+	}
+	if o.Revived {
+		parts = append(parts, loud.Localize("revived by scroll"))
+	}
+	if o.WeaponLost {
+		parts = append(parts, loud.Localize("sword lost"))
+	}
+	if o.ArmorLost {
+		parts = append(parts, loud.Localize("armor lost"))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, loud.Localize("win"))
+	}
+	if o.Gold > 0 || o.XP > 0 {
+		parts = append(parts, fmt.Sprintf("💰 %.0f XP +%.0f", o.Gold, o.XP))
+	}
+	if len(o.Drop) > 0 {
+		parts = append(parts, "+ "+loud.Localize(o.Drop))
+	}
+	if o.Special {
+		parts = append(parts, "+ "+loud.Localize("bonus skill"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// fightEstimateDesc describes the outcome odds of the forest action for active character and weapon
+func (screen *GameScreen) fightEstimateDesc(stus ScreenStatus) string {
+	rcpName := screen.forestRecipeName(stus)
+	if len(rcpName) == 0 {
+		return ""
+	}
+	estimate, err := loud.EstimateRecipe(rcpName, screen.user.GetActiveCharacter(), screen.user.GetActiveWeapon(), screen.user.GetActiveArmor())
+	if err != nil {
+		return loud.Sprintf("Outcome odds are not available: %s", loud.Localize(err.Error()))
+	}
+	outcomes := append([]loud.FightOutcome{}, estimate.Outcomes...)
+	sort.SliceStable(outcomes, func(i, j int) bool {
+		return outcomes[i].Chance > outcomes[j].Chance
+	})
+	lines := []string{loud.Localize("Outcome odds")}
+	for _, o := range outcomes {
+		lines = append(lines, fmt.Sprintf("%5.1f%% %s", o.Chance*100, fightOutcomeLabel(o)))
+	}
+	lines = append(lines, loud.Sprintf("Expected reward 💰 %.1f XP +%.1f", estimate.ExpectedGold, estimate.ExpectedXP))
+	if estimate.DropChance > 0 {
+		lines = append(lines, loud.Sprintf("%.1f%% chance of getting \"%s\"", estimate.DropChance*100, loud.Localize(estimate.DropName)))
+	}
+	if estimate.SpecialChance > 0 {
+		specials := []string{}
+		for _, sp := range []int{loud.FIRE_SPECIAL, loud.ICE_SPECIAL, loud.ACID_SPECIAL} {
+			if chance, ok := estimate.SpecialChances[sp]; ok {
+				specials = append(specials, fmt.Sprintf("%s %.1f%%", formatSpecial(sp), chance*100))
+			}
+		}
+		lines = append(lines, loud.Sprintf("%.1f%% chance of bonus skill (%s)", estimate.SpecialChance*100, strings.Join(specials, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,96 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+func (screen *GameScreen) IsFilterableTable(status ScreenStatus) bool {
+	switch status {
+	case SHW_BUYITM_TRDREQS,
+		SHW_SELLITM_TRDREQS,
+		SHW_BUYCHR_TRDREQS,
+		SHW_SELLCHR_TRDREQS:
+		return true
+	}
+	return false
+}
+
+func (screen *GameScreen) filteredItemBuyTrdReqs() []loud.ItemBuyTrdReq {
+	return loud.FilterItemBuyTrdReqs(screen.user, loud.ItemBuyTrdReqs, screen.trdReqFilter(SHW_BUYITM_TRDREQS))
+}
+
+func (screen *GameScreen) filteredItemSellTrdReqs() []loud.ItemSellTrdReq {
+	return loud.FilterItemSellTrdReqs(screen.user, loud.ItemSellTrdReqs, screen.trdReqFilter(SHW_SELLITM_TRDREQS))
+}
+
+func (screen *GameScreen) filteredCharacterBuyTrdReqs() []loud.CharacterBuyTrdReq {
+	return loud.FilterCharacterBuyTrdReqs(screen.user, loud.CharacterBuyTrdReqs, screen.trdReqFilter(SHW_BUYCHR_TRDREQS))
+}
+
+func (screen *GameScreen) filteredCharacterSellTrdReqs() []loud.CharacterSellTrdReq {
+	return loud.FilterCharacterSellTrdReqs(screen.user, loud.CharacterSellTrdReqs, screen.trdReqFilter(SHW_SELLCHR_TRDREQS))
+}
+
+// trdReqFilter returns the filter applied to table, the kept filter is not applied while it's bypassed
+func (screen *GameScreen) trdReqFilter(status ScreenStatus) loud.TrdReqFilter {
+	if status == screen.unfilteredTable {
+		return loud.TrdReqFilter{}
+	}
+	return screen.trdReqFilters[status]
+}
+
+func (screen *GameScreen) trdReqFilterDesc(status ScreenStatus) string {
+	filter := screen.trdReqFilters[status]
+	if filter.IsEmpty() {
+		return ""
+	}
+	if status == screen.unfilteredTable {
+		return "🔎 " + loud.Localize("filter hides the watched row, all rows are shown until you leave")
+	}
+	descs := []string{}
+	if len(filter.Query) > 0 {
+		descs = append(descs, fmt.Sprintf("%s: %s", loud.Localize("filter"), filter.Query))
+	}
+	if filter.SortBy != loud.TRDSORT_NONE {
+		descs = append(descs, fmt.Sprintf("%s: %s", loud.Localize("sort"), loud.Localize("sort by "+filter.SortBy)))
+	}
+	return "🔎 " + strings.Join(descs, "  ")
+}
+
+func (screen *GameScreen) startTrdReqFilter() {
+	screen.filterReturn = screen.scrStatus
+	screen.inputText = screen.trdReqFilters[screen.scrStatus].Query
+	screen.SetScreenStatusAndRefresh(FILTER_TRDREQ_ENT_QUERY)
+}
+
+func (screen *GameScreen) applyTrdReqFilter(query string) {
+	filter, err := loud.ParseTrdReqFilter(query)
+	if err != nil {
+		screen.actionText = loud.Sprintf("invalid filter: %s", err.Error())
+		screen.Render()
+		return
+	}
+	filter.SortBy = screen.trdReqFilters[screen.filterReturn].SortBy
+	screen.trdReqFilters[screen.filterReturn] = filter
+	screen.unfilteredTable = ""
+	screen.activeLine = 0
+	screen.inputText = ""
+	screen.SetScreenStatusAndRefresh(screen.filterReturn)
+}
+
+func (screen *GameScreen) switchTrdReqSort() {
+	screen.trdReqFilters[screen.scrStatus] = screen.trdReqFilters[screen.scrStatus].NextSort()
+	screen.unfilteredTable = ""
+	screen.activeLine = 0
+	screen.Render()
+}
+
+// leaveUnfilteredTable applies the kept filter again once the table bypassing it is left
+func (screen *GameScreen) leaveUnfilteredTable(newStatus ScreenStatus) {
+	if newStatus != screen.unfilteredTable {
+		screen.unfilteredTable = ""
+	}
+}
@@ -1,7 +1,7 @@
 package screen
 
 import (
-	"fmt"
+	"github.com/mgutz/ansi"
 )
 
 type FontType string
@@ -18,85 +18,75 @@ const (
 	INPUT_ACTIVE_FONT          = "input_active_font"
 	BROWN_BOLD                 = "brown_bold"
 	BLUE_BOLD                  = "blue_bold"
+	HOVER                      = "hover"
+	MENU_REGULAR               = "menu_regular"
+	MENU_ACTIVE                = "menu_active"
+	BORDER                     = "border"
+	FILL                       = "fill"
 )
 
+// getFont resolves the font through active theme
 func (screen *GameScreen) getFont(ft FontType) func(string) string {
-	switch ft {
-	case REGULAR:
-		return screen.regularFont()
-	case GREY:
-		return screen.greyFont()
-	case BROWN:
-		return screen.brownFont()
-	case RED:
-		return screen.redFont()
-	case YELLOW:
-		return screen.yellowFont()
-	case GREEN:
-		return screen.greenFont()
-	case RED_BOLD:
-		return screen.redBoldFont()
-	case BLUE_BOLD:
-		return screen.blueBoldFont()
-	case BLINK_BLUE_BOLD:
-		return screen.blinkBlueBoldFont()
-	case INPUT_ACTIVE_FONT:
-		return screen.inputActiveFont()
-	case BROWN_BOLD:
-		return screen.brownBoldFont()
-	default:
-		return screen.regularFont()
-	}
+	return screen.colorFunc(screen.themeSpec(ft))
 }
 
 func (screen *GameScreen) redFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v:%v", 196, bgcolor))
+	return screen.getFont(RED)
 }
 
 func (screen *GameScreen) yellowFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v:%v", 208, bgcolor))
+	return screen.getFont(YELLOW)
 }
 
 func (screen *GameScreen) greenFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v:%v", 76, bgcolor))
+	return screen.getFont(GREEN)
 }
 
 func (screen *GameScreen) redBoldFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v+bh:%v", 196, bgcolor))
+	return screen.getFont(RED_BOLD)
 }
 
 func (screen *GameScreen) blueBoldFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v+bh:%v", 117, 232))
+	return screen.getFont(BLUE_BOLD)
 }
 
 func (screen *GameScreen) brownBoldFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v+bh:%v", 181, 232))
+	return screen.getFont(BROWN_BOLD)
 }
 
 func (screen *GameScreen) brownFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v:%v", 181, 232))
+	return screen.getFont(BROWN)
 }
 
 func (screen *GameScreen) regularFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("255:%v", bgcolor))
+	return screen.getFont(REGULAR)
+}
+
+func (screen *GameScreen) hoverFont() func(string) string {
+	return screen.getFont(HOVER)
 }
 
 func (screen *GameScreen) greyFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v:%v", 181, 232))
+	return screen.getFont(GREY)
 }
 
 func (screen *GameScreen) menuRegularFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v+bh:%v", 255, 0))
+	return screen.getFont(MENU_REGULAR)
 }
 
 func (screen *GameScreen) menuActiveFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v+bh:%v", 255, 202))
+	return screen.getFont(MENU_ACTIVE)
 }
 
 func (screen *GameScreen) blinkBlueBoldFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("%v+B:%v", 117, bgcolor))
+	return screen.getFont(BLINK_BLUE_BOLD)
 }
 
 func (screen *GameScreen) inputActiveFont() func(string) string {
-	return screen.colorFunc(fmt.Sprintf("0+b:%v", bgcolor-1))
+	return screen.getFont(INPUT_ACTIVE_FONT)
+}
+
+// colorCode is the ansi code starting text of the font, for drawing without reset
+func (screen *GameScreen) colorCode(ft FontType) string {
+	return ansi.ColorCode(screen.themeSpec(ft))
 }
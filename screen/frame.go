@@ -0,0 +1,299 @@
+package screen
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ahmetb/go-cursor"
+	"github.com/mattn/go-runewidth"
+)
+
+const resetStyle = "\x1b[0m"
+
+// cell is a character on the screen with the ansi style it's drawn with,
+// the right half of a wide character has empty ch
+type cell struct {
+	ch    string
+	style string
+}
+
+var blankCell = cell{" ", ""}
+
+func blankCells(width, height int) [][]cell {
+	cells := make([][]cell, height)
+	for row := range cells {
+		cells[row] = make([]cell, width)
+		for col := range cells[row] {
+			cells[row][col] = blankCell
+		}
+	}
+	return cells
+}
+
+// Frame is a cell buffer which boxes draw on with cursor moves and ansi styles as if it's the terminal.
+// Flush writes only the cells changed since the last flush.
+type Frame struct {
+	width  int
+	height int
+	back   [][]cell // cells drawn so far
+	front  [][]cell // cells on the terminal, nil when the terminal needs a full repaint
+	row    int      // 0 based cursor
+	col    int
+	sgr    sgrState
+	style  string // ansi style of sgr, cells are drawn with it
+	bell   bool
+}
+
+// sgrState is the ansi style set by color codes, only the last foreground and background colors are kept
+type sgrState struct {
+	attrs [10]bool // bold, faint, italic, underline, blink, rapid blink, inverse, conceal, strikethrough by code
+	fg    string   // eg. "31" or "38;5;208", empty is the default color
+	bg    string
+}
+
+// extendedColor returns "5;n" or "2;r;g;b" params following 38 or 48 and the number of params used
+func extendedColor(codes []string) (string, int) {
+	if len(codes) >= 2 && codes[0] == "5" {
+		return strings.Join(codes[:2], ";"), 2
+	}
+	if len(codes) >= 4 && codes[0] == "2" {
+		return strings.Join(codes[:4], ";"), 4
+	}
+	return "", len(codes)
+}
+
+// apply updates the state with params of a color code, eg. "0;1;38;5;208"
+func (s *sgrState) apply(params string) {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		n := 0
+		if codes[i] != "" {
+			var err error
+			if n, err = strconv.Atoi(codes[i]); err != nil {
+				continue
+			}
+		}
+		switch {
+		case n == 0:
+			*s = sgrState{}
+		case n >= 1 && n <= 9:
+			s.attrs[n] = true
+		case n == 22:
+			s.attrs[1], s.attrs[2] = false, false
+		case n == 25:
+			s.attrs[5], s.attrs[6] = false, false
+		case n >= 23 && n <= 29:
+			s.attrs[n-20] = false
+		case n >= 30 && n <= 37, n >= 90 && n <= 97:
+			s.fg = codes[i]
+		case n >= 40 && n <= 47, n >= 100 && n <= 107:
+			s.bg = codes[i]
+		case n == 39:
+			s.fg = ""
+		case n == 49:
+			s.bg = ""
+		case n == 38, n == 48:
+			color, used := extendedColor(codes[i+1:])
+			i += used
+			if color == "" {
+				continue
+			}
+			if n == 38 {
+				s.fg = "38;" + color
+			} else {
+				s.bg = "48;" + color
+			}
+		}
+	}
+}
+
+// String is the single color code of the state, empty when it's reset
+func (s sgrState) String() string {
+	params := []string{}
+	for code, on := range s.attrs {
+		if on {
+			params = append(params, strconv.Itoa(code))
+		}
+	}
+	for _, color := range []string{s.fg, s.bg} {
+		if color != "" {
+			params = append(params, color)
+		}
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// Resize starts over with a blank buffer when the size changes, and repaints the whole terminal on next flush
+func (frame *Frame) Resize(width, height int) {
+	if frame.width == width && frame.height == height && frame.back != nil {
+		return
+	}
+	frame.width, frame.height = width, height
+	frame.back = blankCells(width, height)
+	frame.front = nil
+}
+
+// Repaint makes next flush redraw every cell, eg. when the terminal was written by someone else
+func (frame *Frame) Repaint() {
+	frame.front = nil
+}
+
+func (frame *Frame) clear() {
+	frame.back = blankCells(frame.width, frame.height)
+}
+
+// Bell rings the terminal bell on next flush
+func (frame *Frame) Bell() {
+	frame.bell = true
+}
+
+// Write draws text on the buffer, it understands cursor moves, screen clear and color codes
+func (frame *Frame) Write(p []byte) (int, error) {
+	text := string(p)
+	for len(text) > 0 {
+		if text[0] == '\x1b' {
+			text = frame.escape(text)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text)
+		switch r {
+		case '\n':
+			frame.row++
+			frame.col = 0
+		case '\r':
+			frame.col = 0
+		case '\a':
+			frame.bell = true
+		default:
+			frame.put(text[:size], runewidth.RuneWidth(r))
+		}
+		text = text[size:]
+	}
+	return len(p), nil
+}
+
+// escape handles the escape sequence at the beginning of text and returns the rest
+func (frame *Frame) escape(text string) string {
+	if len(text) < 2 {
+		return ""
+	}
+	if text[1] == 'c' { // terminal reset
+		frame.clear()
+		frame.row, frame.col = 0, 0
+		frame.sgr, frame.style = sgrState{}, ""
+		return text[2:]
+	}
+	if text[1] != '[' {
+		return text[2:]
+	}
+	end := 2
+	for end < len(text) && (text[end] < 0x40 || text[end] > 0x7e) {
+		end++
+	}
+	if end == len(text) {
+		return ""
+	}
+	params := text[2:end]
+	switch text[end] {
+	case 'H':
+		row, col := 1, 1
+		fields := strings.Split(params, ";")
+		if n, err := strconv.Atoi(fields[0]); err == nil {
+			row = n
+		}
+		if len(fields) > 1 {
+			if n, err := strconv.Atoi(fields[1]); err == nil {
+				col = n
+			}
+		}
+		frame.row, frame.col = row-1, col-1
+	case 'J':
+		if params == "2" {
+			frame.clear()
+		}
+	case 'm':
+		frame.sgr.apply(params)
+		frame.style = frame.sgr.String()
+	}
+	return text[end+1:]
+}
+
+// put draws a character at cursor, overwriting half of a wide character blanks the other half like terminals do
+func (frame *Frame) put(ch string, width int) {
+	row, col := frame.row, frame.col
+	if row < 0 || row >= frame.height || col < 0 || col >= frame.width {
+		frame.col += width
+		return
+	}
+	cells := frame.back[row]
+	if width == 0 { // combining character, eg. variation selector of an emoji
+		if col > 0 {
+			cells[col-1].ch += ch
+		}
+		return
+	}
+	if cells[col].ch == "" && col > 0 {
+		cells[col-1] = cell{" ", cells[col-1].style}
+	}
+	if col+1 < frame.width && cells[col+1].ch == "" {
+		cells[col+1] = cell{" ", cells[col+1].style}
+	}
+	cells[col] = cell{ch, frame.style}
+	if width == 2 && col+1 < frame.width {
+		if col+2 < frame.width && cells[col+2].ch == "" {
+			cells[col+2] = cell{" ", cells[col+2].style}
+		}
+		cells[col+1] = cell{"", frame.style}
+	}
+	frame.col += width
+}
+
+// Flush writes the cells changed since the last flush to the terminal
+func (frame *Frame) Flush(w io.Writer) {
+	var buf bytes.Buffer
+	if frame.front == nil {
+		buf.WriteString(resetStyle + cursor.ClearEntireScreen() + allowMouseInputAndHideCursor)
+		frame.front = blankCells(frame.width, frame.height)
+	}
+	style := ""
+	for row := range frame.back {
+		termCol := -1 // column of the terminal cursor, -1 when unknown
+		for col, c := range frame.back[row] {
+			if c == frame.front[row][col] {
+				continue
+			}
+			frame.front[row][col] = c
+			if c.ch == "" { // drawn with its left half
+				continue
+			}
+			if termCol != col {
+				buf.WriteString(cursor.MoveTo(row+1, col+1))
+			}
+			if style != c.style {
+				buf.WriteString(resetStyle + c.style)
+				style = c.style
+			}
+			buf.WriteString(c.ch)
+			termCol = col + 1
+			if col+1 < frame.width && frame.back[row][col+1].ch == "" {
+				termCol++
+			}
+		}
+	}
+	if style != "" {
+		buf.WriteString(resetStyle)
+	}
+	if frame.bell {
+		buf.WriteString("\a")
+		frame.bell = false
+	}
+	if buf.Len() > 0 {
+		w.Write(buf.Bytes())
+	}
+}
@@ -0,0 +1,150 @@
+package screen
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func newTestFrame(width, height int) *Frame {
+	frame := &Frame{}
+	frame.Resize(width, height)
+	return frame
+}
+
+func flushString(frame *Frame) string {
+	var buf bytes.Buffer
+	frame.Flush(&buf)
+	return buf.String()
+}
+
+func rowText(frame *Frame, row int) string {
+	text := ""
+	for _, c := range frame.back[row] {
+		text += c.ch
+	}
+	return text
+}
+
+func TestFrameFlushDiff(t *testing.T) {
+	frame := newTestFrame(8, 2)
+	io.WriteString(frame, "hello")
+	if out := flushString(frame); !strings.HasPrefix(out, resetStyle+"\x1b[2J") || !strings.Contains(out, "hello") {
+		t.Errorf("first flush = %q, want full repaint", out)
+	}
+	if out := flushString(frame); out != "" {
+		t.Errorf("flush without changes = %q, want nothing", out)
+	}
+	io.WriteString(frame, "\x1b[1;1Hjello\x1b[2;3Hx")
+	if out, want := flushString(frame), "\x1b[1;1Hj\x1b[2;3Hx"; out != want {
+		t.Errorf("flush = %q, want %q", out, want)
+	}
+	io.WriteString(frame, "\x1b[1;2Hoy")
+	if out, want := flushString(frame), "\x1b[1;2Hoy"; out != want {
+		t.Errorf("adjacent cells flush = %q, want %q without cursor move between", out, want)
+	}
+	frame.Repaint()
+	if out := flushString(frame); !strings.Contains(out, "\x1b[2J") {
+		t.Errorf("flush after repaint = %q, want full repaint", out)
+	}
+}
+
+func TestFrameWideChar(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		want  string
+		cells []string
+	}{
+		{"wide chars take two cells", "漢字", "漢字  ", []string{"漢", "", "字", "", " ", " "}},
+		{"overwriting right half blanks left half", "漢字\x1b[1;2Ha", " a字  ", []string{" ", "a", "字", "", " ", " "}},
+		{"overwriting left half blanks right half", "漢字\x1b[1;3Hb", "漢b   ", []string{"漢", "", "b", " ", " ", " "}},
+		{"wide char over a wide char right half", "漢字\x1b[1;2H界", " 界   ", []string{" ", "界", "", " ", " ", " "}},
+		{"wide char at the last column", "\x1b[1;6H漢", "     漢", []string{" ", " ", " ", " ", " ", "漢"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := newTestFrame(6, 1)
+			io.WriteString(frame, tt.text)
+			if got := rowText(frame, 0); got != tt.want {
+				t.Errorf("row = %q, want %q", got, tt.want)
+			}
+			for col, ch := range tt.cells {
+				if frame.back[0][col].ch != ch {
+					t.Errorf("cell %d = %q, want %q", col, frame.back[0][col].ch, ch)
+				}
+			}
+		})
+	}
+}
+
+func TestFrameClear(t *testing.T) {
+	frame := newTestFrame(4, 2)
+	io.WriteString(frame, "\x1b[31mab\x1b[2;1Hcd\x1b[2J")
+	if rowText(frame, 0) != "    " || rowText(frame, 1) != "    " {
+		t.Errorf("rows after clear = %q %q, want blank", rowText(frame, 0), rowText(frame, 1))
+	}
+	io.WriteString(frame, "e")
+	if c := frame.back[1][2]; c.ch != "e" || c.style != "\x1b[31m" {
+		t.Errorf("clear screen moved cursor or reset style: %+v", c)
+	}
+
+	io.WriteString(frame, "\x1b[1mfg\x1bch")
+	if rowText(frame, 1) != "    " {
+		t.Errorf("row after terminal reset = %q, want blank", rowText(frame, 1))
+	}
+	if c := frame.back[0][0]; c.ch != "h" || c.style != "" {
+		t.Errorf("terminal reset didn't move cursor home and reset style: %+v", c)
+	}
+}
+
+func TestFrameStyle(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "", ""},
+		{"last foreground wins", "\x1b[31m\x1b[32m", "\x1b[32m"},
+		{"foreground and background", "\x1b[31m\x1b[44m\x1b[1m", "\x1b[1;31;44m"},
+		{"256 colors", "\x1b[0;1;38;5;208;48;5;17m\x1b[38;5;9m", "\x1b[1;38;5;9;48;5;17m"},
+		{"true color", "\x1b[38;2;1;2;3m\x1b[91m", "\x1b[91m"},
+		{"default colors", "\x1b[31;41m\x1b[39;49m", ""},
+		{"reset", "\x1b[1;31m\x1b[0m", ""},
+		{"empty reset", "\x1b[1;31m\x1b[m", ""},
+		{"reset inside params", "\x1b[4;31m\x1b[0;32m", "\x1b[32m"},
+		{"normal intensity", "\x1b[1;2;4m\x1b[22m", "\x1b[4m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := newTestFrame(2, 1)
+			io.WriteString(frame, tt.text)
+			if frame.style != tt.want {
+				t.Errorf("style = %q, want %q", frame.style, tt.want)
+			}
+		})
+	}
+
+	frame := newTestFrame(2, 1)
+	for i := 0; i < 100; i++ {
+		io.WriteString(frame, "\x1b[31m\x1b[1m\x1b[42m")
+	}
+	if want := "\x1b[1;31;42m"; frame.style != want {
+		t.Errorf("style after repeated codes = %q, want %q", frame.style, want)
+	}
+}
+
+func TestFrameFlushStyleCoalescing(t *testing.T) {
+	frame := newTestFrame(4, 1)
+	flushString(frame)
+	// same style set by different codes is written once
+	io.WriteString(frame, "\x1b[31;1mA\x1b[0m\x1b[1m\x1b[31mB\x1b[0mC")
+	out := flushString(frame)
+	if want := "\x1b[1;1H" + resetStyle + "\x1b[1;31mAB" + resetStyle + "C"; out != want {
+		t.Errorf("flush = %q, want %q", out, want)
+	}
+	if n := strings.Count(out, "\x1b[1;31m"); n != 1 {
+		t.Errorf("style is written %d times, want once", n)
+	}
+}
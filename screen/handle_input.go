@@ -15,6 +15,10 @@ import (
 func (screen *GameScreen) HandleInputKey(input termbox.Event) {
 	// initialize actionText since it's turning into a new command
 	screen.actionText = ""
+	// dismiss the oldest notification
+	if len(screen.toasts) > 0 {
+		screen.toasts = screen.toasts[1:]
+	}
 
 	// log input command
 	Key := strings.ToUpper(string(input.Ch))
@@ -36,18 +40,16 @@ func (screen *GameScreen) HandleInputKey(input termbox.Event) {
 }
 
 func (screen *GameScreen) HandleInputKeyLocationSwitch(input termbox.Event) bool {
-	Key := strings.ToUpper(string(input.Ch))
-
 	tarLctMap := map[string]loud.UserLocation{
-		"F": loud.FOREST,
-		"S": loud.SHOP,
-		"H": loud.HOME,
-		"T": loud.SETTINGS,
-		"C": loud.PYLCNTRL,
-		"D": loud.DEVELOP,
+		ACT_GO_FOREST:         loud.FOREST,
+		ACT_GO_SHOP:           loud.SHOP,
+		ACT_GO_HOME:           loud.HOME,
+		ACT_GO_SETTINGS:       loud.SETTINGS,
+		ACT_GO_PYLONS_CENTRAL: loud.PYLCNTRL,
+		ACT_GO_DEVELOP:        loud.DEVELOP,
 	}
 
-	if newLct, ok := tarLctMap[Key]; ok {
+	if newLct, ok := tarLctMap[screen.keys.Action(input)]; ok {
 		if newLct == loud.FOREST && screen.user.GetActiveCharacter() == nil {
 			screen.actionText = loud.Sprintf("You can't go to forest without character")
 			screen.Render()
@@ -61,21 +63,38 @@ func (screen *GameScreen) HandleInputKeyLocationSwitch(input termbox.Event) bool
 	return false
 }
 func (screen *GameScreen) HandleInputKeyHomeEntryPoint(input termbox.Event) bool {
-	Key := string(input.Ch)
-
-	tarStusMap := map[string]ScreenStatus{
-		"1": SEL_ACTIVE_CHAR,
-		"2": SEL_ACTIVE_WEAPON,
-		"3": SEL_RENAME_CHAR,
-	}
-
-	if newStus, ok := tarStusMap[Key]; ok {
-		screen.scrStatus = newStus
+	tarStusMap := map[int]ScreenStatus{
+		1: SEL_ACTIVE_CHAR,
+		2: SEL_ACTIVE_WEAPON,
+		3: SEL_RENAME_CHAR,
+		4: SHW_MY_ORDERS,
+		5: SHW_WATCHLIST,
+		6: SHW_BATTLE_HISTORY,
+		7: SHW_PROGRESSION,
+		8: SEL_ACTIVE_ARMOR,
+		9: SHW_ACHIEVEMENTS,
+		0: SHW_LEADERBOARD,
+	}
+
+	if newStus, ok := tarStusMap[screen.keys.Number(input)]; ok {
+		screen.SetScreenStatus(newStus)
 		switch newStus {
 		case SEL_ACTIVE_CHAR:
-			screen.activeLine = screen.user.GetActiveCharacterIndex()
+			screen.activeLine = screen.activeEntryLine(SEL_ACTIVE_CHAR)
 		case SEL_ACTIVE_WEAPON:
-			screen.activeLine = screen.user.GetActiveWeaponIndex()
+			screen.activeLine = screen.activeEntryLine(SEL_ACTIVE_WEAPON)
+		case SEL_ACTIVE_ARMOR:
+			screen.activeLine = screen.activeEntryLine(SEL_ACTIVE_ARMOR)
+		case SHW_MY_ORDERS:
+			screen.activeLine = 0
+			screen.myOrderSel = make(map[string]bool)
+		case SEL_RENAME_CHAR, SHW_WATCHLIST, SHW_BATTLE_HISTORY:
+			screen.activeLine = 0
+		case SHW_LEADERBOARD:
+			screen.activeLine = 0
+			if screen.loadLeaderboard().UpdatedAt == 0 {
+				screen.refreshLeaderboard()
+			}
 		}
 		screen.Render()
 		return true
@@ -84,26 +103,27 @@ func (screen *GameScreen) HandleInputKeyHomeEntryPoint(input termbox.Event) bool
 	}
 }
 func (screen *GameScreen) HandleInputKeyPylonsCentralEntryPoint(input termbox.Event) bool {
-	Key := string(input.Ch)
-
-	tarStusMap := map[string]ScreenStatus{
-		"1": SEL_BUYCHR,
-		"2": W8_BUY_GOLD_WITH_PYLONS,
-		"3": SHW_LOUD_BUY_TRDREQS,
-		"4": SHW_LOUD_SELL_TRDREQS,
-		"5": SHW_BUYITM_TRDREQS,
-		"6": SHW_SELLITM_TRDREQS,
-		"7": SHW_BUYCHR_TRDREQS,
-		"8": SHW_SELLCHR_TRDREQS,
-	}
-
-	if newStus, ok := tarStusMap[Key]; ok {
+	tarStusMap := map[int]ScreenStatus{
+		1: SEL_BUYCHR,
+		2: W8_BUY_GOLD_WITH_PYLONS,
+		3: SHW_LOUD_BUY_TRDREQS,
+		4: SHW_LOUD_SELL_TRDREQS,
+		5: SHW_BUYITM_TRDREQS,
+		6: SHW_SELLITM_TRDREQS,
+		7: SHW_BUYCHR_TRDREQS,
+		8: SHW_SELLCHR_TRDREQS,
+		9: SHW_PRICE_HISTORY,
+		0: SHW_BARTER_TRDREQS,
+	}
+
+	if newStus, ok := tarStusMap[screen.keys.Number(input)]; ok {
 		if newStus == W8_BUY_GOLD_WITH_PYLONS {
 			screen.RunTxProcess(W8_BUY_GOLD_WITH_PYLONS, RSLT_BUY_GOLD_WITH_PYLONS, func() (string, error) {
 				return loud.BuyGoldWithPylons(screen.user)
 			})
 		} else {
-			screen.scrStatus = newStus
+			screen.SetScreenStatus(newStus)
+			screen.activeLine = 0
 			screen.Render()
 		}
 		return true
@@ -113,20 +133,21 @@ func (screen *GameScreen) HandleInputKeyPylonsCentralEntryPoint(input termbox.Ev
 }
 
 func (screen *GameScreen) HandleInputKeySettingsEntryPoint(input termbox.Event) bool {
-	Key := string(input.Ch)
-
-	tarLangMap := map[string]string{
-		"1": "en",
-		"2": "es",
+	tarLangMap := map[int]string{
+		1: "en",
+		2: "es",
 	}
 
-	if newLang, ok := tarLangMap[Key]; ok {
+	number := screen.keys.Number(input)
+	if newLang, ok := tarLangMap[number]; ok {
 		loud.GameLanguage = newLang
 		screen.Render()
 		return true
-	} else {
-		return false
 	}
+	if themeIdx := number - SETTINGS_THEME_NUMBER; themeIdx >= 0 && themeIdx < len(screen.themes) {
+		return screen.SetTheme(screen.themes[themeIdx].Name)
+	}
+	return false
 }
 
 func (screen *GameScreen) ForestStatusCheck(newStus ScreenStatus) (string, string) {
@@ -176,27 +197,25 @@ func (screen *GameScreen) ForestStatusCheck(newStus ScreenStatus) (string, strin
 }
 
 func (screen *GameScreen) HandleInputKeyForestEntryPoint(input termbox.Event) bool {
-	Key := strings.ToUpper(string(input.Ch))
-
-	tarStusMap := map[string]ScreenStatus{
-		"1": CONFIRM_HUNT_RABBITS,
-		"2": CONFIRM_FIGHT_GOBLIN,
-		"3": CONFIRM_FIGHT_WOLF,
-		"4": CONFIRM_FIGHT_TROLL,
-		"5": CONFIRM_FIGHT_GIANT,
-		"6": CONFIRM_FIGHT_DRAGONFIRE,
-		"7": CONFIRM_FIGHT_DRAGONICE,
-		"8": CONFIRM_FIGHT_DRAGONACID,
-		"9": CONFIRM_FIGHT_DRAGONUNDEAD,
-	}
-
-	if newStus, ok := tarStusMap[Key]; ok {
+	tarStusMap := map[int]ScreenStatus{
+		1: CONFIRM_HUNT_RABBITS,
+		2: CONFIRM_FIGHT_GOBLIN,
+		3: CONFIRM_FIGHT_WOLF,
+		4: CONFIRM_FIGHT_TROLL,
+		5: CONFIRM_FIGHT_GIANT,
+		6: CONFIRM_FIGHT_DRAGONFIRE,
+		7: CONFIRM_FIGHT_DRAGONICE,
+		8: CONFIRM_FIGHT_DRAGONACID,
+		9: CONFIRM_FIGHT_DRAGONUNDEAD,
+	}
+
+	if newStus, ok := tarStusMap[screen.keys.Number(input)]; ok {
 		if fst, _ := screen.ForestStatusCheck(newStus); len(fst) > 0 {
 			screen.actionText = fst
 			screen.Render()
 			return true
 		}
-		screen.scrStatus = newStus
+		screen.SetScreenStatus(newStus)
 		screen.Render()
 		return true
 	} else {
@@ -205,16 +224,16 @@ func (screen *GameScreen) HandleInputKeyForestEntryPoint(input termbox.Event) bo
 }
 
 func (screen *GameScreen) HandleInputKeyShopEntryPoint(input termbox.Event) bool {
-	Key := strings.ToUpper(string(input.Ch))
-
-	tarStusMap := map[string]ScreenStatus{
-		"1": SEL_BUYITM,
-		"2": SEL_SELLITM,
-		"3": SEL_UPGITM,
+	tarStusMap := map[int]ScreenStatus{
+		1: SEL_BUYITM,
+		2: SEL_SELLITM,
+		3: SEL_UPGITM,
+		4: SHW_CRAFTING,
 	}
 
-	if newStus, ok := tarStusMap[Key]; ok {
-		screen.scrStatus = newStus
+	if newStus, ok := tarStusMap[screen.keys.Number(input)]; ok {
+		screen.SetScreenStatus(newStus)
+		screen.activeLine = 0
 		screen.Render()
 		return true
 	} else {
@@ -223,8 +242,6 @@ func (screen *GameScreen) HandleInputKeyShopEntryPoint(input termbox.Event) bool
 }
 
 func (screen *GameScreen) MoveToNextStep() {
-	activeCharacter := screen.user.GetActiveCharacter()
-
 	switch screen.scrStatus {
 	case CONFIRM_HUNT_RABBITS:
 		screen.RunHuntRabbits()
@@ -253,162 +270,67 @@ func (screen *GameScreen) MoveToNextStep() {
 	case CONFIRM_FIGHT_DRAGONUNDEAD:
 		screen.RunFightDragonUndead()
 		return
+	case CONFIRM_MKTORD:
+		screen.RunMarketOrder()
+		return
+	case CONFIRM_UNLOCK_ITEM:
+		screen.RunUnlockItem()
+		return
+	case SHW_MY_ORDERS:
+		screen.RunCancelMyOrders()
+		return
+	case CR8_BARTER_SEL_OFFER_ITEMS:
+		screen.inputText = ""
+		screen.SetScreenStatusAndRefresh(CR8_BARTER_ENT_OFFER_PYLVAL)
+		return
+	case CR8_BARTER_SEL_WANT_ITEMS:
+		screen.inputText = ""
+		screen.SetScreenStatusAndRefresh(CR8_BARTER_ENT_WANT_PYLVAL)
+		return
 	}
-	nextMapper := map[ScreenStatus]ScreenStatus{
-		RSLT_HUNT_RABBITS:              CONFIRM_HUNT_RABBITS,
-		RSLT_FIGHT_GOBLIN:              CONFIRM_FIGHT_GOBLIN,
-		RSLT_FIGHT_TROLL:               CONFIRM_FIGHT_TROLL,
-		RSLT_FIGHT_WOLF:                CONFIRM_FIGHT_WOLF,
-		RSLT_FIGHT_GIANT:               CONFIRM_FIGHT_GIANT,
-		RSLT_FIGHT_DRAGONFIRE:          CONFIRM_FIGHT_DRAGONFIRE,
-		RSLT_FIGHT_DRAGONICE:           CONFIRM_FIGHT_DRAGONICE,
-		RSLT_FIGHT_DRAGONACID:          CONFIRM_FIGHT_DRAGONACID,
-		RSLT_FIGHT_DRAGONUNDEAD:        CONFIRM_FIGHT_DRAGONUNDEAD,
-		RSLT_BUY_LOUD_TRDREQ_CREATION:  SHW_LOUD_BUY_TRDREQS,
-		RSLT_FULFILL_BUY_LOUD_TRDREQ:   SHW_LOUD_BUY_TRDREQS,
-		RSLT_SELL_LOUD_TRDREQ_CREATION: SHW_LOUD_SELL_TRDREQS,
-		RSLT_FULFILL_SELL_LOUD_TRDREQ:  SHW_LOUD_SELL_TRDREQS,
-		RSLT_SELLITM_TRDREQ_CREATION:   SHW_SELLITM_TRDREQS,
-		RSLT_FULFILL_SELLITM_TRDREQ:    SHW_SELLITM_TRDREQS,
-		RSLT_BUYITM_TRDREQ_CREATION:    SHW_BUYITM_TRDREQS,
-		RSLT_FULFILL_BUYITM_TRDREQ:     SHW_BUYITM_TRDREQS,
-		RSLT_SELLCHR_TRDREQ_CREATION:   SHW_SELLCHR_TRDREQS,
-		RSLT_FULFILL_SELLCHR_TRDREQ:    SHW_SELLCHR_TRDREQS,
-		RSLT_BUYCHR_TRDREQ_CREATION:    SHW_BUYCHR_TRDREQS,
-		RSLT_CANCEL_TRDREQ:             SHW_LOCATION,
-		RSLT_FULFILL_BUYCHR_TRDREQ:     SHW_BUYCHR_TRDREQS,
-		RSLT_RENAME_CHAR:               SEL_RENAME_CHAR,
-		RSLT_SEL_ACT_CHAR:              SEL_ACTIVE_CHAR,
-		RSLT_SEL_ACT_WEAPON:            SEL_ACTIVE_WEAPON,
-		RSLT_BUYITM:                    SEL_BUYITM,
-		RSLT_BUYCHR:                    SEL_BUYCHR,
-		RSLT_SELLITM:                   SEL_SELLITM,
-		RSLT_UPGITM:                    SEL_UPGITM,
-	}
-	if nextStatus, ok := nextMapper[screen.scrStatus]; ok {
-		if screen.user.GetLocation() == loud.DEVELOP {
-			screen.scrStatus = SHW_LOCATION
-		} else if screen.user.GetLocation() == loud.FOREST && activeCharacter == nil {
-			// move back to home in forest if no active character
-			screen.scrStatus = SHW_LOCATION
-		} else if nextStatus == CONFIRM_FIGHT_GIANT && activeCharacter.Special != loud.NO_SPECIAL {
-			// go back to forest entrypoint when Special is not empty
-			screen.scrStatus = SHW_LOCATION
-		} else {
-			screen.scrStatus = nextStatus
-		}
+	if screen.scrStatus.IsResultScreen() {
+		// result screens go back to where the flow started
+		screen.NavigateBack()
 	} else {
-		screen.scrStatus = SHW_LOCATION
+		screen.SetScreenStatus(SHW_LOCATION)
 	}
 	screen.txFailReason = ""
 	screen.Render()
 }
 
 func (screen *GameScreen) MoveToPrevStep() {
-	activeCharacter := screen.user.GetActiveCharacter()
-
-	prevMapper := map[ScreenStatus]ScreenStatus{
-		CR8_BUY_LOUD_TRDREQ_ENT_LUDVAL:  SHW_LOUD_BUY_TRDREQS,
-		CR8_BUY_LOUD_TRDREQ_ENT_PYLVAL:  CR8_BUY_LOUD_TRDREQ_ENT_LUDVAL,
-		CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL: SHW_LOUD_SELL_TRDREQS,
-		CR8_SELL_LOUD_TRDREQ_ENT_PYLVAL: CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL,
-		CR8_SELLITM_TRDREQ_SEL_ITEM:     SHW_SELLITM_TRDREQS,
-		CR8_SELLITM_TRDREQ_ENT_PYLVAL:   CR8_SELLITM_TRDREQ_SEL_ITEM,
-		CR8_BUYITM_TRDREQ_SEL_ITEM:      SHW_BUYITM_TRDREQS,
-		CR8_BUYITM_TRDREQ_ENT_PYLVAL:    CR8_BUYITM_TRDREQ_SEL_ITEM,
-		CR8_SELLCHR_TRDREQ_SEL_CHR:      SHW_SELLCHR_TRDREQS,
-		CR8_SELLCHR_TRDREQ_ENT_PYLVAL:   CR8_SELLCHR_TRDREQ_SEL_CHR,
-		CR8_BUYCHR_TRDREQ_SEL_CHR:       SHW_BUYCHR_TRDREQS,
-		CR8_BUYCHR_TRDREQ_ENT_PYLVAL:    CR8_BUYCHR_TRDREQ_SEL_CHR,
-		RENAME_CHAR_ENT_NEWNAME:         SEL_RENAME_CHAR,
-		RSLT_HUNT_RABBITS:               CONFIRM_HUNT_RABBITS,
-		RSLT_FIGHT_GOBLIN:               CONFIRM_FIGHT_GOBLIN,
-		RSLT_FIGHT_TROLL:                CONFIRM_FIGHT_TROLL,
-		RSLT_FIGHT_WOLF:                 CONFIRM_FIGHT_WOLF,
-		RSLT_FIGHT_GIANT:                CONFIRM_FIGHT_GIANT,
-		RSLT_FIGHT_DRAGONFIRE:           CONFIRM_FIGHT_DRAGONFIRE,
-		RSLT_FIGHT_DRAGONICE:            CONFIRM_FIGHT_DRAGONICE,
-		RSLT_FIGHT_DRAGONACID:           CONFIRM_FIGHT_DRAGONACID,
-		RSLT_FIGHT_DRAGONUNDEAD:         CONFIRM_FIGHT_DRAGONUNDEAD,
-		RSLT_BUY_LOUD_TRDREQ_CREATION:   SHW_LOUD_BUY_TRDREQS,
-		RSLT_FULFILL_BUY_LOUD_TRDREQ:    SHW_LOUD_BUY_TRDREQS,
-		RSLT_SELL_LOUD_TRDREQ_CREATION:  SHW_LOUD_SELL_TRDREQS,
-		RSLT_FULFILL_SELL_LOUD_TRDREQ:   SHW_LOUD_SELL_TRDREQS,
-		RSLT_SELLITM_TRDREQ_CREATION:    SHW_SELLITM_TRDREQS,
-		RSLT_FULFILL_SELLITM_TRDREQ:     SHW_SELLITM_TRDREQS,
-		RSLT_BUYITM_TRDREQ_CREATION:     SHW_BUYITM_TRDREQS,
-		RSLT_FULFILL_BUYITM_TRDREQ:      SHW_BUYITM_TRDREQS,
-		RSLT_SELLCHR_TRDREQ_CREATION:    SHW_SELLCHR_TRDREQS,
-		RSLT_FULFILL_SELLCHR_TRDREQ:     SHW_SELLCHR_TRDREQS,
-		RSLT_BUYCHR_TRDREQ_CREATION:     SHW_BUYCHR_TRDREQS,
-		RSLT_CANCEL_TRDREQ:              SHW_LOCATION,
-		RSLT_FULFILL_BUYCHR_TRDREQ:      SHW_BUYCHR_TRDREQS,
-		RSLT_RENAME_CHAR:                SEL_RENAME_CHAR,
-		RSLT_SEL_ACT_CHAR:               SEL_ACTIVE_CHAR,
-		RSLT_SEL_ACT_WEAPON:             SEL_ACTIVE_WEAPON,
-		RSLT_BUYITM:                     SEL_BUYITM,
-		RSLT_BUYCHR:                     SEL_BUYCHR,
-		RSLT_SELLITM:                    SEL_SELLITM,
-		RSLT_UPGITM:                     SEL_UPGITM,
-	}
-
-	nxtStatus := SHW_LOCATION
-	if nextStatus, ok := prevMapper[screen.scrStatus]; ok {
-		nxtStatus = nextStatus
-	}
-
-	switch nxtStatus {
-	case CR8_BUY_LOUD_TRDREQ_ENT_LUDVAL,
-		CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL:
-		// set loud value previously entered
-		screen.inputText = screen.loudEnterValue
-	case SHW_LOCATION:
-		// move to home if it's somewhere else's entrypoint
-		if screen.scrStatus == SHW_LOCATION {
-			screen.user.SetLocation(loud.HOME)
-		}
-	case CONFIRM_FIGHT_GIANT:
-		if activeCharacter.Special != loud.NO_SPECIAL {
-			// go back to forest entrypoint when Special is not empty
-			screen.scrStatus = SHW_LOCATION
-		}
-	}
-
-	if screen.user.GetLocation() == loud.FOREST && activeCharacter == nil {
-		// move back to home in forest if no active character
-		screen.scrStatus = SHW_LOCATION
-		screen.user.SetLocation(loud.HOME)
-	}
-
-	screen.scrStatus = nxtStatus
+	screen.NavigateBack()
 	screen.Render()
 }
 
 func (screen *GameScreen) HandleFirstClassInputKeys(input termbox.Event) bool {
-	if input.Key == termbox.KeyEsc {
+	action := screen.keys.Action(input)
+	if action == ACT_EXIT {
 		switch screen.scrStatus {
 		case CONFIRM_ENDGAME:
-			screen.scrStatus = SHW_LOCATION
+			screen.NavigateBack()
 		default:
-			screen.scrStatus = CONFIRM_ENDGAME
+			screen.SetScreenStatus(CONFIRM_ENDGAME)
 		}
 		screen.Render()
 		return true
 	}
+	if action == ACT_SWITCH_PANE {
+		return screen.SwitchPane()
+	}
 	// implement first class commands, eg. development input keys
 	if screen.HandleInputKeyLocationSwitch(input) {
 		return true
 	}
-	Key := strings.ToUpper(string(input.Ch))
-	switch Key {
-	case "J": // Create cookbook
+	switch action {
+	case ACT_CREATE_COOKBOOK:
 		if !loud.AutomateInput {
 			return false
 		}
 		screen.RunTxProcess(W8_CREATE_COOKBOOK, RSLT_CREATE_COOKBOOK, func() (string, error) {
 			return loud.CreateCookbook(screen.user)
 		})
-	case "Z": // Switch user
+	case ACT_SWITCH_USER:
 		screen.SetScreenStatusAndRefresh(W8_SWITCH_USER)
 		go func() {
 			newUser := screen.world.GetUser(fmt.Sprintf("%d", time.Now().Unix()))
@@ -417,21 +339,27 @@ func (screen *GameScreen) HandleFirstClassInputKeys(input termbox.Event) bool {
 			screen.user.SetLocation(orgLocation) // set the user back to original location
 			screen.SetScreenStatusAndRefresh(RSLT_SWITCH_USER)
 		}()
-	case "Y": // get initial pylons
+	case ACT_GET_PYLONS:
 		screen.RunTxProcess(W8_GET_PYLONS, RSLT_GET_PYLONS, func() (string, error) {
 			return loud.GetExtraPylons(screen.user)
 		})
-	case "B": // DEV ITEMS GET (troll toes, goblin ear, wolf tail)
+	case ACT_DEV_ITEMS: // troll toes, goblin ear, wolf tail
 		screen.RunTxProcess(W8_DEV_GET_TEST_ITEMS, RSLT_DEV_GET_TEST_ITEMS, func() (string, error) {
 			return loud.DevGetTestItems(screen.user)
 		})
-	case "L": // copy last txhash to CLIPBOARD
+	case ACT_COPY_TXHASH:
 		clipboard.WriteAll(screen.user.GetLastTxHash())
-	case "M": // copy user's cosmos address to CLIPBOARD
+	case ACT_COPY_ADDRESS: // cosmos address
 		clipboard.WriteAll(screen.user.GetAddress())
-	case "E": // REFRESH
+	case ACT_REFRESH:
+		loud.MarkLoudRecipesStale()
 		screen.Resync()
 		return true
+	case ACT_GO_WATCH_ALERT:
+		if !screen.hasWatchAlert {
+			return false
+		}
+		return screen.jumpToWatchAlert(screen.lastWatchAlert)
 	default:
 		return false
 	}
@@ -471,97 +399,143 @@ func (screen *GameScreen) HandleSecondClassInputKeys(input termbox.Event) bool {
 
 func (screen *GameScreen) HandleThirdClassInputKeys(input termbox.Event) bool {
 	// implement thid class commands, eg. commands which are not processed by first, second classes
-	Key := strings.ToUpper(string(input.Ch))
-	switch input.Key {
-	case termbox.KeyArrowLeft:
-	case termbox.KeyArrowRight:
-	case termbox.KeyArrowUp:
+	switch screen.keys.Action(input) {
+	case ACT_UP:
 		if screen.activeLine > 0 {
 			screen.activeLine -= 1
 		}
 		return true
-	case termbox.KeyArrowDown:
+	case ACT_DOWN:
 		screen.activeLine += 1
 		return true
-	}
-	if input.Key == termbox.KeyEnter {
+	case ACT_SELECT:
 		return screen.HandleThirdClassKeyEnterEvent()
-	}
-
-	if input.Key == termbox.KeyBackspace2 || input.Key == termbox.KeyBackspace {
+	case ACT_BACK:
 		screen.MoveToPrevStep()
-	}
-
-	switch Key {
-	case "R": // CREATE ORDER
+	case ACT_FORWARD:
+		return screen.NavigateForward()
+	case ACT_CREATE: // CREATE ORDER
 		if screen.user.GetLocation() == loud.PYLCNTRL {
 			switch screen.scrStatus {
 			case SHW_LOUD_BUY_TRDREQS:
-				screen.scrStatus = CR8_BUY_LOUD_TRDREQ_ENT_LUDVAL
+				screen.SetScreenStatus(CR8_BUY_LOUD_TRDREQ_ENT_LUDVAL)
 			case SHW_LOUD_SELL_TRDREQS:
-				screen.scrStatus = CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL
+				screen.SetScreenStatus(CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL)
 			case SHW_SELLITM_TRDREQS:
-				screen.scrStatus = CR8_SELLITM_TRDREQ_SEL_ITEM
+				screen.SetScreenStatus(CR8_SELLITM_TRDREQ_SEL_ITEM)
 			case SHW_BUYITM_TRDREQS:
-				screen.scrStatus = CR8_BUYITM_TRDREQ_SEL_ITEM
+				screen.SetScreenStatus(CR8_BUYITM_TRDREQ_SEL_ITEM)
 			case SHW_SELLCHR_TRDREQS:
-				screen.scrStatus = CR8_SELLCHR_TRDREQ_SEL_CHR
+				screen.SetScreenStatus(CR8_SELLCHR_TRDREQ_SEL_CHR)
 			case SHW_BUYCHR_TRDREQS:
-				screen.scrStatus = CR8_BUYCHR_TRDREQ_SEL_CHR
+				screen.SetScreenStatus(CR8_BUYCHR_TRDREQ_SEL_CHR)
+			case SHW_BARTER_TRDREQS:
+				screen.startBarterCreation()
+			}
+			if _, ok := screen.selectEntries(screen.scrStatus); ok {
+				screen.activeLine = 0
 			}
 			screen.inputText = ""
 			screen.Render()
 			return true
 		}
-	case "O": // GO ON
-		screen.MoveToNextStep()
-		return true
-	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9": // Numbers
-		switch screen.scrStatus {
-		case SEL_ACTIVE_CHAR:
-			screen.activeLine = loud.GetIndexFromString(Key)
-			screen.RunActiveCharacterSelect(screen.activeLine)
-		case SEL_ACTIVE_WEAPON:
-			screen.activeLine = loud.GetIndexFromString(Key)
-			screen.RunActiveWeaponSelect(screen.activeLine)
-		case SEL_RENAME_CHAR:
-			screen.activeLine = loud.GetIndexFromString(Key)
-			characters := screen.user.InventoryCharacters()
-			if len(characters) <= screen.activeLine || screen.activeLine < 0 {
-				return false
-			}
-			screen.activeCharacter = characters[screen.activeLine]
-			screen.scrStatus = RENAME_CHAR_ENT_NEWNAME
+		if screen.scrStatus == SHW_WATCHLIST { // ADD WATCH RULE
 			screen.inputText = ""
+			screen.SetScreenStatusAndRefresh(WATCH_ENT_RULE)
+			return true
+		}
+		if screen.scrStatus == SHW_LEADERBOARD { // REFRESH LEADERBOARD
+			return screen.refreshLeaderboard()
+		}
+	case ACT_SWITCH_MODE: // ORDER BOOK MODE
+		switch screen.scrStatus {
+		case SHW_LOUD_BUY_TRDREQS, SHW_LOUD_SELL_TRDREQS:
+			screen.orderBook = loud.BuildOrderBook(loud.BuyTrdReqs, loud.SellTrdReqs)
+			screen.SetScreenStatusAndRefresh(SHW_LOUD_ORDERBOOK)
+			return true
+		case SHW_LOUD_ORDERBOOK: // order book is opened from the request list it switches back to
+			screen.MoveToPrevStep()
+			return true
+		case SHW_WATCHLIST: // switch terminal bell on watch alerts
+			screen.toggleWatchBell()
+			return true
+		case SHW_PRICE_HISTORY: // switch day and block range summary
+			screen.priceHistoryByBlock = !screen.priceHistoryByBlock
 			screen.Render()
-		case SEL_BUYITM:
-			screen.activeItem = loud.GetToBuyItemFromKey(Key)
-			if len(screen.activeItem.Name) == 0 {
-				return false
-			}
-			screen.RunActiveItemBuy()
-		case SEL_BUYCHR:
-			screen.activeCharacter = loud.GetToBuyCharacterFromKey(Key)
-			if len(screen.activeCharacter.Name) == 0 {
-				return false
-			}
-			screen.RunActiveCharacterBuy()
-		case SEL_SELLITM:
-			screen.activeItem = loud.GetToSellItemFromKey(screen.user, Key)
-			if len(screen.activeItem.Name) == 0 {
-				return false
-			}
-			screen.RunActiveItemSell()
-
-		case SEL_UPGITM:
-			screen.activeItem = loud.GetToUpgradeItemFromKey(screen.user, Key)
-			if len(screen.activeItem.Name) == 0 {
-				return false
-			}
-			screen.RunActiveItemUpgrade()
+			return true
 		}
+	case ACT_MARKET_ORDER:
+		switch screen.scrStatus {
+		case SHW_LOUD_SELL_TRDREQS, SHW_LOUD_BUY_TRDREQS:
+			// buying at market fulfills sell requests and selling fulfills buy requests
+			screen.marketOrder = loud.MarketOrderPlan{IsBuy: screen.scrStatus == SHW_LOUD_SELL_TRDREQS}
+			screen.inputText = ""
+			screen.SetScreenStatusAndRefresh(CR8_MKTORD_ENT_LUDVAL)
+			return true
+		case SHW_MY_ORDERS: // select all orders
+			screen.toggleAllMyOrders()
+			return true
+		}
+	case ACT_FILTER: // FILTER TRADE REQUESTS
+		if screen.IsFilterableTable(screen.scrStatus) {
+			screen.startTrdReqFilter()
+			return true
+		}
+	case ACT_SORT: // SORT TRADE REQUESTS
+		if screen.IsFilterableTable(screen.scrStatus) {
+			screen.switchTrdReqSort()
+			return true
+		}
+		if screen.scrStatus == SHW_WATCHLIST { // REMOVE WATCH RULE
+			return screen.removeActiveWatchRule()
+		}
+		if screen.scrStatus == SHW_LEADERBOARD { // SWITCH RANKING
+			return screen.switchLeaderboardSort()
+		}
+	case ACT_GO_ON:
+		screen.MoveToNextStep()
+		return true
+	case ACT_REPEAT_HUNT:
+		return screen.startRepeatHunt()
+	case ACT_STOP_REPEAT_HUNT:
+		return screen.stopRepeatHunt()
+	case ACT_USE_CONSUMABLE:
+		return screen.toggleConsumable()
+	case ACT_PREV_PAGE: // PREVIOUS PAGE OF SELECT LIST
+		return screen.moveSelectPage(-1)
+	case ACT_NEXT_PAGE:
+		return screen.moveSelectPage(1)
+	case ACT_JUMP_TO_NUMBER: // JUMP TO ENTRY NUMBER OF SELECT LIST
+		return screen.startSelectJump()
+	case ACT_SHEET_SCROLL_UP: // SCROLL CHARACTER SHEET INVENTORY
+		if screen.sheetScroll > 0 {
+			screen.sheetScroll--
+		}
+		screen.Render()
+		return true
+	case ACT_SHEET_SCROLL_DOWN:
+		screen.sheetScroll++
 		screen.Render()
 		return true
+	default:
+		number := screen.keys.Number(input)
+		if number < 0 || !screen.IsNumberedSelectList(screen.scrStatus) {
+			return false
+		}
+		if number == 0 { // DESELECT
+			switch screen.scrStatus {
+			case SEL_ACTIVE_CHAR:
+				screen.RunActiveCharacterSelect(loud.NO_ACTIVE_ID)
+			case SEL_ACTIVE_WEAPON:
+				screen.RunActiveWeaponSelect(loud.NO_ACTIVE_ID)
+			case SEL_ACTIVE_ARMOR:
+				screen.RunActiveArmorSelect(loud.NO_ACTIVE_ID)
+			default:
+				return false
+			}
+			return true
+		}
+		return screen.selectPageEntry(number)
 	}
 	return false
 }
@@ -582,90 +556,120 @@ func (screen *GameScreen) HandleThirdClassKeyEnterEvent() bool {
 			screen.RunSelectedCharacterBuyTrdReq()
 		case SHW_SELLCHR_TRDREQS:
 			screen.RunSelectedCharacterSellTrdReq()
+		case SHW_BARTER_TRDREQS:
+			screen.RunSelectedBarterTrdReq()
+		case SHW_MY_ORDERS:
+			return screen.toggleMyOrderSelection()
+		case SHW_WATCHLIST:
+			return screen.jumpToActiveWatchRule()
+		case SHW_BATTLE_HISTORY:
+			return screen.showActiveBattleReport()
+		case FULFILL_BUYITM_TRDREQ_SEL_ITEM:
+			screen.RunFulfillItemBuyTrdReq()
+		case FULFILL_BUYCHR_TRDREQ_SEL_CHR:
+			screen.RunFulfillCharacterBuyTrdReq()
+		case CR8_BARTER_SEL_OFFER_ITEMS,
+			CR8_BARTER_SEL_WANT_ITEMS:
+			return screen.toggleBarterSelection()
 		case CR8_SELLITM_TRDREQ_SEL_ITEM:
-			userItems := screen.user.InventoryItems()
-			if len(userItems) <= screen.activeLine || screen.activeLine < 0 {
+			item, ok := screen.selectedEntry().(loud.Item)
+			if !ok {
 				return false
 			}
-			screen.activeItem = userItems[screen.activeLine]
-			screen.scrStatus = CR8_SELLITM_TRDREQ_ENT_PYLVAL
+			screen.activeItem = item
+			if screen.offerUnlock(screen.activeItem.ID, formatItem(screen.activeItem)) {
+				return true
+			}
+			screen.SetScreenStatus(CR8_SELLITM_TRDREQ_ENT_PYLVAL)
 			screen.inputText = ""
 			screen.Render()
 		case CR8_BUYITM_TRDREQ_SEL_ITEM:
-			if len(loud.WorldItemSpecs) <= screen.activeLine || screen.activeLine < 0 {
+			itSpec, ok := screen.selectedEntry().(loud.ItemSpec)
+			if !ok {
 				return false
 			}
-			screen.activeItSpec = loud.WorldItemSpecs[screen.activeLine]
-			screen.scrStatus = CR8_BUYITM_TRDREQ_ENT_PYLVAL
+			screen.activeItSpec = itSpec
+			screen.SetScreenStatus(CR8_BUYITM_TRDREQ_ENT_PYLVAL)
 			screen.inputText = ""
 			screen.Render()
 		case CR8_SELLCHR_TRDREQ_SEL_CHR:
-			userCharacters := screen.user.InventoryCharacters()
-			if len(userCharacters) <= screen.activeLine || screen.activeLine < 0 {
+			character, ok := screen.selectedEntry().(loud.Character)
+			if !ok {
 				return false
 			}
-			screen.activeCharacter = userCharacters[screen.activeLine]
-			screen.scrStatus = CR8_SELLCHR_TRDREQ_ENT_PYLVAL
+			screen.activeCharacter = character
+			if screen.offerUnlock(screen.activeCharacter.ID, formatCharacter(screen.activeCharacter)) {
+				return true
+			}
+			screen.SetScreenStatus(CR8_SELLCHR_TRDREQ_ENT_PYLVAL)
 			screen.inputText = ""
 			screen.Render()
 		case CR8_BUYCHR_TRDREQ_SEL_CHR:
-			if len(loud.WorldCharacterSpecs) <= screen.activeLine || screen.activeLine < 0 {
+			chSpec, ok := screen.selectedEntry().(loud.CharacterSpec)
+			if !ok {
 				return false
 			}
-			screen.activeChSpec = loud.WorldCharacterSpecs[screen.activeLine]
-			screen.scrStatus = CR8_BUYCHR_TRDREQ_ENT_PYLVAL
+			screen.activeChSpec = chSpec
+			screen.SetScreenStatus(CR8_BUYCHR_TRDREQ_ENT_PYLVAL)
 			screen.inputText = ""
 			screen.Render()
 		case SEL_ACTIVE_CHAR:
-			characters := screen.user.InventoryCharacters()
-			if len(characters) <= screen.activeLine || screen.activeLine < 0 {
+			character, ok := screen.selectedEntry().(loud.Character)
+			if !ok {
 				return false
 			}
-			screen.activeCharacter = characters[screen.activeLine]
-			screen.RunActiveCharacterSelect(screen.activeLine)
+			screen.activeCharacter = character
+			screen.RunActiveCharacterSelect(character.ID)
 		case SEL_ACTIVE_WEAPON:
-			items := screen.user.InventorySwords()
-			if len(items) <= screen.activeLine || screen.activeLine < 0 {
+			item, ok := screen.selectedEntry().(loud.Item)
+			if !ok {
+				return false
+			}
+			screen.activeItem = item
+			screen.RunActiveWeaponSelect(item.ID)
+		case SEL_ACTIVE_ARMOR:
+			item, ok := screen.selectedEntry().(loud.Item)
+			if !ok {
 				return false
 			}
-			screen.activeItem = items[screen.activeLine]
-			screen.RunActiveWeaponSelect(screen.activeLine)
+			screen.activeItem = item
+			screen.RunActiveArmorSelect(item.ID)
 		case SEL_RENAME_CHAR:
-			characters := screen.user.InventoryCharacters()
-			if len(characters) <= screen.activeLine || screen.activeLine < 0 {
+			character, ok := screen.selectedEntry().(loud.Character)
+			if !ok {
 				return false
 			}
-			screen.activeCharacter = characters[screen.activeLine]
-			screen.scrStatus = RENAME_CHAR_ENT_NEWNAME
+			screen.activeCharacter = character
+			screen.SetScreenStatus(RENAME_CHAR_ENT_NEWNAME)
 			screen.inputText = ""
 			screen.Render()
 		case SEL_BUYITM:
-			items := loud.ShopItems
-			if len(items) <= screen.activeLine || screen.activeLine < 0 {
+			item, ok := screen.selectedEntry().(loud.Item)
+			if !ok {
 				return false
 			}
-			screen.activeItem = items[screen.activeLine]
+			screen.activeItem = item
 			screen.RunActiveItemBuy()
 		case SEL_BUYCHR:
-			characters := loud.ShopCharacters
-			if len(characters) <= screen.activeLine || screen.activeLine < 0 {
+			character, ok := screen.selectedEntry().(loud.Character)
+			if !ok {
 				return false
 			}
-			screen.activeCharacter = characters[screen.activeLine]
+			screen.activeCharacter = character
 			screen.RunActiveCharacterBuy()
 		case SEL_SELLITM:
-			items := screen.user.InventoryItems()
-			if len(items) <= screen.activeLine || screen.activeLine < 0 {
+			item, ok := screen.selectedEntry().(loud.Item)
+			if !ok {
 				return false
 			}
-			screen.activeItem = items[screen.activeLine]
+			screen.activeItem = item
 			screen.RunActiveItemSell()
 		case SEL_UPGITM:
-			items := screen.user.InventoryUpgradableItems()
-			if len(items) <= screen.activeLine || screen.activeLine < 0 {
+			item, ok := screen.selectedEntry().(loud.Item)
+			if !ok {
 				return false
 			}
-			screen.activeItem = items[screen.activeLine]
+			screen.activeItem = item
 			screen.RunActiveItemUpgrade()
 		default:
 			screen.MoveToNextStep()
@@ -697,13 +701,21 @@ func (screen *GameScreen) HandleTypingModeInputKeys(input termbox.Event) bool {
 		switch screen.scrStatus {
 		case RENAME_CHAR_ENT_NEWNAME:
 			screen.RunCharacterRename(screen.inputText)
+		case FILTER_TRDREQ_ENT_QUERY:
+			screen.applyTrdReqFilter(screen.inputText)
+		case JUMP_ENT_NUMBER:
+			screen.applySelectJump(screen.inputText)
+		case WATCH_ENT_RULE:
+			screen.addWatchRule(screen.inputText)
+		case CR8_REPEAT_HUNT_ENT_RULE:
+			screen.RunRepeatHunt(screen.inputText)
 		case CR8_BUY_LOUD_TRDREQ_ENT_LUDVAL:
-			screen.scrStatus = CR8_BUY_LOUD_TRDREQ_ENT_PYLVAL
+			screen.SetScreenStatus(CR8_BUY_LOUD_TRDREQ_ENT_PYLVAL)
 			screen.loudEnterValue = screen.inputText
 			screen.inputText = ""
 			screen.Render()
 		case CR8_BUY_LOUD_TRDREQ_ENT_PYLVAL:
-			screen.scrStatus = W8_BUY_LOUD_TRDREQ_CREATION
+			screen.SetScreenStatus(W8_BUY_LOUD_TRDREQ_CREATION)
 			screen.pylonEnterValue = screen.inputText
 			screen.SetInputTextAndRender("")
 			txhash, err := loud.CreateBuyLoudTrdReq(screen.user, screen.loudEnterValue, screen.pylonEnterValue)
@@ -718,12 +730,12 @@ func (screen *GameScreen) HandleTypingModeInputKeys(input termbox.Event) bool {
 				})
 			}
 		case CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL:
-			screen.scrStatus = CR8_SELL_LOUD_TRDREQ_ENT_PYLVAL
+			screen.SetScreenStatus(CR8_SELL_LOUD_TRDREQ_ENT_PYLVAL)
 			screen.Render()
 			screen.loudEnterValue = screen.inputText
 			screen.inputText = ""
 		case CR8_SELL_LOUD_TRDREQ_ENT_PYLVAL:
-			screen.scrStatus = W8_SELL_LOUD_TRDREQ_CREATION
+			screen.SetScreenStatus(W8_SELL_LOUD_TRDREQ_CREATION)
 			screen.Render()
 			screen.pylonEnterValue = screen.inputText
 			screen.SetInputTextAndRender("")
@@ -739,8 +751,86 @@ func (screen *GameScreen) HandleTypingModeInputKeys(input termbox.Event) bool {
 					screen.SetScreenStatusAndRefresh(RSLT_SELL_LOUD_TRDREQ_CREATION)
 				})
 			}
+		case CR8_MKTORD_ENT_LUDVAL:
+			amount, err := strconv.Atoi(screen.inputText)
+			if err != nil || amount <= 0 {
+				screen.actionText = loud.Localize("Please enter gold amount as a positive integer")
+				screen.Render()
+				return true
+			}
+			if !screen.marketOrder.IsBuy && amount > screen.user.GetGold() {
+				screen.actionText = loud.Localize("You don't have enough gold to sell")
+				screen.Render()
+				return true
+			}
+			screen.loudEnterValue = screen.inputText
+			screen.SetScreenStatus(CR8_MKTORD_ENT_PRICE)
+			screen.SetInputTextAndRender("")
+		case CR8_MKTORD_ENT_PRICE:
+			limitPrice, err := strconv.ParseFloat(screen.inputText, 64)
+			if err != nil || limitPrice <= 0 {
+				screen.actionText = loud.Localize("Please enter price as a positive number")
+				screen.Render()
+				return true
+			}
+			amount, _ := strconv.Atoi(screen.loudEnterValue)
+			requests := loud.SellTrdReqs
+			if !screen.marketOrder.IsBuy {
+				requests = loud.BuyTrdReqs
+			}
+			plan := loud.PlanMarketOrder(requests, screen.marketOrder.IsBuy, amount, limitPrice, screen.user.GetPylonAmount())
+			if len(plan.Fills) == 0 {
+				screen.actionText = loud.Localize("There are no trade requests matching your market order")
+				screen.Render()
+				return true
+			}
+			screen.marketOrder = plan
+			screen.pylonEnterValue = screen.inputText
+			screen.SetScreenStatus(CONFIRM_MKTORD)
+			screen.SetInputTextAndRender("")
+		case CR8_BARTER_ENT_OFFER_PYLVAL:
+			offerPylon, err := parseOptionalCoinValue(screen.inputText)
+			if err != nil {
+				screen.actionText = loud.Localize("Please enter pylon amount as an integer")
+				screen.Render()
+				return true
+			}
+			screen.barterOfferPylon = offerPylon
+			screen.SetScreenStatus(CR8_BARTER_ENT_OFFER_GOLDVAL)
+			screen.SetInputTextAndRender("")
+		case CR8_BARTER_ENT_OFFER_GOLDVAL:
+			offerGold, err := parseOptionalCoinValue(screen.inputText)
+			if err != nil {
+				screen.actionText = loud.Localize("Please enter gold amount as an integer")
+				screen.Render()
+				return true
+			}
+			screen.barterOfferGold = offerGold
+			screen.activeLine = 0
+			screen.SetScreenStatus(CR8_BARTER_SEL_WANT_ITEMS)
+			screen.SetInputTextAndRender("")
+		case CR8_BARTER_ENT_WANT_PYLVAL:
+			wantPylon, err := parseOptionalCoinValue(screen.inputText)
+			if err != nil {
+				screen.actionText = loud.Localize("Please enter pylon amount as an integer")
+				screen.Render()
+				return true
+			}
+			screen.barterWantPylon = wantPylon
+			screen.SetScreenStatus(CR8_BARTER_ENT_WANT_GOLDVAL)
+			screen.SetInputTextAndRender("")
+		case CR8_BARTER_ENT_WANT_GOLDVAL:
+			wantGold, err := parseOptionalCoinValue(screen.inputText)
+			if err != nil {
+				screen.actionText = loud.Localize("Please enter gold amount as an integer")
+				screen.Render()
+				return true
+			}
+			screen.barterWantGold = wantGold
+			screen.inputText = ""
+			screen.RunBarterTrdReqCreation()
 		case CR8_SELLITM_TRDREQ_ENT_PYLVAL:
-			screen.scrStatus = W8_SELLITM_TRDREQ_CREATION
+			screen.SetScreenStatus(W8_SELLITM_TRDREQ_CREATION)
 			screen.pylonEnterValue = screen.inputText
 			screen.SetInputTextAndRender("")
 			txhash, err := loud.CreateSellItemTrdReq(screen.user, screen.activeItem, screen.pylonEnterValue)
@@ -755,7 +845,7 @@ func (screen *GameScreen) HandleTypingModeInputKeys(input termbox.Event) bool {
 				})
 			}
 		case CR8_BUYITM_TRDREQ_ENT_PYLVAL:
-			screen.scrStatus = W8_BUYITM_TRDREQ_CREATION
+			screen.SetScreenStatus(W8_BUYITM_TRDREQ_CREATION)
 			screen.pylonEnterValue = screen.inputText
 			screen.SetInputTextAndRender("")
 			txhash, err := loud.CreateBuyItemTrdReq(screen.user, screen.activeItSpec, screen.pylonEnterValue)
@@ -771,7 +861,7 @@ func (screen *GameScreen) HandleTypingModeInputKeys(input termbox.Event) bool {
 			}
 
 		case CR8_SELLCHR_TRDREQ_ENT_PYLVAL:
-			screen.scrStatus = W8_SELLCHR_TRDREQ_CREATION
+			screen.SetScreenStatus(W8_SELLCHR_TRDREQ_CREATION)
 			screen.pylonEnterValue = screen.inputText
 			screen.SetInputTextAndRender("")
 			txhash, err := loud.CreateSellCharacterTrdReq(screen.user, screen.activeCharacter, screen.pylonEnterValue)
@@ -786,7 +876,7 @@ func (screen *GameScreen) HandleTypingModeInputKeys(input termbox.Event) bool {
 				})
 			}
 		case CR8_BUYCHR_TRDREQ_ENT_PYLVAL:
-			screen.scrStatus = W8_BUYCHR_TRDREQ_CREATION
+			screen.SetScreenStatus(W8_BUYCHR_TRDREQ_CREATION)
 			screen.pylonEnterValue = screen.inputText
 			screen.SetInputTextAndRender("")
 			txhash, err := loud.CreateBuyCharacterTrdReq(screen.user, screen.activeChSpec, screen.pylonEnterValue)
@@ -806,8 +896,16 @@ func (screen *GameScreen) HandleTypingModeInputKeys(input termbox.Event) bool {
 		return true
 	default:
 		iChar := string(input.Ch)
+		isQueryInput := screen.scrStatus == FILTER_TRDREQ_ENT_QUERY || screen.scrStatus == WATCH_ENT_RULE || screen.scrStatus == CR8_REPEAT_HUNT_ENT_RULE
+		if input.Key == termbox.KeySpace && isQueryInput {
+			// query words are separated by space
+			iChar = " "
+		}
 		Key := strings.ToUpper(iChar)
-		if screen.scrStatus == RENAME_CHAR_ENT_NEWNAME {
+		if screen.scrStatus == RENAME_CHAR_ENT_NEWNAME || isQueryInput {
+			screen.SetInputTextAndRender(screen.inputText + iChar)
+		} else if screen.scrStatus == CR8_MKTORD_ENT_PRICE && iChar == "." && !strings.Contains(screen.inputText, ".") {
+			// price can be decimal value
 			screen.SetInputTextAndRender(screen.inputText + iChar)
 		} else if _, err := strconv.Atoi(Key); err == nil {
 			// If user entered number, just use it
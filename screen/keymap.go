@@ -0,0 +1,349 @@
+package screen
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+	"github.com/Pylons-tech/LOUD/log"
+	"github.com/nsf/termbox-go"
+)
+
+// key actions, names are used on app.keymap section of config file
+const (
+	ACT_GO_HOME           = "go_home"
+	ACT_GO_FOREST         = "go_forest"
+	ACT_GO_SHOP           = "go_shop"
+	ACT_GO_PYLONS_CENTRAL = "go_pylons_central"
+	ACT_GO_SETTINGS       = "go_settings"
+	ACT_GO_DEVELOP        = "go_develop"
+	ACT_CREATE_COOKBOOK   = "create_cookbook"
+	ACT_SWITCH_USER       = "switch_user"
+	ACT_GET_PYLONS        = "get_initial_pylons"
+	ACT_DEV_ITEMS         = "get_dev_items"
+	ACT_COPY_TXHASH       = "copy_last_txhash"
+	ACT_COPY_ADDRESS      = "copy_address"
+	ACT_REFRESH           = "refresh"
+	ACT_GO_WATCH_ALERT    = "go_to_watch_alert"
+	ACT_CREATE            = "create"
+	ACT_SWITCH_MODE       = "switch_mode"
+	ACT_MARKET_ORDER      = "market_order"
+	ACT_FILTER            = "filter"
+	ACT_SORT              = "sort"
+	ACT_GO_ON             = "go_on"
+	ACT_REPEAT_HUNT       = "repeat_hunt"
+	ACT_STOP_REPEAT_HUNT  = "stop_repeat_hunt"
+	ACT_USE_CONSUMABLE    = "use_consumable"
+	ACT_PREV_PAGE         = "prev_page"
+	ACT_NEXT_PAGE         = "next_page"
+	ACT_JUMP_TO_NUMBER    = "jump_to_number"
+	ACT_SHEET_SCROLL_UP   = "sheet_scroll_up"
+	ACT_SHEET_SCROLL_DOWN = "sheet_scroll_down"
+	ACT_SWITCH_PANE       = "switch_pane"
+	ACT_UP                = "up"
+	ACT_DOWN              = "down"
+	ACT_SELECT            = "select"
+	ACT_BACK              = "back"
+	ACT_FORWARD           = "forward"
+	ACT_EXIT              = "exit"
+	ACT_NUMBER_PREFIX     = "number_"
+)
+
+// defaultKeys is the key specs of each action when config doesn't bind it
+var defaultKeys = map[string][]string{
+	ACT_GO_HOME:           {"h"},
+	ACT_GO_FOREST:         {"f"},
+	ACT_GO_SHOP:           {"s"},
+	ACT_GO_PYLONS_CENTRAL: {"c"},
+	ACT_GO_SETTINGS:       {"t"},
+	ACT_GO_DEVELOP:        {"d"},
+	ACT_CREATE_COOKBOOK:   {"j"},
+	ACT_SWITCH_USER:       {"z"},
+	ACT_GET_PYLONS:        {"y"},
+	ACT_DEV_ITEMS:         {"b"},
+	ACT_COPY_TXHASH:       {"l"},
+	ACT_COPY_ADDRESS:      {"m"},
+	ACT_REFRESH:           {"e"},
+	ACT_GO_WATCH_ALERT:    {"g"},
+	ACT_CREATE:            {"r"},
+	ACT_SWITCH_MODE:       {"v"},
+	ACT_MARKET_ORDER:      {"a"},
+	ACT_FILTER:            {"/"},
+	ACT_SORT:              {"x"},
+	ACT_GO_ON:             {"o"},
+	ACT_REPEAT_HUNT:       {"n"},
+	ACT_STOP_REPEAT_HUNT:  {"q"},
+	ACT_USE_CONSUMABLE:    {"p"},
+	ACT_PREV_PAGE:         {"[", "pgup"},
+	ACT_NEXT_PAGE:         {"]", "pgdn"},
+	ACT_JUMP_TO_NUMBER:    {"#"},
+	ACT_SHEET_SCROLL_UP:   {"<"},
+	ACT_SHEET_SCROLL_DOWN: {">"},
+	ACT_SWITCH_PANE:       {"tab"},
+	ACT_UP:                {"up"},
+	ACT_DOWN:              {"down"},
+	ACT_SELECT:            {"enter"},
+	ACT_BACK:              {"backspace"},
+	ACT_FORWARD:           {"right"},
+	ACT_EXIT:              {"esc"},
+}
+
+func init() {
+	for n := 0; n < 10; n++ {
+		defaultKeys[NumberAction(n)] = []string{fmt.Sprintf("%d", n)}
+	}
+}
+
+// NumberAction is the action of picking n) entry of a list
+func NumberAction(n int) string {
+	return fmt.Sprintf("%s%d", ACT_NUMBER_PREFIX, n%10)
+}
+
+var namedKeys = map[string]termbox.Key{
+	"enter":     termbox.KeyEnter,
+	"esc":       termbox.KeyEsc,
+	"backspace": termbox.KeyBackspace2,
+	"tab":       termbox.KeyTab,
+	"space":     termbox.KeySpace,
+	"up":        termbox.KeyArrowUp,
+	"down":      termbox.KeyArrowDown,
+	"left":      termbox.KeyArrowLeft,
+	"right":     termbox.KeyArrowRight,
+	"pgup":      termbox.KeyPgup,
+	"pgdn":      termbox.KeyPgdn,
+	"home":      termbox.KeyHome,
+	"end":       termbox.KeyEnd,
+	"insert":    termbox.KeyInsert,
+	"delete":    termbox.KeyDelete,
+	"f1":        termbox.KeyF1,
+	"f2":        termbox.KeyF2,
+	"f3":        termbox.KeyF3,
+	"f4":        termbox.KeyF4,
+	"f5":        termbox.KeyF5,
+	"f6":        termbox.KeyF6,
+	"f7":        termbox.KeyF7,
+	"f8":        termbox.KeyF8,
+	"f9":        termbox.KeyF9,
+	"f10":       termbox.KeyF10,
+	"f11":       termbox.KeyF11,
+	"f12":       termbox.KeyF12,
+}
+
+var namedKeyLabels = map[string]string{
+	"enter":     "↵",
+	"esc":       "Esc",
+	"backspace": "⌫",
+	"tab":       "Tab",
+	"space":     "Space",
+	"up":        "↑",
+	"down":      "↓",
+	"left":      "←",
+	"right":     "→",
+	"pgup":      "PgUp",
+	"pgdn":      "PgDn",
+	"home":      "Home",
+	"end":       "End",
+	"insert":    "Ins",
+	"delete":    "Del",
+}
+
+// keySpec returns spec of a key event, eg. "h", "enter" or "ctrl+r", empty when the key can't be bound
+func keySpec(ev termbox.Event) string {
+	if ev.Type != termbox.EventKey {
+		return ""
+	}
+	if ev.Ch != 0 {
+		return strings.ToLower(string(ev.Ch))
+	}
+	if ev.Key == termbox.KeyBackspace {
+		return "backspace"
+	}
+	for name, key := range namedKeys {
+		if key == ev.Key {
+			return name
+		}
+	}
+	if ev.Key >= termbox.KeyCtrlA && ev.Key <= termbox.KeyCtrlZ {
+		return fmt.Sprintf("ctrl+%c", 'a'+rune(ev.Key-termbox.KeyCtrlA))
+	}
+	return ""
+}
+
+// keySpecEvent returns the key event of a spec
+func keySpecEvent(spec string) (termbox.Event, error) {
+	ev := termbox.Event{Type: termbox.EventKey}
+	if key, ok := namedKeys[spec]; ok {
+		ev.Key = key
+		return ev, nil
+	}
+	if runes := []rune(spec); len(runes) == 1 {
+		ev.Ch = runes[0]
+		return ev, nil
+	}
+	if strings.HasPrefix(spec, "ctrl+") {
+		if runes := []rune(strings.TrimPrefix(spec, "ctrl+")); len(runes) == 1 && runes[0] >= 'a' && runes[0] <= 'z' {
+			ev.Key = termbox.KeyCtrlA + termbox.Key(runes[0]-'a')
+			return ev, nil
+		}
+	}
+	return ev, errors.New("unknown key")
+}
+
+// parseKeySpec normalizes a configured key, eg. "H" to "h" and "ctrl+m" to "enter" as terminals can't tell them apart
+func parseKeySpec(spec string) (string, error) {
+	spec = strings.TrimSpace(spec)
+	if runes := []rune(spec); len(runes) != 1 {
+		spec = strings.ToLower(spec)
+	}
+	ev, err := keySpecEvent(spec)
+	if err != nil {
+		return "", err
+	}
+	return keySpec(ev), nil
+}
+
+// keySpecLabel is how a key is shown on command hints
+func keySpecLabel(spec string) string {
+	if label, ok := namedKeyLabels[spec]; ok {
+		return label
+	}
+	if strings.HasPrefix(spec, "ctrl+") {
+		return "Ctrl+" + strings.ToUpper(strings.TrimPrefix(spec, "ctrl+"))
+	}
+	return strings.ToUpper(spec)
+}
+
+// Keymap binds keys to actions
+type Keymap struct {
+	actions map[string]string   // key spec => action
+	keys    map[string][]string // action => key specs, first one is shown on hints
+}
+
+// NewKeymap binds default keys overridden by config, eg. {"go_pylons_central": "m", "refresh": "ctrl+r,e"}
+// A key bound on config is taken from the action it was bound to by default.
+func NewKeymap(config map[string]string) Keymap {
+	km := Keymap{
+		actions: map[string]string{},
+		keys:    map[string][]string{},
+	}
+	for action, specs := range defaultKeys {
+		if _, ok := config[action]; ok {
+			continue
+		}
+		for _, spec := range specs {
+			km.bind(action, spec)
+		}
+	}
+	actions := []string{}
+	for action := range config {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		if _, ok := defaultKeys[action]; !ok {
+			log.Println("keymap: unknown action", action)
+			continue
+		}
+		rawSpecs := strings.Split(config[action], ",")
+		if strings.TrimSpace(config[action]) == "," {
+			rawSpecs = []string{","}
+		}
+		for _, rawSpec := range rawSpecs {
+			spec, err := parseKeySpec(rawSpec)
+			if err != nil {
+				log.Println("keymap:", err, rawSpec, "for", action)
+				continue
+			}
+			km.bind(action, spec)
+		}
+	}
+	return km
+}
+
+func (km Keymap) bind(action, spec string) {
+	if prev, ok := km.actions[spec]; ok {
+		prevKeys := []string{}
+		for _, s := range km.keys[prev] {
+			if s != spec {
+				prevKeys = append(prevKeys, s)
+			}
+		}
+		km.keys[prev] = prevKeys
+	}
+	km.actions[spec] = action
+	km.keys[action] = append(km.keys[action], spec)
+}
+
+// Action returns the action bound to the key event, empty when it's not bound
+func (km Keymap) Action(ev termbox.Event) string {
+	return km.actions[keySpec(ev)]
+}
+
+// Number returns n of number action bound to the key event, -1 when it's not a number action
+func (km Keymap) Number(ev termbox.Event) int {
+	action := km.Action(ev)
+	if !strings.HasPrefix(action, ACT_NUMBER_PREFIX) {
+		return -1
+	}
+	return int(action[len(ACT_NUMBER_PREFIX)] - '0')
+}
+
+// Event returns the key event of an action, false when it's unbound
+func (km Keymap) Event(action string) (termbox.Event, bool) {
+	if len(km.keys[action]) == 0 {
+		return termbox.Event{}, false
+	}
+	ev, err := keySpecEvent(km.keys[action][0])
+	return ev, err == nil
+}
+
+// Label returns the key label of an action, empty when it's unbound
+func (km Keymap) Label(action string) string {
+	if len(km.keys[action]) == 0 {
+		return ""
+	}
+	return keySpecLabel(km.keys[action][0])
+}
+
+// Mark is the keys of the actions shown after a hint, eg. "( ↵ )", empty when none is bound
+func (km Keymap) Mark(actions ...string) string {
+	labels := []string{}
+	for _, action := range actions {
+		if keyLabel := km.Label(action); keyLabel != "" {
+			labels = append(labels, keyLabel)
+		}
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("( %s )", strings.Join(labels, " "))
+}
+
+// Hint is a command hint with keys of the actions, eg. "Select ( ↵ )"
+func (km Keymap) Hint(label string, actions ...string) string {
+	if mark := km.Mark(actions...); mark != "" {
+		return loud.Localize(label) + " " + mark
+	}
+	return loud.Localize(label)
+}
+
+// HintLine is a command line of Hint, a click on it runs the last bound action
+func (km Keymap) HintLine(label string, actions ...string) TextLine {
+	line := TextLine{content: km.Hint(label, actions...)}
+	for _, action := range actions {
+		if len(km.keys[action]) > 0 {
+			line.action = action
+		}
+	}
+	return line
+}
+
+// NumberedLine is "n) text" line of the key of number action n
+func (km Keymap) NumberedLine(n int, text string) TextLine {
+	return TextLine{
+		content: fmt.Sprintf("%s) %s", km.Label(NumberAction(n)), text),
+		action:  NumberAction(n),
+	}
+}
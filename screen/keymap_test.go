@@ -0,0 +1,146 @@
+package screen
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nsf/termbox-go"
+)
+
+func TestParseKeySpec(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{"h", "h", false},
+		{"H", "h", false},
+		{" e ", "e", false},
+		{",", ",", false},
+		{"Enter", "enter", false},
+		{"PgDn", "pgdn", false},
+		{"f5", "f5", false},
+		{"ctrl+r", "ctrl+r", false},
+		{"Ctrl+R", "ctrl+r", false},
+		// terminals send the same code for these ctrl keys and named keys
+		{"ctrl+h", "backspace", false},
+		{"ctrl+i", "tab", false},
+		{"ctrl+m", "enter", false},
+		{"", "", true},
+		{"ctrl+1", "", true},
+		{"ctrl+rr", "", true},
+		{"shift+a", "", true},
+		{"hello", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parseKeySpec(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseKeySpec(%q) = %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewKeymap(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]string
+		keys   map[string][]string // action => key specs, nil is unbound
+	}{
+		{"defaults", nil, map[string][]string{
+			ACT_GO_PYLONS_CENTRAL: {"c"},
+			ACT_COPY_ADDRESS:      {"m"},
+			ACT_NEXT_PAGE:         {"]", "pgdn"},
+			NumberAction(3):       {"3"},
+		}},
+		{"rebind steals default key", map[string]string{ACT_GO_PYLONS_CENTRAL: "m"}, map[string][]string{
+			ACT_GO_PYLONS_CENTRAL: {"m"},
+			ACT_COPY_ADDRESS:      nil,
+		}},
+		{"multiple keys, first is shown", map[string]string{ACT_REFRESH: "ctrl+r,e"}, map[string][]string{
+			ACT_REFRESH: {"ctrl+r", "e"},
+		}},
+		{"stealing one of several keys", map[string]string{ACT_GO_ON: "pgdn"}, map[string][]string{
+			ACT_GO_ON:     {"pgdn"},
+			ACT_NEXT_PAGE: {"]"},
+		}},
+		{"ctrl+m is enter", map[string]string{ACT_GO_ON: "ctrl+m"}, map[string][]string{
+			ACT_GO_ON:  {"enter"},
+			ACT_SELECT: nil,
+		}},
+		{"ctrl+h is backspace", map[string]string{ACT_FORWARD: "ctrl+h"}, map[string][]string{
+			ACT_FORWARD: {"backspace"},
+			ACT_BACK:    nil,
+		}},
+		{"ctrl+i is tab", map[string]string{ACT_SORT: "ctrl+i"}, map[string][]string{
+			ACT_SORT:        {"tab"},
+			ACT_SWITCH_PANE: nil,
+		}},
+		{"comma key", map[string]string{ACT_FILTER: ","}, map[string][]string{
+			ACT_FILTER: {","},
+		}},
+		{"bad specs are skipped", map[string]string{ACT_FILTER: "hello,ctrl+f"}, map[string][]string{
+			ACT_FILTER: {"ctrl+f"},
+		}},
+		{"unknown actions are ignored", map[string]string{"fly": "h"}, map[string][]string{
+			ACT_GO_HOME: {"h"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km := NewKeymap(tt.config)
+			for action, want := range tt.keys {
+				if got := km.keys[action]; !(len(got) == 0 && len(want) == 0) && !reflect.DeepEqual(got, want) {
+					t.Errorf("keys of %s = %v, want %v", action, got, want)
+				}
+				for _, spec := range want {
+					if got := km.actions[spec]; got != action {
+						t.Errorf("action of %q = %q, want %q", spec, got, action)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestKeymapEvents(t *testing.T) {
+	km := NewKeymap(map[string]string{ACT_REFRESH: "ctrl+r,e"})
+	if got := km.Action(termbox.Event{Type: termbox.EventKey, Key: termbox.KeyCtrlR}); got != ACT_REFRESH {
+		t.Errorf("ctrl+r action = %q, want %q", got, ACT_REFRESH)
+	}
+	if got := km.Action(termbox.Event{Type: termbox.EventKey, Ch: 'E'}); got != ACT_REFRESH {
+		t.Errorf("E action = %q, want %q", got, ACT_REFRESH)
+	}
+	if got := km.Label(ACT_REFRESH); got != "Ctrl+R" {
+		t.Errorf("refresh label = %q, want Ctrl+R", got)
+	}
+	if got := km.Number(termbox.Event{Type: termbox.EventKey, Ch: '7'}); got != 7 {
+		t.Errorf("number of 7 = %d, want 7", got)
+	}
+	if got := km.Number(termbox.Event{Type: termbox.EventKey, Ch: 'h'}); got != -1 {
+		t.Errorf("number of h = %d, want -1", got)
+	}
+	if ev, ok := km.Event(ACT_SELECT); !ok || ev.Key != termbox.KeyEnter {
+		t.Errorf("select event = %v, %v, want enter", ev, ok)
+	}
+	if got := km.Mark(ACT_PREV_PAGE, ACT_NEXT_PAGE); got != "( [ ] )" {
+		t.Errorf("page mark = %q", got)
+	}
+}
+
+func TestKeymapHintLine(t *testing.T) {
+	km := NewKeymap(map[string]string{ACT_NEXT_PAGE: ""})
+	if line := km.HintLine(PAGE_CMD, ACT_PREV_PAGE, ACT_NEXT_PAGE); line.action != ACT_PREV_PAGE || line.content != "Page ( [ )" {
+		t.Errorf("hint line = %+v, want last bound action", line)
+	}
+	if line := km.HintLine(GO_BACK_CMD, ACT_NEXT_PAGE); line.action != "" || line.content != "Go back" {
+		t.Errorf("unbound hint line = %+v, want no action", line)
+	}
+	if line := km.NumberedLine(2, "Sword"); line.action != NumberAction(2) || line.content != "2) Sword" {
+		t.Errorf("numbered line = %+v", line)
+	}
+}
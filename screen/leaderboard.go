@@ -0,0 +1,135 @@
+package screen
+
+import (
+	"fmt"
+	"time"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+	"github.com/Pylons-tech/LOUD/log"
+)
+
+// loadLeaderboard caches the stored leaderboard sorted by current sort key and the known usernames on the screen
+func (screen *GameScreen) loadLeaderboard() loud.Leaderboard {
+	leaderboard := screen.user.GetLeaderboard()
+	screen.setLeaderboard(leaderboard, screen.user.GetKnownUsernames())
+	return leaderboard
+}
+
+// setLeaderboard hands a leaderboard to the render loop, sorted by current sort key
+func (screen *GameScreen) setLeaderboard(leaderboard loud.Leaderboard, usernames map[string]string) {
+	screen.renderMu.Lock()
+	defer screen.renderMu.Unlock()
+	leaderboard.Entries = sortedLeaderboardEntries(leaderboard.Entries, screen.leaderboardSort)
+	screen.leaderboard = leaderboard
+	screen.knownUsernames = usernames
+}
+
+// sortedLeaderboardEntries returns a sorted copy, the cached entries may be drawn while sorting
+func sortedLeaderboardEntries(entries []loud.LeaderboardEntry, sortIdx int) []loud.LeaderboardEntry {
+	sorted := append([]loud.LeaderboardEntry{}, entries...)
+	loud.SortLeaderboard(sorted, loud.LeaderboardSortKeys[sortIdx])
+	return sorted
+}
+
+// refreshLeaderboard scans characters of all accounts in background and caches the result
+func (screen *GameScreen) refreshLeaderboard() bool {
+	screen.renderMu.Lock()
+	scanning := screen.leaderboardScanning
+	screen.leaderboardScanning = true
+	screen.renderMu.Unlock()
+	if scanning {
+		return true
+	}
+	screen.Render()
+	go func() {
+		log.Println("started leaderboard scan")
+		leaderboard, err := loud.ScanLeaderboard(screen.user)
+		log.Println("ended leaderboard scan", len(leaderboard.Entries))
+		if err == nil {
+			screen.user.SetLeaderboard(leaderboard)
+			screen.setLeaderboard(leaderboard, screen.user.GetKnownUsernames())
+		}
+		screen.renderMu.Lock()
+		if err != nil {
+			screen.actionText = loud.Sprintf("Leaderboard scan failed: %s", err.Error())
+		}
+		screen.leaderboardScanning = false
+		screen.renderMu.Unlock()
+		screen.Render()
+	}()
+	return true
+}
+
+func (screen *GameScreen) switchLeaderboardSort() bool {
+	screen.renderMu.Lock()
+	screen.leaderboardSort = (screen.leaderboardSort + 1) % len(loud.LeaderboardSortKeys)
+	screen.leaderboard.Entries = sortedLeaderboardEntries(screen.leaderboard.Entries, screen.leaderboardSort)
+	screen.activeLine = 0
+	screen.renderMu.Unlock()
+	screen.Render()
+	return true
+}
+
+func (screen *GameScreen) renderLeaderboardLine(text string, font FontType, isActiveLine bool, width int) string {
+	calcText := tableRow(reportColumns, width, text)
+	onColor := screen.getFont(font)
+	if isActiveLine {
+		onColor = screen.blueBoldFont()
+	}
+	return onColor(fillSpace(calcText, width))
+}
+
+func (screen *GameScreen) renderLeaderboard(width int) ([]string, []string) {
+	leaderboard := screen.leaderboard
+	sortKey := loud.LeaderboardSortKeys[screen.leaderboardSort]
+	entries := leaderboard.Entries
+
+	infoLines := []string{
+		loud.Sprintf("Leaderboard: %d characters ranked by %s", len(entries), loud.Localize(sortKey)),
+	}
+	switch {
+	case screen.leaderboardScanning:
+		infoLines = append(infoLines, loud.Localize("Scanning characters of all accounts..."))
+	case leaderboard.UpdatedAt == 0:
+		infoLines = append(infoLines, loud.Localize("Leaderboard is not scanned yet"))
+	default:
+		infoLines = append(infoLines, loud.Sprintf("Updated at %s (block %d)", time.Unix(leaderboard.UpdatedAt, 0).Format("01-02 15:04"), leaderboard.Block))
+	}
+
+	usernames := screen.knownUsernames
+	myAddress := screen.user.GetAddress()
+	tableLines := []string{}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(reportColumns, width, "╭┬╮"), width)))
+	tableLines = append(tableLines, screen.renderLeaderboardLine(
+		fmt.Sprintf("%4s %-16s %4s %9s %4s %4s %s", "#", loud.Localize("Character"), "Lv", "XP", "🗿", "🐉", loud.Localize("Owner")),
+		REGULAR, false, width))
+	if screen.activeLine >= len(entries) {
+		screen.activeLine = len(entries) - 1
+	}
+	activeLine := screen.activeLine
+	numLines := screen.GetSituationBox().H - 4 - len(infoLines)
+	startLine := activeLine - numLines + 1
+	if startLine < 0 {
+		startLine = 0
+	}
+	endLine := startLine + numLines
+	if endLine > len(entries) {
+		endLine = len(entries)
+	}
+	screen.setTableRows(len(tableLines), startLine, endLine)
+	for li, entry := range entries[startLine:endLine] {
+		owner, ok := usernames[entry.Owner]
+		if !ok {
+			owner = truncateRight(entry.Owner, 16)
+		}
+		font := REGULAR
+		if entry.Owner == myAddress {
+			font = GREEN
+		}
+		ch := entry.Character
+		text := fmt.Sprintf("%4d %-16s %4d %9.0f %4d %4d %s", startLine+li+1, truncateRight(ch.Name, 16), ch.Level, ch.XP, ch.GiantKill, entry.DragonKill(), owner)
+		tableLines = append(tableLines, screen.renderLeaderboardLine(text, font, startLine+li == activeLine, width))
+	}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(reportColumns, width, "╰┴╯"), width)))
+	return infoLines, tableLines
+}
@@ -0,0 +1,68 @@
+package screen
+
+import (
+	"reflect"
+	"testing"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+// leaderboardUser is a user with a stored leaderboard, other methods aren't used by the tests
+type leaderboardUser struct {
+	loud.User
+	leaderboard loud.Leaderboard
+}
+
+func (user *leaderboardUser) GetLeaderboard() loud.Leaderboard {
+	return user.leaderboard
+}
+
+func (user *leaderboardUser) GetKnownUsernames() map[string]string {
+	return map[string]string{"addr1": "alice"}
+}
+
+func leaderboardIDs(entries []loud.LeaderboardEntry) []string {
+	ids := []string{}
+	for _, entry := range entries {
+		ids = append(ids, entry.Character.ID)
+	}
+	return ids
+}
+
+func TestLeaderboardCache(t *testing.T) {
+	stored := loud.Leaderboard{
+		UpdatedAt: 1,
+		Entries: []loud.LeaderboardEntry{
+			{Character: loud.Character{ID: "lowlevel", Level: 1, XP: 500}},
+			{Character: loud.Character{ID: "highlevel", Level: 3, XP: 100}},
+			{Character: loud.Character{ID: "midlevel", Level: 2, XP: 1000}},
+		},
+	}
+	screen := &GameScreen{user: &leaderboardUser{leaderboard: stored}, renderReq: make(chan struct{}, 1)}
+
+	if got := screen.loadLeaderboard(); got.UpdatedAt != 1 {
+		t.Errorf("loaded leaderboard UpdatedAt = %d, want 1", got.UpdatedAt)
+	}
+	if ids, want := leaderboardIDs(screen.leaderboard.Entries), []string{"highlevel", "midlevel", "lowlevel"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("entries by level = %v, want %v", ids, want)
+	}
+	if screen.knownUsernames["addr1"] != "alice" {
+		t.Errorf("known usernames are not cached: %v", screen.knownUsernames)
+	}
+
+	cached := screen.leaderboard.Entries
+	screen.activeLine = 2
+	screen.switchLeaderboardSort()
+	if ids, want := leaderboardIDs(screen.leaderboard.Entries), []string{"midlevel", "lowlevel", "highlevel"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("entries by XP = %v, want %v", ids, want)
+	}
+	if ids, want := leaderboardIDs(cached), []string{"highlevel", "midlevel", "lowlevel"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("switching sort changed entries being drawn: %v", ids)
+	}
+	if screen.activeLine != 0 {
+		t.Errorf("activeLine = %d, want 0 after switching sort", screen.activeLine)
+	}
+	if ids := leaderboardIDs(stored.Entries); ids[0] != "lowlevel" {
+		t.Errorf("stored leaderboard is sorted in place: %v", ids)
+	}
+}
@@ -0,0 +1,14 @@
+package screen
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain runs tests from the repository root where locale files are
+func TestMain(m *testing.M) {
+	if err := os.Chdir(".."); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,125 @@
+package screen
+
+import (
+	"regexp"
+
+	"github.com/nsf/termbox-go"
+)
+
+var ansiCodeRe = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// tableRows is where entry rows of the situation table are drawn
+type tableRows struct {
+	offset int // index of the first entry row in table lines
+	start  int // entry index of the first row
+	end    int
+	y      int // screen line of the first row
+}
+
+func stripANSI(text string) string {
+	return ansiCodeRe.ReplaceAllString(text, "")
+}
+
+// setTableRows is called by table renderers which move active line over their entries
+func (screen *GameScreen) setTableRows(offset, start, end int) {
+	screen.situationRows = &tableRows{offset: offset, start: start, end: end}
+}
+
+// hoverTableLines highlights the row under the mouse unless it's the active line
+func (screen *GameScreen) hoverTableLines(tableLines []string, width int) []string {
+	rows := screen.situationRows
+	if rows == nil {
+		return tableLines
+	}
+	row := screen.hoverY - rows.y
+	lineIdx := rows.offset + row
+	if row < 0 || rows.start+row >= rows.end || rows.start+row == screen.activeLine || lineIdx >= len(tableLines) {
+		return tableLines
+	}
+	hovered := append([]string{}, tableLines...)
+	hovered[lineIdx] = screen.hoverFont()(fillSpace(stripANSI(tableLines[lineIdx]), width))
+	return hovered
+}
+
+// lineEvent returns the key event of the action recorded for a clicked line
+func (screen *GameScreen) lineEvent(action string) (termbox.Event, bool) {
+	switch action {
+	case "":
+		return termbox.Event{}, false
+	case TYPING_ENTER_ACT:
+		return termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEnter}, true
+	case TYPING_ESC_ACT:
+		return termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEsc}, true
+	}
+	return screen.keys.Event(action)
+}
+
+// HandleMouseEvent updates hover and translates clicks and wheel into the key of what's under the mouse
+func (screen *GameScreen) HandleMouseEvent(ev termbox.Event) (termbox.Event, bool) {
+	// termbox positions are 0 based, while boxes are drawn with 1 based cursor positions
+	x, y := ev.MouseX+1, ev.MouseY+1
+	if ev.Mod&termbox.ModMotion != 0 {
+		if screen.hoverY != y {
+			screen.hoverY = y
+			screen.Render()
+		}
+		return termbox.Event{}, false
+	}
+	onSheet := x >= screen.GetCharacterSheetBox().X
+	if screen.IsCompact() {
+		onSheet = screen.pane == PANE_CHARACTER_SHEET
+	}
+	switch ev.Key {
+	case termbox.MouseWheelUp:
+		if onSheet {
+			return screen.keys.Event(ACT_SHEET_SCROLL_UP)
+		}
+		return screen.keys.Event(ACT_UP)
+	case termbox.MouseWheelDown:
+		if onSheet {
+			return screen.keys.Event(ACT_SHEET_SCROLL_DOWN)
+		}
+		return screen.keys.Event(ACT_DOWN)
+	case termbox.MouseLeft:
+		return screen.clickEvent(x, y, onSheet)
+	}
+	return termbox.Event{}, false
+}
+
+func (screen *GameScreen) clickEvent(x, y int, onSheet bool) (termbox.Event, bool) {
+	if y == screen.GetMenuBox().Y {
+		for _, md := range screen.menuDisplays() {
+			if x >= md.start && x < md.start+md.width {
+				return md.key, true
+			}
+		}
+		return termbox.Event{}, false
+	}
+	if screen.IsCompact() && y == screen.GetPaneTabBox().Y {
+		for pane, md := range screen.paneTabDisplays() {
+			if pane < NUM_PANES && x >= md.start && x < md.start+md.width {
+				screen.SetPane(pane)
+			}
+		}
+		return termbox.Event{}, false
+	}
+	if onSheet {
+		if idx := y - screen.GetCharacterSheetBox().Y; idx >= 0 && idx < len(screen.sheetActions) {
+			return screen.lineEvent(screen.sheetActions[idx])
+		}
+		return termbox.Event{}, false
+	}
+	if idx := y - screen.GetCmdBox().Y; idx >= 0 && idx < len(screen.cmdActions) {
+		return screen.lineEvent(screen.cmdActions[idx])
+	}
+	if rows := screen.situationRows; rows != nil && y >= rows.y && rows.start+y-rows.y < rows.end {
+		// clicking a row selects it, clicking the selected row runs it
+		entryIdx := rows.start + y - rows.y
+		if entryIdx == screen.activeLine {
+			return screen.keys.Event(ACT_SELECT)
+		}
+		screen.activeLine = entryIdx
+		screen.Render()
+	}
+	return termbox.Event{}, false
+}
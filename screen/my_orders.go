@@ -0,0 +1,203 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+// myOrderGivesGets returns what I give and what I get when the order is fulfilled
+func (screen *GameScreen) myOrderGivesGets(order loud.MyOrder) ([]string, []string) {
+	pylon := func(amount int) []string {
+		return []string{fmt.Sprintf("%s%d", screen.pylonIcon(), amount)}
+	}
+	gold := func(amount int) []string {
+		return []string{fmt.Sprintf("%s%d", screen.goldIcon(), amount)}
+	}
+	switch request := order.TrdReq.(type) {
+	case loud.TrdReq:
+		if order.Market == loud.MYORD_BUY_GOLD {
+			return pylon(request.Total), gold(request.Amount)
+		}
+		return gold(request.Amount), pylon(request.Total)
+	case loud.ItemBuyTrdReq:
+		return pylon(request.Price), []string{formatItemSpec(request.TItem)}
+	case loud.ItemSellTrdReq:
+		return []string{formatItem(request.TItem)}, pylon(request.Price)
+	case loud.CharacterBuyTrdReq:
+		return pylon(request.Price), []string{formatCharacterSpec(request.TCharacter)}
+	case loud.CharacterSellTrdReq:
+		return []string{formatCharacter(request.TCharacter)}, pylon(request.Price)
+	case loud.BarterTrdReq:
+		return screen.barterOfferLabels(request), screen.barterWantLabels(request)
+	}
+	return []string{}, []string{}
+}
+
+func (screen *GameScreen) myOrderAge(order loud.MyOrder) string {
+	if order.FirstSeenBlock == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", screen.blockHeight-order.FirstSeenBlock)
+}
+
+func (screen *GameScreen) myOrderFillText(fill loud.MyOrderFill) string {
+	_, gets := screen.myOrderGivesGets(fill.Order)
+	return loud.Sprintf("your %s order was fulfilled, you received %s", loud.Localize(fill.Order.Market), strings.Join(gets, " + "))
+}
+
+// pushMyOrderFillToasts shows notifications for my orders fulfilled since previous sync
+func (screen *GameScreen) pushMyOrderFillToasts() {
+	for _, fill := range loud.LastMyOrderFills {
+		screen.toasts = append(screen.toasts, screen.myOrderFillText(fill))
+	}
+}
+
+func (screen *GameScreen) selectedMyOrders() []loud.MyOrder {
+	selected := []loud.MyOrder{}
+	for _, order := range loud.MyOrders() {
+		if screen.myOrderSel[order.ID] {
+			selected = append(selected, order)
+		}
+	}
+	return selected
+}
+
+func (screen *GameScreen) toggleMyOrderSelection() bool {
+	orders := loud.MyOrders()
+	if len(orders) <= screen.activeLine || screen.activeLine < 0 {
+		return false
+	}
+	id := orders[screen.activeLine].ID
+	screen.myOrderSel[id] = !screen.myOrderSel[id]
+	screen.Render()
+	return true
+}
+
+// toggleAllMyOrders selects all orders, or unselects all when all are already selected
+func (screen *GameScreen) toggleAllMyOrders() {
+	orders := loud.MyOrders()
+	selectAll := len(screen.selectedMyOrders()) < len(orders)
+	screen.myOrderSel = make(map[string]bool)
+	if selectAll {
+		for _, order := range orders {
+			screen.myOrderSel[order.ID] = true
+		}
+	}
+	screen.Render()
+}
+
+func (screen *GameScreen) RunCancelMyOrders() {
+	orders := screen.selectedMyOrders()
+	if len(orders) == 0 {
+		screen.actionText = loud.Localize("Please select orders to cancel")
+		screen.Render()
+		return
+	}
+	screen.SetScreenStatusAndRefresh(W8_CANCEL_MYORDS)
+
+	go func() {
+		screen.myOrderCancelRes = loud.CancelMyOrders(screen.user, orders)
+		screen.myOrderSel = make(map[string]bool)
+		screen.txFailReason = ""
+		numCancelled := 0
+		for _, result := range screen.myOrderCancelRes {
+			if len(result.FailReason) == 0 {
+				numCancelled++
+			}
+		}
+		if numCancelled == 0 {
+			screen.txFailReason = loud.Localize("none of the orders were cancelled")
+		}
+		screen.SetScreenStatusAndRefresh(RSLT_CANCEL_MYORDS)
+	}()
+}
+
+func (screen *GameScreen) cancelMyOrdersResultDesc() (string, FontType) {
+	results := screen.myOrderCancelRes
+	numCancelled := 0
+	for _, result := range results {
+		if len(result.FailReason) == 0 {
+			numCancelled++
+		}
+	}
+	font := REGULAR
+	if numCancelled < len(results) {
+		font = YELLOW
+	}
+	desc := loud.Sprintf("cancelled %d of %d orders", numCancelled, len(results))
+	desc += "\n"
+	for _, result := range results {
+		gives, gets := screen.myOrderGivesGets(result.Order)
+		line := fmt.Sprintf("\n%s → %s", strings.Join(gives, " + "), strings.Join(gets, " + "))
+		if len(result.FailReason) > 0 {
+			line += ": " + loud.Localize(result.FailReason)
+		} else {
+			line += ": " + loud.Localize("cancelled")
+		}
+		desc += line
+	}
+	return desc, font
+}
+
+var myOrderColumns = []tableColumn{{16, 0, true}, {22, 0, true}, {22, 0, true}, {8, 1, false}}
+
+func (screen *GameScreen) renderMyOrderLine(texts [4]string, isActiveLine bool, width int) string {
+	calcText := tableRow(myOrderColumns, width, texts[:]...)
+	onColor := screen.regularFont()
+	if isActiveLine {
+		onColor = screen.blueBoldFont()
+	}
+	return onColor(fillSpace(calcText, width))
+}
+
+func (screen *GameScreen) renderMyOrdersTable(width int) ([]string, []string) {
+	orders := loud.MyOrders()
+	infoLines := []string{
+		loud.Sprintf("My orders: %d open, %d selected", len(orders), len(screen.selectedMyOrders())),
+	}
+	if numFills := len(loud.MyOrderFills); numFills > 0 {
+		infoLines = append(infoLines, screen.myOrderFillText(loud.MyOrderFills[numFills-1]))
+	}
+
+	tableLines := []string{}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(myOrderColumns, width, "╭┬╮"), width)))
+	tableLines = append(tableLines, screen.renderMyOrderLine([4]string{
+		loud.Localize("Market"),
+		loud.Localize("Give"),
+		loud.Localize("Get"),
+		loud.Localize("Age"),
+	}, false, width))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(myOrderColumns, width, "├┼┤"), width)))
+
+	if screen.activeLine >= len(orders) {
+		screen.activeLine = len(orders) - 1
+	}
+	activeLine := screen.activeLine
+	numLines := screen.GetSituationBox().H - 5 - len(infoLines)
+	startLine := activeLine - numLines + 1
+	if startLine < 0 {
+		startLine = 0
+	}
+	endLine := startLine + numLines
+	if endLine > len(orders) {
+		endLine = len(orders)
+	}
+	screen.setTableRows(len(tableLines), startLine, endLine)
+	for li, order := range orders[startLine:endLine] {
+		mark := "☐ "
+		if screen.myOrderSel[order.ID] {
+			mark = "☑ "
+		}
+		gives, gets := screen.myOrderGivesGets(order)
+		tableLines = append(tableLines, screen.renderMyOrderLine([4]string{
+			mark + loud.Localize(order.Market),
+			strings.Join(gives, " + "),
+			strings.Join(gets, " + "),
+			screen.myOrderAge(order),
+		}, startLine+li == activeLine, width))
+	}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(myOrderColumns, width, "╰┴╯"), width)))
+	return infoLines, tableLines
+}
@@ -0,0 +1,291 @@
+package screen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+	"github.com/ahmetb/go-cursor"
+)
+
+// NAV_HISTORY_LIMIT is the number of screens kept for back navigation
+const NAV_HISTORY_LIMIT = 100
+
+// navEntry is a screen on navigation history
+type navEntry struct {
+	location   loud.UserLocation
+	status     ScreenStatus
+	activeLine int
+}
+
+var locationLabels = map[loud.UserLocation]string{
+	loud.HOME:     "Home",
+	loud.FOREST:   "Forest",
+	loud.SHOP:     "Shop",
+	loud.PYLCNTRL: "Pylons Central",
+	loud.SETTINGS: "Settings",
+	loud.DEVELOP:  "Develop",
+}
+
+// statusCrumbs is the breadcrumb label of screens, wait and result screens show the path of their flow
+var statusCrumbs = map[ScreenStatus]string{
+	CONFIRM_ENDGAME:                 "Exit Game",
+	SEL_ACTIVE_CHAR:                 "Select active character",
+	SEL_ACTIVE_WEAPON:               "Select active weapon",
+	SEL_ACTIVE_ARMOR:                "Select armor",
+	SEL_RENAME_CHAR:                 "Update character name",
+	RENAME_CHAR_ENT_NEWNAME:         "New name",
+	SHW_MY_ORDERS:                   "My orders",
+	SHW_WATCHLIST:                   "Watchlist",
+	WATCH_ENT_RULE:                  "Add watch rule",
+	SHW_BATTLE_HISTORY:              "Battle history",
+	SHW_BATTLE_REPORT:               "Battle report",
+	SHW_PROGRESSION:                 "Progression",
+	SHW_ACHIEVEMENTS:                "Achievements",
+	SHW_LEADERBOARD:                 "Leaderboard",
+	SEL_BUYITM:                      "Buy items",
+	SEL_SELLITM:                     "Sell items",
+	SEL_UPGITM:                      "Upgrade items",
+	SHW_CRAFTING:                    "Crafting",
+	CONFIRM_HUNT_RABBITS:            "Rabbits",
+	CONFIRM_FIGHT_GOBLIN:            "Goblin",
+	CONFIRM_FIGHT_WOLF:              "Wolf",
+	CONFIRM_FIGHT_TROLL:             "Troll",
+	CONFIRM_FIGHT_GIANT:             "Giant",
+	CONFIRM_FIGHT_DRAGONFIRE:        "Fire Dragon",
+	CONFIRM_FIGHT_DRAGONICE:         "Ice Dragon",
+	CONFIRM_FIGHT_DRAGONACID:        "Acid Dragon",
+	CONFIRM_FIGHT_DRAGONUNDEAD:      "Undead Dragon",
+	CR8_REPEAT_HUNT_ENT_RULE:        "Repeat hunt",
+	SEL_BUYCHR:                      "Buy characters",
+	SHW_LOUD_BUY_TRDREQS:            "Gold buy requests",
+	SHW_LOUD_SELL_TRDREQS:           "Gold sell requests",
+	SHW_LOUD_ORDERBOOK:              "Gold order book",
+	SHW_PRICE_HISTORY:               "Price history",
+	SHW_BUYITM_TRDREQS:              "Buy item requests",
+	SHW_SELLITM_TRDREQS:             "Sell item requests",
+	SHW_BUYCHR_TRDREQS:              "Buy character requests",
+	SHW_SELLCHR_TRDREQS:             "Sell character requests",
+	SHW_BARTER_TRDREQS:              "Barter requests",
+	CR8_BUY_LOUD_TRDREQ_ENT_LUDVAL:  "Create",
+	CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL: "Create",
+	CR8_SELLITM_TRDREQ_SEL_ITEM:     "Create",
+	CR8_BUYITM_TRDREQ_SEL_ITEM:      "Create",
+	CR8_SELLCHR_TRDREQ_SEL_CHR:      "Create",
+	CR8_BUYCHR_TRDREQ_SEL_CHR:       "Create",
+	CR8_BARTER_SEL_OFFER_ITEMS:      "Create",
+	CR8_BUY_LOUD_TRDREQ_ENT_PYLVAL:  "Price (pylon)",
+	CR8_SELL_LOUD_TRDREQ_ENT_PYLVAL: "Price (pylon)",
+	CR8_SELLITM_TRDREQ_ENT_PYLVAL:   "Price (pylon)",
+	CR8_BUYITM_TRDREQ_ENT_PYLVAL:    "Price (pylon)",
+	CR8_SELLCHR_TRDREQ_ENT_PYLVAL:   "Price (pylon)",
+	CR8_BUYCHR_TRDREQ_ENT_PYLVAL:    "Price (pylon)",
+	CR8_BARTER_ENT_OFFER_PYLVAL:     "Offer",
+	CR8_BARTER_ENT_OFFER_GOLDVAL:    "Offer",
+	CR8_BARTER_SEL_WANT_ITEMS:       "Want",
+	CR8_BARTER_ENT_WANT_PYLVAL:      "Want",
+	CR8_BARTER_ENT_WANT_GOLDVAL:     "Want",
+	FULFILL_BUYITM_TRDREQ_SEL_ITEM:  "Fulfill",
+	FULFILL_BUYCHR_TRDREQ_SEL_CHR:   "Fulfill",
+	CR8_MKTORD_ENT_LUDVAL:           "Market order",
+	CR8_MKTORD_ENT_PRICE:            "Price (pylon)",
+	CONFIRM_MKTORD:                  "Confirm",
+	FILTER_TRDREQ_ENT_QUERY:         "Filter requests",
+	JUMP_ENT_NUMBER:                 "Jump to number",
+	CONFIRM_UNLOCK_ITEM:             "Unlock item",
+}
+
+// isTransient returns true for the screens back and forward skip, eg. wait and result screens
+func isTransient(status ScreenStatus) bool {
+	return status.IsWaitScreen() || status.IsResultScreen() || status == CONFIRM_ENDGAME
+}
+
+// isFlowStep returns true for steps of create, fulfill and enter flows, a finished flow returns to where it started
+func isFlowStep(status ScreenStatus) bool {
+	if strings.HasPrefix(string(status), "CR8_") || strings.HasPrefix(string(status), "FULFILL_") {
+		return true
+	}
+	switch status {
+	case CONFIRM_MKTORD,
+		CONFIRM_UNLOCK_ITEM,
+		RENAME_CHAR_ENT_NEWNAME,
+		FILTER_TRDREQ_ENT_QUERY,
+		JUMP_ENT_NUMBER,
+		WATCH_ENT_RULE:
+		return true
+	}
+	return false
+}
+
+func (entry navEntry) is(other navEntry) bool {
+	return entry.location == other.location && entry.status == other.status
+}
+
+// SetScreenStatus moves to the screen and pushes it on navigation history
+func (screen *GameScreen) SetScreenStatus(newStatus ScreenStatus) {
+	entry := navEntry{location: screen.user.GetLocation(), status: newStatus}
+	screen.leaveUnfilteredTable(newStatus)
+	screen.scrStatus = newStatus
+	screen.forward = nil
+	n := len(screen.history)
+	if n > 0 {
+		top := &screen.history[n-1]
+		if top.is(entry) {
+			return
+		}
+		top.activeLine = screen.activeLine
+		if top.status.IsWaitScreen() && newStatus.IsResultScreen() {
+			// result replaces its wait screen
+			screen.history = screen.history[:n-1]
+		} else if idx := screen.flowStartIndex(entry); idx >= 0 {
+			// finishing a flow, eg. entering a filter, returns to the screen it started from
+			screen.history = screen.history[:idx]
+		}
+	}
+	screen.history = append(screen.history, entry)
+	if len(screen.history) > NAV_HISTORY_LIMIT {
+		screen.history = screen.history[len(screen.history)-NAV_HISTORY_LIMIT:]
+	}
+}
+
+// flowStartIndex returns where the entry is on history when only flow steps are above it, otherwise -1
+func (screen *GameScreen) flowStartIndex(entry navEntry) int {
+	for idx := len(screen.history) - 1; idx >= 0; idx-- {
+		status := screen.history[idx].status
+		if screen.history[idx].is(entry) {
+			return idx
+		}
+		if !isFlowStep(status) && !isTransient(status) {
+			break
+		}
+	}
+	return -1
+}
+
+// canRevisit returns false for the screens which can't be shown anymore, eg. a fight without a usable character
+func (screen *GameScreen) canRevisit(entry navEntry) bool {
+	if entry.location != loud.FOREST {
+		return true
+	}
+	if screen.user.GetActiveCharacter() == nil {
+		return false
+	}
+	if strings.HasPrefix(string(entry.status), "CONFIRM_") {
+		fst, _ := screen.ForestStatusCheck(entry.status)
+		return len(fst) == 0
+	}
+	return true
+}
+
+// NavigateBack returns to the previous screen, leaving a result screen returns to where its flow started
+func (screen *GameScreen) NavigateBack() {
+	n := len(screen.history)
+	leaving := navEntry{location: screen.user.GetLocation(), status: screen.scrStatus, activeLine: screen.activeLine}
+	if n > 0 {
+		screen.history = screen.history[:n-1]
+	}
+	if leaving.status != CONFIRM_ENDGAME { // exit confirm goes back to exactly where it was opened
+		skipFlow := isTransient(leaving.status)
+		for len(screen.history) > 0 {
+			top := screen.history[len(screen.history)-1]
+			if !isTransient(top.status) && !(skipFlow && isFlowStep(top.status)) && screen.canRevisit(top) {
+				break
+			}
+			screen.history = screen.history[:len(screen.history)-1]
+		}
+	}
+	if len(screen.history) == 0 {
+		// back from entrypoint of a location goes home
+		screen.history = []navEntry{{location: loud.HOME, status: SHW_LOCATION}}
+	}
+	prev := screen.history[len(screen.history)-1]
+	if !isTransient(leaving.status) && !isFlowStep(leaving.status) && !leaving.is(prev) {
+		screen.forward = append(screen.forward, leaving)
+	}
+	screen.restore(prev)
+}
+
+// NavigateForward moves to the screen left by the last back navigation
+func (screen *GameScreen) NavigateForward() bool {
+	n := len(screen.forward)
+	if n == 0 {
+		return false
+	}
+	entry := screen.forward[n-1]
+	if !screen.canRevisit(entry) {
+		screen.forward = nil
+		return false
+	}
+	screen.forward = screen.forward[:n-1]
+	if top := len(screen.history) - 1; top >= 0 {
+		screen.history[top].activeLine = screen.activeLine
+	}
+	screen.history = append(screen.history, entry)
+	screen.restore(entry)
+	screen.Render()
+	return true
+}
+
+func (screen *GameScreen) restore(entry navEntry) {
+	if screen.user.GetLocation() != entry.location {
+		screen.user.SetLocation(entry.location)
+	}
+	screen.leaveUnfilteredTable(entry.status)
+	screen.scrStatus = entry.status
+	screen.activeLine = entry.activeLine
+	screen.txFailReason = ""
+	switch entry.status {
+	case CR8_BUY_LOUD_TRDREQ_ENT_LUDVAL,
+		CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL,
+		CR8_MKTORD_ENT_LUDVAL:
+		// set loud value previously entered
+		screen.inputText = screen.loudEnterValue
+	case CR8_MKTORD_ENT_PRICE:
+		// set price previously entered
+		screen.inputText = screen.pylonEnterValue
+	case CR8_BARTER_ENT_OFFER_PYLVAL:
+		screen.inputText = fmt.Sprintf("%d", screen.barterOfferPylon)
+	case CR8_BARTER_ENT_OFFER_GOLDVAL:
+		screen.inputText = fmt.Sprintf("%d", screen.barterOfferGold)
+	case CR8_BARTER_ENT_WANT_PYLVAL:
+		screen.inputText = fmt.Sprintf("%d", screen.barterWantPylon)
+	}
+}
+
+// breadcrumb is the path from location entrypoint to current screen, eg. "Pylons Central › Sell item requests › Create"
+func (screen *GameScreen) breadcrumb() string {
+	location := screen.user.GetLocation()
+	crumbs := []string{}
+	for idx := len(screen.history) - 1; idx >= 0; idx-- {
+		entry := screen.history[idx]
+		if entry.location != location || entry.status == SHW_LOCATION {
+			break
+		}
+		label, ok := statusCrumbs[entry.status]
+		if !ok {
+			continue
+		}
+		label = loud.Localize(label)
+		if len(crumbs) == 0 || crumbs[0] != label {
+			crumbs = append([]string{label}, crumbs...)
+		}
+	}
+	crumbs = append([]string{loud.Localize(locationLabels[location])}, crumbs...)
+	return strings.Join(crumbs, " › ")
+}
+
+// renderBreadcrumb draws breadcrumb on the border below the menu
+func (screen *GameScreen) renderBreadcrumb() {
+	y := screen.GetMenuBox().Y + 1
+	w := screen.leftInnerWidth() - 1
+	text := " " + screen.breadcrumb() + " "
+	if len(screen.forward) > 0 {
+		text += screen.keys.Hint("Forward", ACT_FORWARD) + " "
+	}
+	if NumberOfSpaces(text) > w-2 {
+		text = truncateRight(text, w-2)
+	}
+	border := strings.Repeat("─", w-NumberOfSpaces(text)-1)
+	io.WriteString(screen.frame, cursor.MoveTo(y, 3)+screen.colorCode(BORDER)+"─"+screen.regularFont()(text)+screen.colorCode(BORDER)+border+resetStyle)
+}
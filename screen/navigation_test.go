@@ -0,0 +1,230 @@
+package screen
+
+import (
+	"testing"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+// navUser is a user with location, character and weapon, other methods aren't used by the tests
+type navUser struct {
+	loud.User
+	location  loud.UserLocation
+	character *loud.Character
+	weapon    *loud.Item
+}
+
+func (user *navUser) GetLocation() loud.UserLocation {
+	return user.location
+}
+
+func (user *navUser) SetLocation(location loud.UserLocation) {
+	user.location = location
+}
+
+func (user *navUser) GetActiveCharacter() *loud.Character {
+	return user.character
+}
+
+func (user *navUser) GetActiveWeapon() *loud.Item {
+	return user.weapon
+}
+
+const (
+	navGo      = "go"
+	navBack    = "back"
+	navForward = "forward"
+	navNoChar  = "nochar"
+)
+
+// navStep is a user action, navGo moves to the screen at the location
+type navStep struct {
+	act      string
+	location loud.UserLocation
+	status   ScreenStatus
+}
+
+func goTo(location loud.UserLocation, status ScreenStatus) navStep {
+	return navStep{act: navGo, location: location, status: status}
+}
+
+func newNavScreen() (*GameScreen, *navUser) {
+	user := &navUser{
+		location:  loud.HOME,
+		character: &loud.Character{Name: "hero"},
+		weapon:    &loud.Item{Name: loud.WOODEN_SWORD},
+	}
+	screen := &GameScreen{user: user, renderReq: make(chan struct{}, 1), closed: true}
+	return screen, user
+}
+
+func TestNavigation(t *testing.T) {
+	tests := []struct {
+		name         string
+		steps        []navStep
+		wantLocation loud.UserLocation
+		wantStatus   ScreenStatus
+		wantForward  int
+	}{
+		{
+			name: "create, result then back returns to requests",
+			steps: []navStep{
+				goTo(loud.PYLCNTRL, SHW_LOCATION),
+				goTo(loud.PYLCNTRL, SHW_LOUD_SELL_TRDREQS),
+				goTo(loud.PYLCNTRL, CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL),
+				goTo(loud.PYLCNTRL, CR8_SELL_LOUD_TRDREQ_ENT_PYLVAL),
+				goTo(loud.PYLCNTRL, W8_SELL_LOUD_TRDREQ_CREATION),
+				goTo(loud.PYLCNTRL, RSLT_SELL_LOUD_TRDREQ_CREATION),
+				{act: navBack},
+			},
+			wantLocation: loud.PYLCNTRL,
+			wantStatus:   SHW_LOUD_SELL_TRDREQS,
+		},
+		{
+			name: "cancel trade then back returns to requests",
+			steps: []navStep{
+				goTo(loud.PYLCNTRL, SHW_LOCATION),
+				goTo(loud.PYLCNTRL, SHW_LOUD_SELL_TRDREQS),
+				goTo(loud.PYLCNTRL, W8_CANCEL_TRDREQ),
+				goTo(loud.PYLCNTRL, RSLT_CANCEL_TRDREQ),
+				{act: navBack},
+			},
+			wantLocation: loud.PYLCNTRL,
+			wantStatus:   SHW_LOUD_SELL_TRDREQS,
+		},
+		{
+			name: "back",
+			steps: []navStep{
+				goTo(loud.PYLCNTRL, SHW_LOCATION),
+				goTo(loud.PYLCNTRL, SHW_MY_ORDERS),
+				{act: navBack},
+			},
+			wantLocation: loud.PYLCNTRL,
+			wantStatus:   SHW_LOCATION,
+			wantForward:  1,
+		},
+		{
+			name: "back then forward",
+			steps: []navStep{
+				goTo(loud.PYLCNTRL, SHW_LOCATION),
+				goTo(loud.PYLCNTRL, SHW_MY_ORDERS),
+				{act: navBack},
+				{act: navForward},
+			},
+			wantLocation: loud.PYLCNTRL,
+			wantStatus:   SHW_MY_ORDERS,
+		},
+		{
+			name: "back after entering a filter skips the filter flow",
+			steps: []navStep{
+				goTo(loud.PYLCNTRL, SHW_LOCATION),
+				goTo(loud.PYLCNTRL, SHW_MY_ORDERS),
+				goTo(loud.PYLCNTRL, SHW_LOUD_SELL_TRDREQS),
+				goTo(loud.PYLCNTRL, FILTER_TRDREQ_ENT_QUERY),
+				goTo(loud.PYLCNTRL, SHW_LOUD_SELL_TRDREQS),
+				{act: navBack},
+			},
+			wantLocation: loud.PYLCNTRL,
+			wantStatus:   SHW_MY_ORDERS,
+			wantForward:  1,
+		},
+		{
+			name: "back to forest confirm with no character goes home",
+			steps: []navStep{
+				goTo(loud.FOREST, SHW_LOCATION),
+				goTo(loud.FOREST, CONFIRM_FIGHT_GOBLIN),
+				goTo(loud.FOREST, W8_FIGHT_GOBLIN),
+				goTo(loud.FOREST, RSLT_FIGHT_GOBLIN),
+				{act: navNoChar},
+				{act: navBack},
+			},
+			wantLocation: loud.HOME,
+			wantStatus:   SHW_LOCATION,
+		},
+		{
+			name: "forward to forest confirm with no character stays",
+			steps: []navStep{
+				goTo(loud.FOREST, SHW_LOCATION),
+				goTo(loud.FOREST, CONFIRM_FIGHT_GOBLIN),
+				{act: navBack},
+				{act: navNoChar},
+				{act: navForward},
+			},
+			wantLocation: loud.FOREST,
+			wantStatus:   SHW_LOCATION,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen, user := newNavScreen()
+			for _, step := range tt.steps {
+				switch step.act {
+				case navGo:
+					user.SetLocation(step.location)
+					screen.SetScreenStatus(step.status)
+				case navBack:
+					screen.NavigateBack()
+				case navForward:
+					screen.NavigateForward()
+				case navNoChar:
+					user.character = nil
+				}
+			}
+			if user.location != tt.wantLocation || screen.scrStatus != tt.wantStatus {
+				t.Errorf("screen = %v %s, want %v %s", user.location, screen.scrStatus, tt.wantLocation, tt.wantStatus)
+			}
+			if len(screen.forward) != tt.wantForward {
+				t.Errorf("forward history length = %d, want %d", len(screen.forward), tt.wantForward)
+			}
+		})
+	}
+}
+
+func TestFlowStartIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []ScreenStatus
+		status  ScreenStatus
+		want    int
+	}{
+		{"empty history", nil, SHW_LOUD_SELL_TRDREQS, -1},
+		{"only flow steps above", []ScreenStatus{SHW_LOCATION, SHW_LOUD_SELL_TRDREQS, CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL, CR8_SELL_LOUD_TRDREQ_ENT_PYLVAL}, SHW_LOUD_SELL_TRDREQS, 1},
+		{"wait screen above", []ScreenStatus{SHW_LOCATION, SHW_LOUD_SELL_TRDREQS, W8_CANCEL_TRDREQ}, SHW_LOUD_SELL_TRDREQS, 1},
+		{"another screen above", []ScreenStatus{SHW_LOCATION, SHW_LOUD_SELL_TRDREQS, SHW_MY_ORDERS}, SHW_LOUD_SELL_TRDREQS, -1},
+		{"not on history", []ScreenStatus{SHW_LOCATION, CR8_SELL_LOUD_TRDREQ_ENT_LUDVAL}, SHW_BUYITM_TRDREQS, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screen, _ := newNavScreen()
+			for _, status := range tt.history {
+				screen.history = append(screen.history, navEntry{location: loud.PYLCNTRL, status: status})
+			}
+			if got := screen.flowStartIndex(navEntry{location: loud.PYLCNTRL, status: tt.status}); got != tt.want {
+				t.Errorf("flowStartIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNavHistoryLimit(t *testing.T) {
+	screen, user := newNavScreen()
+	user.SetLocation(loud.PYLCNTRL)
+	pushed := NAV_HISTORY_LIMIT + 10
+	for idx := 0; idx < pushed; idx++ {
+		if idx%2 == 0 {
+			screen.SetScreenStatus(SHW_MY_ORDERS)
+		} else {
+			screen.SetScreenStatus(SHW_WATCHLIST)
+		}
+		screen.activeLine = idx // kept on history when the next screen is pushed
+	}
+	if len(screen.history) != NAV_HISTORY_LIMIT {
+		t.Fatalf("history length = %d, want %d", len(screen.history), NAV_HISTORY_LIMIT)
+	}
+	if oldest := screen.history[0].activeLine; oldest != pushed-NAV_HISTORY_LIMIT {
+		t.Errorf("oldest screen on history is push %d, want %d", oldest, pushed-NAV_HISTORY_LIMIT)
+	}
+	if top := screen.history[NAV_HISTORY_LIMIT-1]; top.status != SHW_WATCHLIST {
+		t.Errorf("top of history = %s, want %s", top.status, SHW_WATCHLIST)
+	}
+}
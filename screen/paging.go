@@ -0,0 +1,184 @@
+package screen
+
+import (
+	"strconv"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+// SELECT_PAGE_SIZE is the number of entries listed with number keys on the command box
+const SELECT_PAGE_SIZE = 5
+
+// IsNumberedSelectList returns true for the lists whose entries are picked with number keys
+func (screen *GameScreen) IsNumberedSelectList(status ScreenStatus) bool {
+	switch status {
+	case SEL_ACTIVE_CHAR,
+		SEL_ACTIVE_WEAPON,
+		SEL_ACTIVE_ARMOR,
+		SEL_RENAME_CHAR,
+		SEL_BUYITM,
+		SEL_BUYCHR,
+		SEL_SELLITM,
+		SEL_UPGITM:
+		return true
+	}
+	return false
+}
+
+// selectEntries returns entries of the select list shown on the status, false when it's not a select list
+func (screen *GameScreen) selectEntries(status ScreenStatus) ([]interface{}, bool) {
+	var entries interface{}
+	switch status {
+	case SEL_ACTIVE_CHAR, SEL_RENAME_CHAR, CR8_SELLCHR_TRDREQ_SEL_CHR:
+		entries = screen.user.InventoryCharacters()
+	case SEL_ACTIVE_WEAPON:
+		entries = screen.user.InventorySwords()
+	case SEL_ACTIVE_ARMOR:
+		entries = screen.user.InventoryArmors()
+	case SEL_BUYITM:
+		entries = loud.ShopItems
+	case SEL_BUYCHR:
+		entries = loud.ShopCharacters
+	case SEL_SELLITM:
+		entries = screen.user.InventorySellableItems()
+	case SEL_UPGITM:
+		entries = screen.user.InventoryUpgradableItems()
+	case CR8_SELLITM_TRDREQ_SEL_ITEM:
+		entries = screen.user.InventoryItems()
+	case CR8_BARTER_SEL_OFFER_ITEMS:
+		entries = loud.BarterOfferItems(screen.user)
+	case CR8_BUYITM_TRDREQ_SEL_ITEM, CR8_BARTER_SEL_WANT_ITEMS:
+		entries = loud.WorldItemSpecs
+	case CR8_BUYCHR_TRDREQ_SEL_CHR:
+		entries = loud.WorldCharacterSpecs
+	case FULFILL_BUYITM_TRDREQ_SEL_ITEM:
+		entries = loud.ItemBuyTrdReqCandidates(screen.user, screen.activeItemTrdReq.(loud.ItemBuyTrdReq))
+	case FULFILL_BUYCHR_TRDREQ_SEL_CHR:
+		entries = loud.CharacterBuyTrdReqCandidates(screen.user, screen.activeItemTrdReq.(loud.CharacterBuyTrdReq))
+	default:
+		return nil, false
+	}
+	return InterfaceSlice(entries), true
+}
+
+// selectedEntry returns the entry on active line of the select list, nil when nothing is selected
+func (screen *GameScreen) selectedEntry() interface{} {
+	entries, _ := screen.selectEntries(screen.scrStatus)
+	if screen.activeLine < 0 || screen.activeLine >= len(entries) {
+		return nil
+	}
+	return entries[screen.activeLine]
+}
+
+// activeEntryLine returns line of active character, weapon or armor on its select list, -1 when none is active
+func (screen *GameScreen) activeEntryLine(status ScreenStatus) int {
+	activeID := ""
+	switch status {
+	case SEL_ACTIVE_CHAR:
+		if char := screen.user.GetActiveCharacter(); char != nil {
+			activeID = char.ID
+		}
+	case SEL_ACTIVE_WEAPON:
+		if item := screen.user.GetActiveWeapon(); item != nil {
+			activeID = item.ID
+		}
+	case SEL_ACTIVE_ARMOR:
+		if item := screen.user.GetActiveArmor(); item != nil {
+			activeID = item.ID
+		}
+	}
+	entries, _ := screen.selectEntries(status)
+	for idx, entry := range entries {
+		switch entry := entry.(type) {
+		case loud.Character:
+			if entry.ID == activeID {
+				return idx
+			}
+		case loud.Item:
+			if entry.ID == activeID {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+// selectPageRange returns start and end of the page which contains active line
+func selectPageRange(activeLine, total int) (int, int) {
+	if activeLine >= total {
+		activeLine = total - 1
+	}
+	if activeLine < 0 {
+		activeLine = 0
+	}
+	start := activeLine / SELECT_PAGE_SIZE * SELECT_PAGE_SIZE
+	end := start + SELECT_PAGE_SIZE
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// selectPositionDesc is the "n of m" indicator of select lists
+func selectPositionDesc(activeLine, total int) string {
+	if total == 0 {
+		return loud.Sprintf("%d of %d", 0, 0)
+	}
+	numPages := (total + SELECT_PAGE_SIZE - 1) / SELECT_PAGE_SIZE
+	return loud.Sprintf("%d of %d", activeLine+1, total) + "  " +
+		loud.Sprintf("page %d of %d", activeLine/SELECT_PAGE_SIZE+1, numPages)
+}
+
+// moveSelectPage moves active line to the first entry of next or previous page
+func (screen *GameScreen) moveSelectPage(delta int) bool {
+	entries, ok := screen.selectEntries(screen.scrStatus)
+	if !ok || len(entries) == 0 {
+		return false
+	}
+	start, _ := selectPageRange(screen.activeLine, len(entries))
+	activeLine := start + delta*SELECT_PAGE_SIZE
+	if activeLine < 0 {
+		activeLine = 0
+	}
+	if activeLine >= len(entries) {
+		activeLine = len(entries) - 1
+	}
+	screen.activeLine = activeLine
+	screen.Render()
+	return true
+}
+
+// selectPageEntry selects the entry of the number key on the current page
+func (screen *GameScreen) selectPageEntry(number int) bool {
+	entries, _ := screen.selectEntries(screen.scrStatus)
+	start, end := selectPageRange(screen.activeLine, len(entries))
+	idx := start + number - 1
+	if idx < start || idx >= end {
+		return false
+	}
+	screen.activeLine = idx
+	return screen.HandleThirdClassKeyEnterEvent()
+}
+
+func (screen *GameScreen) startSelectJump() bool {
+	if _, ok := screen.selectEntries(screen.scrStatus); !ok {
+		return false
+	}
+	screen.jumpReturn = screen.scrStatus
+	screen.inputText = ""
+	screen.SetScreenStatusAndRefresh(JUMP_ENT_NUMBER)
+	return true
+}
+
+func (screen *GameScreen) applySelectJump(text string) {
+	entries, _ := screen.selectEntries(screen.jumpReturn)
+	number, err := strconv.Atoi(text)
+	if err != nil || number < 1 || number > len(entries) {
+		screen.actionText = loud.Sprintf("Please enter a number from 1 to %d", len(entries))
+		screen.Render()
+		return
+	}
+	screen.activeLine = number - 1
+	screen.inputText = ""
+	screen.SetScreenStatusAndRefresh(screen.jumpReturn)
+}
@@ -0,0 +1,58 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+func recipeProgressState(rp loud.RecipeProgress) string {
+	switch {
+	case !rp.Synced:
+		return loud.Localize("recipe is not synced yet")
+	case !rp.CharacterOK:
+		return loud.Localize("character is not eligible")
+	case rp.NeedsWeapon && !rp.WeaponOK:
+		return loud.Localize("needs matching sword")
+	}
+	return loud.Localize("ready")
+}
+
+// nextLevelDesc describes XP needed for the next level in a single line
+func nextLevelDesc(p loud.Progression) string {
+	switch {
+	case !p.HasNextLevel:
+		return loud.Sprintf("Lv%d XP %.0f, next level is not available", p.Level, p.XP)
+	case p.XP >= p.NextLevelXP:
+		return loud.Sprintf("Lv%d XP %.0f, next win levels up", p.Level, p.XP)
+	}
+	return loud.Sprintf("Lv%d XP %.0f/%.0f, %.0f XP to next level", p.Level, p.XP, p.NextLevelXP, p.NextLevelXP-p.XP)
+}
+
+// progressionDesc is the progression panel of active character
+func (screen *GameScreen) progressionDesc() string {
+	character := screen.user.GetActiveCharacter()
+	if character == nil {
+		return loud.Localize("You need a character for this action!")
+	}
+	p, err := loud.CharacterProgression(character, screen.user.GetActiveWeapon())
+	if err != nil {
+		return loud.Sprintf("Progression is not available: %s", loud.Localize(err.Error()))
+	}
+	lines := []string{
+		formatCharacterP(character),
+		nextLevelDesc(p),
+		loud.Sprintf("Kills: giant %d, special dragon %d, undead dragon %d", character.GiantKill, character.SpecialDragonKill, character.UndeadDragonKill),
+		"",
+		loud.Localize("XP per win"),
+	}
+	for _, rp := range p.Recipes {
+		line := fmt.Sprintf("%-16s XP +%-8.0f %s", loud.Localize(battleActionLabels[rp.Action]), rp.XP, recipeProgressState(rp))
+		if rp.Synced && rp.LevelAfter > p.Level {
+			line += ", " + loud.Sprintf("levels up to %d", rp.LevelAfter)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,114 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+	"github.com/Pylons-tech/LOUD/log"
+)
+
+var repeatHuntFuncs = map[ScreenStatus]func(loud.User) (string, error){
+	CONFIRM_HUNT_RABBITS:       loud.HuntRabbits,
+	CONFIRM_FIGHT_GOBLIN:       loud.FightGoblin,
+	CONFIRM_FIGHT_WOLF:         loud.FightWolf,
+	CONFIRM_FIGHT_TROLL:        loud.FightTroll,
+	CONFIRM_FIGHT_GIANT:        loud.FightGiant,
+	CONFIRM_FIGHT_DRAGONFIRE:   loud.FightDragonFire,
+	CONFIRM_FIGHT_DRAGONICE:    loud.FightDragonIce,
+	CONFIRM_FIGHT_DRAGONACID:   loud.FightDragonAcid,
+	CONFIRM_FIGHT_DRAGONUNDEAD: loud.FightDragonUndead,
+}
+
+func (screen *GameScreen) startRepeatHunt() bool {
+	if _, ok := repeatHuntFuncs[screen.scrStatus]; !ok {
+		return false
+	}
+	screen.repeatHuntReturn = screen.scrStatus
+	screen.inputText = ""
+	screen.SetScreenStatusAndRefresh(CR8_REPEAT_HUNT_ENT_RULE)
+	return true
+}
+
+// RunRepeatHunt runs the forest action until one of the stop conditions is met
+func (screen *GameScreen) RunRepeatHunt(query string) {
+	rule, err := loud.ParseRepeatHuntRule(query)
+	if err != nil {
+		screen.actionText = loud.Localize(err.Error())
+		screen.Render()
+		return
+	}
+	fn := repeatHuntFuncs[screen.repeatHuntReturn]
+	screen.repeatHunt = loud.RepeatHuntProgress{Rule: rule}
+	screen.inputText = ""
+	screen.SetScreenStatusAndRefresh(W8_REPEAT_HUNT)
+
+	log.Println("started repeat hunt", rule.Query)
+	go func() {
+		for !screen.repeatHunt.Stopped() {
+			loadout := loud.ActiveLoadout(screen.user)
+			txhash, err := fn(screen.user)
+			if err != nil {
+				screen.repeatHunt.Add(screen.user, loud.BattleReport{}, err.Error())
+				break
+			}
+			time.Sleep(1 * time.Second)
+			txResult, failReason := loud.ProcessTxResult(screen.user, txhash)
+			screen.txResult = txResult
+			if len(failReason) == 0 {
+				screen.battleReport = screen.recordBattle(txhash, loadout, txResult)
+			}
+			screen.repeatHunt.Add(screen.user, screen.battleReport, failReason)
+			screen.Render()
+		}
+		log.Println("ended repeat hunt", screen.repeatHunt.StopReason)
+		screen.txFailReason = ""
+		screen.SetScreenStatusAndRefresh(RSLT_REPEAT_HUNT)
+	}()
+}
+
+func (screen *GameScreen) stopRepeatHunt() bool {
+	if screen.scrStatus != W8_REPEAT_HUNT {
+		return false
+	}
+	screen.repeatHunt.StopRequested = true
+	screen.Render()
+	return true
+}
+
+func (screen *GameScreen) repeatHuntProgressDesc() string {
+	p := screen.repeatHunt
+	lines := []string{
+		loud.Sprintf("Repeat rule: %s", p.Rule.Query),
+		loud.Sprintf("Runs: %d", p.Runs),
+		loud.Sprintf("Gold earned: 💰 %d", p.Gold),
+		loud.Sprintf("XP earned: %.0f", p.XP),
+	}
+	drops := []string{}
+	for _, drop := range p.Drops {
+		drops = append(drops, loud.Localize(drop))
+	}
+	if len(drops) > 0 {
+		lines = append(lines, loud.Sprintf("Drops: %s", strings.Join(drops, ", ")))
+	}
+	if ch := screen.user.GetActiveCharacter(); ch != nil {
+		lines = append(lines, fmt.Sprintf("%s, 💰 %d", formatCharacterP(ch), screen.user.GetGold()))
+	}
+	if p.StopRequested && !p.Stopped() {
+		lines = append(lines, loud.Localize("Stopping after current fight"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (screen *GameScreen) repeatHuntResultDesc() (string, FontType) {
+	p := screen.repeatHunt
+	desc := loud.Sprintf("Repeat hunt stopped: %s", p.StopReason) + "\n\n" + screen.repeatHuntProgressDesc()
+	switch {
+	case p.CharacterLost, p.Runs == 0:
+		return desc, RED
+	case p.WeaponLost, p.StopRequested:
+		return desc, YELLOW
+	}
+	return desc, GREEN
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 
 	"github.com/ahmetb/go-cursor"
 	"github.com/gliderlabs/ssh"
 	"github.com/nsf/termbox-go"
 
 	loud "github.com/Pylons-tech/LOUD/data"
+	"github.com/Pylons-tech/LOUD/log"
 	terminal "github.com/wayneashleyberry/terminal-dimensions"
 )
 
@@ -18,7 +20,6 @@ const resetScreen string = "\x1bc"
 const ellipsis = "…"
 const hpon = "◆"
 const hpoff = "◇"
-const bgcolor = 232
 
 // Screen represents a UI screen.
 type Screen interface {
@@ -27,6 +28,9 @@ type Screen interface {
 	UpdateFakeBlockHeight(int64)
 	SetScreenSize(int, int)
 	HandleInputKey(termbox.Event)
+	HandleMouseEvent(termbox.Event) (termbox.Event, bool)
+	KeyAction(termbox.Event) string
+	ActionEvent(string) termbox.Event
 	GetScreenStatus() ScreenStatus
 	SetScreenStatus(ScreenStatus)
 	GetTxFailReason() string
@@ -37,28 +41,74 @@ type Screen interface {
 }
 
 type GameScreen struct {
-	world            loud.World
-	user             loud.User
-	screenSize       ssh.Window
-	activeItem       loud.Item
-	activeItSpec     loud.ItemSpec
-	activeCharacter  loud.Character
-	activeChSpec     loud.CharacterSpec
-	activeLine       int
-	activeTrdReq     loud.TrdReq
-	activeItemTrdReq interface{}
-	pylonEnterValue  string
-	loudEnterValue   string
-	actionText       string
-	inputText        string
-	syncingData      bool
-	blockHeight      int64
-	fakeBlockHeight  int64
-	txFailReason     string
-	txResult         []byte
-	refreshed        bool
-	scrStatus        ScreenStatus
-	colorCodeCache   map[string](func(string) string)
+	world               loud.World
+	user                loud.User
+	screenSize          ssh.Window
+	keys                Keymap
+	theme               Theme
+	themes              []Theme
+	colorDepth          int
+	activeItem          loud.Item
+	activeItSpec        loud.ItemSpec
+	activeCharacter     loud.Character
+	activeChSpec        loud.CharacterSpec
+	activeLine          int
+	activeTrdReq        loud.TrdReq
+	activeItemTrdReq    interface{}
+	orderBook           loud.OrderBook
+	marketOrder         loud.MarketOrderPlan
+	marketOrderRes      []loud.MarketOrderFillResult
+	priceHistoryByBlock bool
+	trdReqFilters       map[ScreenStatus]loud.TrdReqFilter
+	filterReturn        ScreenStatus
+	unfilteredTable     ScreenStatus // table bypassing its filter to show a watch alert row
+	jumpReturn          ScreenStatus
+	sheetScroll         int        // first inventory line shown on character sheet
+	cmdActions          []string   // key action of each command box line of the last render, for mouse clicks
+	sheetActions        []string   // key action of each character sheet line of the last render
+	situationRows       *tableRows // entry rows of the situation table, nil when it has none
+	hoverY              int        // screen line under the mouse
+	pane                int        // pane shown on the compact layout
+	history             []navEntry // screens navigated through, the last is the current screen
+	forward             []navEntry // screens left by back navigation
+	activeBarterTrdReq  loud.BarterTrdReq
+	barterOfferIDs      map[string]bool
+	barterWantIdxs      map[int]bool
+	barterOfferPylon    int
+	barterWantPylon     int
+	barterOfferGold     int
+	barterWantGold      int
+	unlockTrdReqID      string
+	unlockItemLabel     string
+	myOrderSel          map[string]bool
+	myOrderCancelRes    []loud.MyOrderCancelResult
+	toasts              []string
+	bell                bool // rings the terminal bell on next frame
+	lastWatchAlert      loud.WatchAlert
+	hasWatchAlert       bool
+	repeatHunt          loud.RepeatHuntProgress
+	repeatHuntReturn    ScreenStatus
+	battleReport        loud.BattleReport
+	leaderboardSort     int // index of loud.LeaderboardSortKeys
+	leaderboardScanning bool
+	leaderboard         loud.Leaderboard  // sorted by leaderboardSort, loaded when leaderboard opens or scan ends
+	knownUsernames      map[string]string // usernames of local users by address, loaded with leaderboard
+	pylonEnterValue     string
+	loudEnterValue      string
+	actionText          string
+	inputText           string
+	syncingData         bool
+	blockHeight         int64
+	fakeBlockHeight     int64
+	txFailReason        string
+	txResult            []byte
+	refreshed           bool
+	scrStatus           ScreenStatus
+	colorCodeCache      map[string](func(string) string)
+	frame               *Frame        // cell buffer boxes are drawn on, flushed by the render loop
+	renderReq           chan struct{} // render requests, a request made while drawing merges into one
+	renderMu            sync.Mutex    // held while drawing, and by Reset to keep the terminal for goodbye
+	closed              bool
 }
 
 // NewScreen manages the window rendering for game
@@ -75,21 +125,64 @@ func NewScreen(world loud.World, user loud.User) Screen {
 		world:          world,
 		user:           user,
 		screenSize:     window,
-		colorCodeCache: make(map[string](func(string) string))}
+		keys:           NewKeymap(loud.KeymapConfig),
+		themes:         LoadThemes(loud.ThemesConfig),
+		colorDepth:     DetectColorDepth(),
+		trdReqFilters:  make(map[ScreenStatus]loud.TrdReqFilter),
+		myOrderSel:     make(map[string]bool),
+		colorCodeCache: make(map[string](func(string) string)),
+		frame:          &Frame{},
+		renderReq:      make(chan struct{}, 1)}
+
+	screen.theme = screen.themes[0]
+	if theme, ok := findTheme(screen.themes, loud.ThemeConfig); ok {
+		screen.theme = theme
+	} else if loud.ThemeConfig != "" {
+		log.Println("theme: unknown theme", loud.ThemeConfig)
+	}
+	screen.orderBook = loud.BuildOrderBook(loud.BuyTrdReqs, loud.SellTrdReqs)
+	screen.SetScreenStatus(SHW_LOCATION)
+	loud.AddSyncListener(screen.OnSyncFinished)
+	go screen.renderLoop()
 
 	return &screen
 }
 
+// Render asks the render loop to draw the screen, so it's safe to call from any goroutine on state change
 func (screen *GameScreen) Render() {
+	select {
+	case screen.renderReq <- struct{}{}:
+	default: // a render is pending already, it will draw the latest state
+	}
+}
+
+// renderLoop serializes drawing, each frame flushes only the cells which changed
+func (screen *GameScreen) renderLoop() {
+	for range screen.renderReq {
+		screen.renderMu.Lock()
+		if !screen.closed {
+			screen.draw()
+			screen.frame.Flush(os.Stdout)
+		}
+		screen.renderMu.Unlock()
+	}
+}
+
+func (screen *GameScreen) draw() {
+	screen.frame.Resize(screen.Width(), screen.Height())
+	if screen.bell {
+		screen.frame.Bell()
+		screen.bell = false
+	}
 	if len(loud.SomethingWentWrongMsg) > 0 {
 		clear := cursor.ClearEntireScreen()
 		dead := loud.Localize("Something went wrong, please close using Esc key and see loud.log")
 		move := cursor.MoveTo(screen.Height()/2, screen.Width()/2-NumberOfSpaces(dead)/2)
-		io.WriteString(os.Stdout, clear+move+dead)
+		io.WriteString(screen.frame, clear+move+dead)
 
 		detailedErrorMsg := fmt.Sprintf("%s: %s", loud.Localize("detailed error"), loud.SomethingWentWrongMsg)
 		move = cursor.MoveTo(screen.Height()/2+3, screen.Width()/2-NumberOfSpaces(dead)/2)
-		io.WriteString(os.Stdout, move+detailedErrorMsg)
+		io.WriteString(screen.frame, move+detailedErrorMsg)
 		screen.refreshed = false
 		return
 	}
@@ -97,24 +190,28 @@ func (screen *GameScreen) Render() {
 		screen.scrStatus = SHW_LOCATION
 	}
 
-	if screen.Height() < 38 || screen.Width() < 120 {
+	if screen.IsTooSmall() {
 		clear := cursor.ClearEntireScreen()
 		move := cursor.MoveTo(1, 1)
-		io.WriteString(os.Stdout,
+		io.WriteString(screen.frame,
 			fmt.Sprintf("%s%s%s", clear, move, loud.Localize("screen size warning")))
 		return
 	}
 
 	if !screen.refreshed {
-		clear := cursor.ClearEntireScreen() + allowMouseInputAndHideCursor
-		io.WriteString(os.Stdout, clear)
+		io.WriteString(screen.frame, cursor.ClearEntireScreen())
 		screen.redrawBorders()
 		screen.refreshed = true
 	}
 
-	screen.renderUserCommands()
-	screen.renderUserSituation()
-	screen.renderCharacterSheet()
+	if screen.IsCompact() {
+		screen.renderPane()
+	} else {
+		screen.renderUserCommands()
+		screen.renderUserSituation()
+		screen.renderCharacterSheet()
+	}
 	screen.renderInputValue()
 	screen.renderMenu()
+	screen.renderBreadcrumb()
 }
@@ -15,6 +15,9 @@ const (
 	SEL_ACTIVE_WEAPON   = "SEL_ACTIVE_WEAPON"
 	RSLT_SEL_ACT_WEAPON = "RSLT_SEL_ACT_WEAPON"
 
+	SEL_ACTIVE_ARMOR   = "SEL_ACTIVE_ARMOR"
+	RSLT_SEL_ACT_ARMOR = "RSLT_SEL_ACT_ARMOR"
+
 	SEL_RENAME_CHAR         = "SEL_RENAME_CHAR"
 	RENAME_CHAR_ENT_NEWNAME = "RENAME_CHAR_ENT_NEWNAME"
 	W8_RENAME_CHAR          = "W8_RENAME_CHAR"
@@ -105,6 +108,19 @@ const (
 	W8_FULFILL_SELL_LOUD_TRDREQ     = "W8_FULFILL_SELL_LOUD_TRDREQ"
 	RSLT_FULFILL_SELL_LOUD_TRDREQ   = "RSLT_FULFILL_SELL_LOUD_TRDREQ"
 
+	SHW_LOUD_ORDERBOOK = "SHW_LOUD_ORDERBOOK" // aggregated gold buy/sell requests by price level
+	SHW_PRICE_HISTORY  = "SHW_PRICE_HISTORY"  // sparklines and OHLC summaries of completed trades
+
+	CR8_MKTORD_ENT_LUDVAL = "CR8_MKTORD_ENT_LUDVAL" // enter gold amount to buy or sell at market
+	CR8_MKTORD_ENT_PRICE  = "CR8_MKTORD_ENT_PRICE"  // enter max (buy) or min (sell) average price
+	CONFIRM_MKTORD        = "CONFIRM_MKTORD"        // preview of picked trade requests
+	W8_MKTORD             = "W8_MKTORD"
+	RSLT_MKTORD           = "RSLT_MKTORD"
+
+	FILTER_TRDREQ_ENT_QUERY = "FILTER_TRDREQ_ENT_QUERY" // enter filter query of item and character trade request tables
+
+	JUMP_ENT_NUMBER = "JUMP_ENT_NUMBER" // enter entry number of a select list to jump to
+
 	SHW_SELLITM_TRDREQS           = "SHW_SELLITM_TRDREQS"
 	CR8_SELLITM_TRDREQ_SEL_ITEM   = "CR8_SELLITM_TRDREQ_SEL_ITEM"
 	CR8_SELLITM_TRDREQ_ENT_PYLVAL = "CR8_SELLITM_TRDREQ_ENT_PYLVAL"
@@ -113,13 +129,14 @@ const (
 	W8_FULFILL_SELLITM_TRDREQ     = "W8_FULFILL_SELLITM_TRDREQ"
 	RSLT_FULFILL_SELLITM_TRDREQ   = "RSLT_FULFILL_SELLITM_TRDREQ"
 
-	SHW_BUYITM_TRDREQS           = "SHW_BUYITM_TRDREQS"
-	CR8_BUYITM_TRDREQ_SEL_ITEM   = "CR8_BUYITM_TRDREQ_SEL_ITEM"
-	CR8_BUYITM_TRDREQ_ENT_PYLVAL = "CR8_BUYITM_TRDREQ_ENT_PYLVAL"
-	W8_BUYITM_TRDREQ_CREATION    = "W8_BUYITM_TRDREQ_CREATION"
-	RSLT_BUYITM_TRDREQ_CREATION  = "RSLT_BUYITM_TRDREQ_CREATION"
-	W8_FULFILL_BUYITM_TRDREQ     = "W8_FULFILL_BUYITM_TRDREQ"
-	RSLT_FULFILL_BUYITM_TRDREQ   = "RSLT_FULFILL_BUYITM_TRDREQ"
+	SHW_BUYITM_TRDREQS             = "SHW_BUYITM_TRDREQS"
+	CR8_BUYITM_TRDREQ_SEL_ITEM     = "CR8_BUYITM_TRDREQ_SEL_ITEM"
+	CR8_BUYITM_TRDREQ_ENT_PYLVAL   = "CR8_BUYITM_TRDREQ_ENT_PYLVAL"
+	W8_BUYITM_TRDREQ_CREATION      = "W8_BUYITM_TRDREQ_CREATION"
+	RSLT_BUYITM_TRDREQ_CREATION    = "RSLT_BUYITM_TRDREQ_CREATION"
+	FULFILL_BUYITM_TRDREQ_SEL_ITEM = "FULFILL_BUYITM_TRDREQ_SEL_ITEM" // select item to give
+	W8_FULFILL_BUYITM_TRDREQ       = "W8_FULFILL_BUYITM_TRDREQ"
+	RSLT_FULFILL_BUYITM_TRDREQ     = "RSLT_FULFILL_BUYITM_TRDREQ"
 
 	SHW_SELLCHR_TRDREQS           = "SHW_SELLCHR_TRDREQS"
 	CR8_SELLCHR_TRDREQ_SEL_CHR    = "CR8_SELLCHR_TRDREQ_SEL_CHR"
@@ -129,13 +146,50 @@ const (
 	W8_FULFILL_SELLCHR_TRDREQ     = "W8_FULFILL_SELLCHR_TRDREQ"
 	RSLT_FULFILL_SELLCHR_TRDREQ   = "RSLT_FULFILL_SELLCHR_TRDREQ"
 
-	SHW_BUYCHR_TRDREQS           = "SHW_BUYCHR_TRDREQS"
-	CR8_BUYCHR_TRDREQ_SEL_CHR    = "CR8_BUYCHR_TRDREQ_SEL_CHR"
-	CR8_BUYCHR_TRDREQ_ENT_PYLVAL = "CR8_BUYCHR_TRDREQ_ENT_PYLVAL"
-	W8_BUYCHR_TRDREQ_CREATION    = "W8_BUYCHR_TRDREQ_CREATION"
-	RSLT_BUYCHR_TRDREQ_CREATION  = "RSLT_BUYCHR_TRDREQ_CREATION"
-	W8_FULFILL_BUYCHR_TRDREQ     = "W8_FULFILL_BUYCHR_TRDREQ"
-	RSLT_FULFILL_BUYCHR_TRDREQ   = "RSLT_FULFILL_BUYCHR_TRDREQ"
+	SHW_BUYCHR_TRDREQS            = "SHW_BUYCHR_TRDREQS"
+	CR8_BUYCHR_TRDREQ_SEL_CHR     = "CR8_BUYCHR_TRDREQ_SEL_CHR"
+	CR8_BUYCHR_TRDREQ_ENT_PYLVAL  = "CR8_BUYCHR_TRDREQ_ENT_PYLVAL"
+	W8_BUYCHR_TRDREQ_CREATION     = "W8_BUYCHR_TRDREQ_CREATION"
+	RSLT_BUYCHR_TRDREQ_CREATION   = "RSLT_BUYCHR_TRDREQ_CREATION"
+	FULFILL_BUYCHR_TRDREQ_SEL_CHR = "FULFILL_BUYCHR_TRDREQ_SEL_CHR" // select character to give
+	W8_FULFILL_BUYCHR_TRDREQ      = "W8_FULFILL_BUYCHR_TRDREQ"
+	RSLT_FULFILL_BUYCHR_TRDREQ    = "RSLT_FULFILL_BUYCHR_TRDREQ"
+
+	SHW_BARTER_TRDREQS           = "SHW_BARTER_TRDREQS"
+	CR8_BARTER_SEL_OFFER_ITEMS   = "CR8_BARTER_SEL_OFFER_ITEMS" // multi select items to give
+	CR8_BARTER_ENT_OFFER_PYLVAL  = "CR8_BARTER_ENT_OFFER_PYLVAL"
+	CR8_BARTER_ENT_OFFER_GOLDVAL = "CR8_BARTER_ENT_OFFER_GOLDVAL"
+	CR8_BARTER_SEL_WANT_ITEMS    = "CR8_BARTER_SEL_WANT_ITEMS" // multi select items to get
+	CR8_BARTER_ENT_WANT_PYLVAL   = "CR8_BARTER_ENT_WANT_PYLVAL"
+	CR8_BARTER_ENT_WANT_GOLDVAL  = "CR8_BARTER_ENT_WANT_GOLDVAL"
+	W8_BARTER_TRDREQ_CREATION    = "W8_BARTER_TRDREQ_CREATION"
+	RSLT_BARTER_TRDREQ_CREATION  = "RSLT_BARTER_TRDREQ_CREATION"
+	W8_FULFILL_BARTER_TRDREQ     = "W8_FULFILL_BARTER_TRDREQ"
+	RSLT_FULFILL_BARTER_TRDREQ   = "RSLT_FULFILL_BARTER_TRDREQ"
+
+	CONFIRM_UNLOCK_ITEM = "CONFIRM_UNLOCK_ITEM" // cancel trade request to unlock listed item
+
+	SHW_WATCHLIST  = "SHW_WATCHLIST"
+	WATCH_ENT_RULE = "WATCH_ENT_RULE"
+
+	SHW_MY_ORDERS      = "SHW_MY_ORDERS"
+	W8_CANCEL_MYORDS   = "W8_CANCEL_MYORDS"
+	RSLT_CANCEL_MYORDS = "RSLT_CANCEL_MYORDS"
+
+	CR8_REPEAT_HUNT_ENT_RULE = "CR8_REPEAT_HUNT_ENT_RULE" // stop conditions of repeat hunt
+	W8_REPEAT_HUNT           = "W8_REPEAT_HUNT"
+	RSLT_REPEAT_HUNT         = "RSLT_REPEAT_HUNT"
+
+	SHW_BATTLE_HISTORY = "SHW_BATTLE_HISTORY"
+	SHW_BATTLE_REPORT  = "SHW_BATTLE_REPORT"
+
+	SHW_PROGRESSION = "SHW_PROGRESSION"
+
+	SHW_ACHIEVEMENTS = "SHW_ACHIEVEMENTS"
+
+	SHW_LEADERBOARD = "SHW_LEADERBOARD"
+
+	SHW_CRAFTING = "SHW_CRAFTING"
 
 	W8_CANCEL_TRDREQ   = "W8_CANCEL_TRDREQ"
 	RSLT_CANCEL_TRDREQ = "RSLT_CANCEL_TRDREQ"
@@ -7,11 +7,114 @@ import (
 	loud "github.com/Pylons-tech/LOUD/data"
 )
 
+// tableColumn is a column of situation tables
+type tableColumn struct {
+	width    int
+	priority int  // columns of higher priority are dropped first on narrow screens, 0 is never dropped
+	left     bool // left aligned, centered otherwise
+}
+
+var (
+	trColumns        = []tableColumn{{20, 0, false}, {15, 0, false}, {15, 1, false}}
+	itrColumns       = []tableColumn{{36, 0, false}, {15, 0, false}, {15, 1, false}}
+	itemColumns      = []tableColumn{{52, 0, false}}
+	orderBookColumns = []tableColumn{{12, 0, false}, {12, 0, false}, {12, 1, false}, {20, 2, false}}
+	ohlcColumns      = []tableColumn{{17, 0, false}, {9, 3, false}, {9, 2, false}, {9, 2, false}, {9, 0, false}, {9, 1, false}}
+	reportColumns    = []tableColumn{{67, 0, true}}
+)
+
+func tableWidth(columns []tableColumn) int {
+	width := 1
+	for _, col := range columns {
+		if col.width > 0 {
+			width += col.width + 1
+		}
+	}
+	return width
+}
+
+// fitColumns drops low priority columns until the table fits the width, then narrows the widest column.
+// Dropped columns get 0 width.
+func fitColumns(columns []tableColumn, width int) []tableColumn {
+	fitted := append([]tableColumn{}, columns...)
+	for tableWidth(fitted) > width {
+		drop := -1
+		for idx, col := range fitted {
+			if col.width > 0 && col.priority > 0 && (drop < 0 || col.priority > fitted[drop].priority) {
+				drop = idx
+			}
+		}
+		if drop < 0 {
+			break
+		}
+		fitted[drop].width = 0
+	}
+	for tableWidth(fitted) > width {
+		widest := 0
+		for idx, col := range fitted {
+			if col.width > fitted[widest].width {
+				widest = idx
+			}
+		}
+		if fitted[widest].width <= 4 {
+			break
+		}
+		fitted[widest].width--
+	}
+	return fitted
+}
+
+// tableBorder draws a border line of the table fit to the width, edges are left, middle and right runes eg. "╭┬╮"
+func tableBorder(columns []tableColumn, width int, edges string) string {
+	runes := []rune(edges)
+	line := string(runes[0])
+	cols := fitColumns(columns, width)
+	last := -1
+	for idx, col := range cols {
+		if col.width > 0 {
+			last = idx
+		}
+	}
+	for idx, col := range cols {
+		if col.width == 0 {
+			continue
+		}
+		line += strings.Repeat("─", col.width)
+		if idx == last {
+			line += string(runes[2])
+		} else {
+			line += string(runes[1])
+		}
+	}
+	return line
+}
+
+// tableCells draws cells of the columns which are already fit, texts of dropped columns are skipped
+func tableCells(columns []tableColumn, texts []string) string {
+	line := "│"
+	for idx, col := range columns {
+		if col.width == 0 || idx >= len(texts) {
+			continue
+		}
+		if col.left {
+			line += fillSpace(texts[idx], col.width) + "│"
+		} else {
+			line += centerText(texts[idx], " ", col.width) + "│"
+		}
+	}
+	return line
+}
+
+// tableRow draws a row of the table fit to the width
+func tableRow(columns []tableColumn, width int, texts ...string) string {
+	return tableCells(fitColumns(columns, width), texts)
+}
+
 func (screen *GameScreen) renderTRTable(requests []loud.TrdReq, width int) ([]string, []string) {
 	tableLines := []string{}
-	tableLines = append(tableLines, screen.regularFont()(fillSpace("╭────────────────────┬───────────────┬───────────────╮", width)))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(trColumns, width, "╭┬╮"), width)))
 	tableLines = append(tableLines, screen.renderTRLine("GOLD price (pylon)", "Amount (gold)", "Total (pylon)", false, false, width))
-	tableLines = append(tableLines, screen.regularFont()(fillSpace("├────────────────────┼───────────────┼───────────────┤", width)))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(trColumns, width, "├┼┤"), width)))
 	numLines := screen.GetSituationBox().H - 5
 	if screen.activeLine >= len(requests) {
 		screen.activeLine = len(requests) - 1
@@ -25,6 +128,7 @@ func (screen *GameScreen) renderTRTable(requests []loud.TrdReq, width int) ([]st
 	if endLine > len(requests) {
 		endLine = len(requests)
 	}
+	screen.setTableRows(len(tableLines), startLine, endLine)
 	for li, request := range requests[startLine:endLine] {
 		tableLines = append(
 			tableLines,
@@ -38,19 +142,22 @@ func (screen *GameScreen) renderTRTable(requests []loud.TrdReq, width int) ([]st
 			),
 		)
 	}
-	tableLines = append(tableLines, screen.regularFont()(fillSpace("╰────────────────────┴───────────────┴───────────────╯", width)))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(trColumns, width, "╰┴╯"), width)))
 	return []string{}, tableLines
 }
 
-func (screen *GameScreen) renderITRTable(title string, theads [2]string, requestsSlice interface{}, width int) ([]string, []string) {
+func (screen *GameScreen) renderITRTable(title string, theads [3]string, requestsSlice interface{}, width int) ([]string, []string) {
 	requests := InterfaceSlice(requestsSlice)
 	infoLines := strings.Split(loud.Localize(title), "\n")
+	if filterDesc := screen.trdReqFilterDesc(screen.scrStatus); len(filterDesc) > 0 {
+		infoLines = append(infoLines, filterDesc)
+	}
 	numHeaderLines := len(infoLines)
 
 	tableLines := []string{}
-	tableLines = append(tableLines, screen.regularFont()(fillSpace("╭────────────────────────────────────┬───────────────╮", width)))
-	tableLines = append(tableLines, screen.renderItemTrdReqTableLine(theads[0], theads[1], false, false, width))
-	tableLines = append(tableLines, screen.regularFont()(fillSpace("├────────────────────────────────────┼───────────────┤", width)))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(itrColumns, width, "╭┬╮"), width)))
+	tableLines = append(tableLines, screen.renderItemTrdReqTableLine(theads[0], theads[1], theads[2], false, false, width))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(itrColumns, width, "├┼┤"), width)))
 	numLines := screen.GetSituationBox().H - 5 - numHeaderLines
 	if screen.activeLine >= len(requests) {
 		screen.activeLine = len(requests) - 1
@@ -64,6 +171,7 @@ func (screen *GameScreen) renderITRTable(title string, theads [2]string, request
 	if endLine > len(requests) {
 		endLine = len(requests)
 	}
+	screen.setTableRows(len(tableLines), startLine, endLine)
 	for li, request := range requests[startLine:endLine] {
 		line := ""
 		switch request.(type) {
@@ -72,6 +180,7 @@ func (screen *GameScreen) renderITRTable(title string, theads [2]string, request
 			line = screen.renderItemTrdReqTableLine(
 				fmt.Sprintf("%s  ", formatItemSpec(itr.TItem)),
 				fmt.Sprintf("%d", itr.Price),
+				lastSoldText(loud.TRDHIST_ITEM, itr.TItem.Name, itr.TItem.Level),
 				startLine+li == activeLine,
 				itr.IsMyTrdReq,
 				width,
@@ -81,6 +190,7 @@ func (screen *GameScreen) renderITRTable(title string, theads [2]string, request
 			line = screen.renderItemTrdReqTableLine(
 				fmt.Sprintf("%s  ", formatItem(itr.TItem)),
 				fmt.Sprintf("%d", itr.Price),
+				lastSoldText(loud.TRDHIST_ITEM, itr.TItem.Name, [2]int{itr.TItem.Level, itr.TItem.Level}),
 				startLine+li == activeLine,
 				itr.IsMyTrdReq,
 				width,
@@ -90,6 +200,7 @@ func (screen *GameScreen) renderITRTable(title string, theads [2]string, request
 			line = screen.renderItemTrdReqTableLine(
 				fmt.Sprintf("%s  ", formatCharacterSpec(itr.TCharacter)),
 				fmt.Sprintf("%d", itr.Price),
+				lastSoldText(loud.TRDHIST_CHAR, itr.TCharacter.Name, itr.TCharacter.Level),
 				startLine+li == activeLine,
 				itr.IsMyTrdReq,
 				width,
@@ -99,6 +210,7 @@ func (screen *GameScreen) renderITRTable(title string, theads [2]string, request
 			line = screen.renderItemTrdReqTableLine(
 				fmt.Sprintf("%s  ", formatCharacter(itr.TCharacter)),
 				fmt.Sprintf("%d", itr.Price),
+				lastSoldText(loud.TRDHIST_CHAR, itr.TCharacter.Name, [2]int{itr.TCharacter.Level, itr.TCharacter.Level}),
 				startLine+li == activeLine,
 				itr.IsMyTrdReq,
 				width,
@@ -106,24 +218,25 @@ func (screen *GameScreen) renderITRTable(title string, theads [2]string, request
 		}
 		tableLines = append(tableLines, line)
 	}
-	tableLines = append(tableLines, screen.regularFont()(fillSpace("╰────────────────────────────────────┴───────────────╯", width)))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(itrColumns, width, "╰┴╯"), width)))
 	return infoLines, tableLines
 }
 
 func (screen *GameScreen) renderITTable(header string, th string, itemSlice interface{}, width int) ([]string, []string) {
 	items := InterfaceSlice(itemSlice)
+	if screen.activeLine >= len(items) {
+		screen.activeLine = len(items) - 1
+	}
 	infoLines := strings.Split(loud.Localize(header), "\n")
+	infoLines = append(infoLines, selectPositionDesc(screen.activeLine, len(items)))
 	numHeaderLines := len(infoLines)
 	numLines := screen.GetSituationBox().H - 5 - numHeaderLines
 	fmtFunc := screen.regularFont()
 
 	tableLines := []string{}
-	tableLines = append(tableLines, fmtFunc(fillSpace("╭────────────────────────────────────────────────────╮", width)))
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(itemColumns, width, "╭┬╮"), width)))
 	tableLines = append(tableLines, screen.renderItemTableLine(th, false, width))
-	tableLines = append(tableLines, fmtFunc(fillSpace("├────────────────────────────────────────────────────┤", width)))
-	if screen.activeLine >= len(items) {
-		screen.activeLine = len(items) - 1
-	}
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(itemColumns, width, "├┼┤"), width)))
 	activeLine := screen.activeLine
 	startLine := activeLine - numLines + 1
 	if startLine < 0 {
@@ -133,20 +246,21 @@ func (screen *GameScreen) renderITTable(header string, th string, itemSlice inte
 	if endLine > len(items) {
 		endLine = len(items)
 	}
+	screen.setTableRows(len(tableLines), startLine, endLine)
 	for li, item := range items[startLine:endLine] {
 		line := ""
 		switch item.(type) {
 		case loud.Item:
 			itemT := item.(loud.Item)
 			line = screen.renderItemTableLine(
-				fmt.Sprintf("%s  ", formatItem(itemT)),
+				fmt.Sprintf("%s%s  ", formatItem(itemT), lockedMark(itemT.ID)),
 				startLine+li == activeLine,
 				width,
 			)
 		case loud.Character:
 			itemT := item.(loud.Character)
 			line = screen.renderItemTableLine(
-				fmt.Sprintf("%s  ", formatCharacter(itemT)),
+				fmt.Sprintf("%s%s  ", formatCharacter(itemT), lockedMark(itemT.ID)),
 				startLine+li == activeLine,
 				width,
 			)
@@ -167,6 +281,218 @@ func (screen *GameScreen) renderITTable(header string, th string, itemSlice inte
 		}
 		tableLines = append(tableLines, line)
 	}
-	tableLines = append(tableLines, fmtFunc(fillSpace("╰────────────────────────────────────────────────────╯", width)))
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(itemColumns, width, "╰┴╯"), width)))
+	return infoLines, tableLines
+}
+
+func (screen *GameScreen) renderOrderBookLine(level loud.OrderBookLevel, maxDepth int, isBid bool, width int) string {
+	cols := fitColumns(orderBookColumns, width)
+	calcText := tableCells(cols[:3], []string{
+		fmt.Sprintf("%.4f", level.Price),
+		fmt.Sprintf("%d", level.Amount),
+		fmt.Sprintf("%d", level.Depth),
+	})
+	onColor := screen.regularFont()
+	if level.HasMyTrdReq {
+		onColor = screen.brownFont()
+	}
+	barWidth := cols[3].width
+	if barWidth == 0 {
+		return onColor(fillSpace(calcText, width))
+	}
+
+	barLen := 0
+	if maxDepth > 0 {
+		barLen = level.Depth * barWidth / maxDepth
+	}
+	if barLen == 0 && level.Depth > 0 {
+		barLen = 1
+	}
+	bar := strings.Repeat("█", barLen) + strings.Repeat(" ", barWidth-barLen)
+
+	barColor := screen.redFont()
+	if isBid {
+		barColor = screen.greenFont()
+	}
+	return onColor(calcText) + barColor(bar) + onColor(fillSpace("│", width-NumberOfSpaces(calcText)-barWidth))
+}
+
+func (screen *GameScreen) renderOrderBook(ob loud.OrderBook, width int) ([]string, []string) {
+	fmtFunc := screen.regularFont()
+	bestBid, bidOk := ob.BestBid()
+	bestAsk, askOk := ob.BestAsk()
+	spread, spreadOk := ob.Spread()
+
+	bidText := "-"
+	if bidOk {
+		bidText = fmt.Sprintf("%.4f", bestBid)
+	}
+	askText := "-"
+	if askOk {
+		askText = fmt.Sprintf("%.4f", bestAsk)
+	}
+	spreadText := "-"
+	if spreadOk {
+		spreadText = fmt.Sprintf("%.4f", spread)
+		if bestAsk > 0 {
+			spreadText += fmt.Sprintf(" (%.2f%%)", spread*100/bestAsk)
+		}
+	}
+	infoLines := []string{
+		loud.Localize("Gold order book"),
+		fmt.Sprintf("%s: %s   %s: %s   %s: %s",
+			loud.Localize("best bid"), bidText,
+			loud.Localize("best ask"), askText,
+			loud.Localize("spread"), spreadText),
+	}
+
+	tableLines := []string{}
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(orderBookColumns, width, "╭┬╮"), width)))
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableRow(orderBookColumns, width,
+		loud.Localize("Price (pylon)"),
+		loud.Localize("Amount (gold)"),
+		loud.Localize("Depth (gold)"),
+		""), width)))
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(orderBookColumns, width, "├┼┤"), width)))
+
+	numLines := screen.GetSituationBox().H - 5 - len(infoLines)
+	numAskLines := numLines / 2
+	numBidLines := numLines - numAskLines
+	if len(ob.Asks) < numAskLines {
+		numBidLines += numAskLines - len(ob.Asks)
+		numAskLines = len(ob.Asks)
+	}
+	if len(ob.Bids) < numBidLines {
+		numBidLines = len(ob.Bids)
+	}
+	maxDepth := ob.MaxDepth()
+
+	// asks are shown from the farthest price down to the best ask
+	for idx := numAskLines - 1; idx >= 0; idx-- {
+		tableLines = append(tableLines, screen.renderOrderBookLine(ob.Asks[idx], maxDepth, false, width))
+	}
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(orderBookColumns, width, "├┼┤"), width)))
+	for idx := 0; idx < numBidLines; idx++ {
+		tableLines = append(tableLines, screen.renderOrderBookLine(ob.Bids[idx], maxDepth, true, width))
+	}
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(orderBookColumns, width, "╰┴╯"), width)))
+	return infoLines, tableLines
+}
+
+func (screen *GameScreen) renderMarketOrderPlan(plan loud.MarketOrderPlan, requestedAmount string, width int) ([]string, []string) {
+	infoLines := []string{}
+	if plan.IsBuy {
+		infoLines = append(infoLines, loud.Sprintf("You are buying %d gold for %d pylons at market", plan.Amount, plan.Total))
+	} else {
+		infoLines = append(infoLines, loud.Sprintf("You are selling %d gold for %d pylons at market", plan.Amount, plan.Total))
+	}
+	infoLines = append(infoLines, fmt.Sprintf("%s: %.4f", loud.Localize("average price"), plan.AvgPrice()))
+	if fmt.Sprintf("%d", plan.Amount) != requestedAmount {
+		infoLines = append(infoLines, loud.Sprintf("only %d of %s gold can be filled by current trade requests", plan.Amount, requestedAmount))
+	}
+
+	tableLines := []string{}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(trColumns, width, "╭┬╮"), width)))
+	tableLines = append(tableLines, screen.renderTRLine("GOLD price (pylon)", "Amount (gold)", "Total (pylon)", false, false, width))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(trColumns, width, "├┼┤"), width)))
+	numLines := screen.GetSituationBox().H - 5 - len(infoLines)
+	for li, request := range plan.Fills {
+		if li >= numLines {
+			break
+		}
+		tableLines = append(
+			tableLines,
+			screen.renderTRLine(
+				fmt.Sprintf("%.4f", request.Price),
+				fmt.Sprintf("%d", request.Amount),
+				fmt.Sprintf("%d", request.Total),
+				false,
+				false,
+				width,
+			),
+		)
+	}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(trColumns, width, "╰┴╯"), width)))
+	return infoLines, tableLines
+}
+
+func (screen *GameScreen) renderOHLCLine(texts [6]string, width int) string {
+	return screen.regularFont()(fillSpace(tableRow(ohlcColumns, width, texts[:]...), width))
+}
+
+func (screen *GameScreen) renderPriceHistory(width int) ([]string, []string) {
+	const sparkWidth = 40
+	const priceHistoryBlockRange = 100
+	fmtFunc := screen.regularFont()
+
+	goldRecords := loud.TradeHistoryByKind(loud.TRDHIST_GOLD)
+	goldPrices := []float64{}
+	for _, record := range goldRecords {
+		goldPrices = append(goldPrices, record.Price)
+	}
+	infoLines := []string{loud.Localize("Gold price history (pylon per gold)")}
+	if len(goldPrices) == 0 {
+		infoLines = append(infoLines, loud.Localize("No completed trades recorded yet"))
+	} else {
+		infoLines = append(infoLines, fmt.Sprintf("%s  %s: %.4f",
+			sparkline(goldPrices, sparkWidth),
+			loud.Localize("last"),
+			goldPrices[len(goldPrices)-1]))
+	}
+
+	summaries := loud.OHLCByDay(goldRecords)
+	periodText := loud.Localize("Day")
+	if screen.priceHistoryByBlock {
+		summaries = loud.OHLCByBlockRange(goldRecords, priceHistoryBlockRange)
+		periodText = loud.Localize("Blocks")
+	}
+
+	tableLines := []string{}
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(ohlcColumns, width, "╭┬╮"), width)))
+	tableLines = append(tableLines, screen.renderOHLCLine([6]string{
+		periodText,
+		loud.Localize("open"),
+		loud.Localize("high"),
+		loud.Localize("low"),
+		loud.Localize("close"),
+		loud.Localize("volume"),
+	}, width))
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(ohlcColumns, width, "├┼┤"), width)))
+	numOHLCLines := 6
+	if len(summaries) > numOHLCLines {
+		summaries = summaries[len(summaries)-numOHLCLines:]
+	}
+	for _, summary := range summaries {
+		tableLines = append(tableLines, screen.renderOHLCLine([6]string{
+			summary.Label,
+			fmt.Sprintf("%.4f", summary.Open),
+			fmt.Sprintf("%.4f", summary.High),
+			fmt.Sprintf("%.4f", summary.Low),
+			fmt.Sprintf("%.4f", summary.Close),
+			fmt.Sprintf("%d", summary.Volume),
+		}, width))
+	}
+	tableLines = append(tableLines, fmtFunc(fillSpace(tableBorder(ohlcColumns, width, "╰┴╯"), width)))
+
+	tableLines = append(tableLines, fmtFunc(fillSpace("", width)))
+	tableLines = append(tableLines, fmtFunc(fillSpace(loud.Localize("Item and character sale prices (pylon)"), width)))
+	numGroupLines := screen.GetSituationBox().H - len(infoLines) - len(tableLines)
+	for idx, group := range loud.ItemTradeHistoryGroups() {
+		if idx >= numGroupLines {
+			break
+		}
+		prices := []float64{}
+		for _, record := range group.Records {
+			prices = append(prices, record.Price)
+		}
+		last := group.Records[len(group.Records)-1]
+		groupName := fmt.Sprintf("%s Lv%s", loud.Localize(group.Name), formatIntRange(group.Level))
+		tableLines = append(tableLines, fmtFunc(fillSpace(fmt.Sprintf("%s %s %s: %d (%s)",
+			truncateRight(groupName, 28),
+			truncateRight(sparkline(prices, 20), 20),
+			loud.Localize("last"),
+			last.Total,
+			loud.Sprintf("%d trades", len(group.Records))), width)))
+	}
 	return infoLines, tableLines
 }
@@ -0,0 +1,331 @@
+package screen
+
+import (
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+	"github.com/Pylons-tech/LOUD/log"
+)
+
+// color depths of the terminal
+const (
+	COLOR_DEPTH_NONE = 0
+	COLOR_DEPTH_16   = 16
+	COLOR_DEPTH_256  = 256
+)
+
+const (
+	THEME_DARK          = "dark"
+	THEME_LIGHT         = "light"
+	THEME_HIGH_CONTRAST = "high-contrast"
+	THEME_MONOCHROME    = "monochrome"
+)
+
+// Theme is ansi color spec of each font, eg. "196+b:232" for bold red on dark grey
+type Theme struct {
+	Name  string
+	Fonts map[FontType]string
+}
+
+// themeFontNames is font names used on app.themes section of config file
+var themeFontNames = map[string]FontType{
+	"regular":           REGULAR,
+	"grey":              GREY,
+	"brown":             BROWN,
+	"red":               RED,
+	"red_bold":          RED_BOLD,
+	"yellow":            YELLOW,
+	"green":             GREEN,
+	"blink_blue_bold":   BLINK_BLUE_BOLD,
+	"input_active_font": INPUT_ACTIVE_FONT,
+	"brown_bold":        BROWN_BOLD,
+	"blue_bold":         BLUE_BOLD,
+	"hover":             HOVER,
+	"menu_regular":      MENU_REGULAR,
+	"menu_active":       MENU_ACTIVE,
+	"border":            BORDER,
+	"fill":              FILL,
+}
+
+var builtinThemes = []Theme{
+	{THEME_DARK, map[FontType]string{
+		REGULAR:           "255:232",
+		GREY:              "181:232",
+		BROWN:             "181:232",
+		RED:               "196:232",
+		RED_BOLD:          "196+bh:232",
+		YELLOW:            "208:232",
+		GREEN:             "76:232",
+		BLINK_BLUE_BOLD:   "117+B:232",
+		INPUT_ACTIVE_FONT: "0+b:231",
+		BROWN_BOLD:        "181+bh:232",
+		BLUE_BOLD:         "117+bh:232",
+		HOVER:             "255:238",
+		MENU_REGULAR:      "255+bh:0",
+		MENU_ACTIVE:       "255+bh:202",
+		BORDER:            "255:232",
+		FILL:              "0:232",
+	}},
+	{THEME_LIGHT, map[FontType]string{
+		REGULAR:           "235:255",
+		GREY:              "244:255",
+		BROWN:             "94:255",
+		RED:               "160:255",
+		RED_BOLD:          "160+b:255",
+		YELLOW:            "166:255",
+		GREEN:             "28:255",
+		BLINK_BLUE_BOLD:   "25+B:255",
+		INPUT_ACTIVE_FONT: "235+b:253",
+		BROWN_BOLD:        "94+b:255",
+		BLUE_BOLD:         "25+b:255",
+		HOVER:             "235:252",
+		MENU_REGULAR:      "235+b:250",
+		MENU_ACTIVE:       "255+b:202",
+		BORDER:            "240:255",
+		FILL:              "235:255",
+	}},
+	{THEME_HIGH_CONTRAST, map[FontType]string{
+		REGULAR:           "231:16",
+		GREY:              "252:16",
+		BROWN:             "229:16",
+		RED:               "196+b:16",
+		RED_BOLD:          "196+b:16",
+		YELLOW:            "226:16",
+		GREEN:             "46:16",
+		BLINK_BLUE_BOLD:   "51+bB:16",
+		INPUT_ACTIVE_FONT: "16+b:231",
+		BROWN_BOLD:        "229+b:16",
+		BLUE_BOLD:         "51+b:16",
+		HOVER:             "16:231",
+		MENU_REGULAR:      "231+b:16",
+		MENU_ACTIVE:       "16+b:226",
+		BORDER:            "231:16",
+		FILL:              "231:16",
+	}},
+	{THEME_MONOCHROME, map[FontType]string{
+		REGULAR:           "default:default",
+		GREY:              "default:default",
+		BROWN:             "default:default",
+		RED:               "default+b:default",
+		RED_BOLD:          "default+b:default",
+		YELLOW:            "default:default",
+		GREEN:             "default:default",
+		BLINK_BLUE_BOLD:   "default+bB:default",
+		INPUT_ACTIVE_FONT: "default+i:default",
+		BROWN_BOLD:        "default+b:default",
+		BLUE_BOLD:         "default+b:default",
+		HOVER:             "default+i:default",
+		MENU_REGULAR:      "default+b:default",
+		MENU_ACTIVE:       "default+bi:default",
+		BORDER:            "default:default",
+		FILL:              "default:default",
+	}},
+}
+
+// themeLabels is the names of built-in themes shown on settings
+var themeLabels = map[string]string{
+	THEME_DARK:          "Dark",
+	THEME_LIGHT:         "Light",
+	THEME_HIGH_CONTRAST: "High contrast",
+	THEME_MONOCHROME:    "Monochrome",
+}
+
+func builtinTheme(name string) (Theme, bool) {
+	for _, theme := range builtinThemes {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+	return builtinThemes[0], false
+}
+
+// LoadThemes returns built-in themes overridden by config and the themes only defined on config.
+// A theme on config starts from the built-in theme of the same name, or dark when there's none.
+func LoadThemes(config map[string]map[string]string) []Theme {
+	names := []string{}
+	for _, theme := range builtinThemes {
+		names = append(names, theme.Name)
+	}
+	customNames := []string{}
+	for name := range config {
+		if _, ok := builtinTheme(name); !ok {
+			customNames = append(customNames, name)
+		}
+	}
+	sort.Strings(customNames)
+	names = append(names, customNames...)
+
+	themes := []Theme{}
+	for _, name := range names {
+		base, _ := builtinTheme(name)
+		theme := Theme{Name: name, Fonts: map[FontType]string{}}
+		for ft, spec := range base.Fonts {
+			theme.Fonts[ft] = spec
+		}
+		for fontName, spec := range config[name] {
+			ft, ok := themeFontNames[fontName]
+			if !ok {
+				log.Println("theme: unknown font", fontName, "on", name)
+				continue
+			}
+			theme.Fonts[ft] = spec
+		}
+		themes = append(themes, theme)
+	}
+	return themes
+}
+
+// Label is the theme name shown on settings
+func (theme Theme) Label() string {
+	if label, ok := themeLabels[theme.Name]; ok {
+		return loud.Localize(label)
+	}
+	return theme.Name
+}
+
+// DetectColorDepth reads color support of the terminal from environment, NO_COLOR turns colors off
+func DetectColorDepth() int {
+	if os.Getenv("NO_COLOR") != "" {
+		return COLOR_DEPTH_NONE
+	}
+	term := os.Getenv("TERM")
+	switch {
+	case term == "dumb":
+		return COLOR_DEPTH_NONE
+	case term == "",
+		os.Getenv("COLORTERM") != "",
+		strings.Contains(term, "256color"),
+		strings.Contains(term, "truecolor"),
+		strings.Contains(term, "24bit"):
+		return COLOR_DEPTH_256
+	}
+	return COLOR_DEPTH_16
+}
+
+// ansi16Names is the 16 basic colors, high intensity ones are used with "h" style
+var ansi16Names = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// ansi16RGB is xterm's default palette of the 16 basic colors
+var ansi16RGB = [16][3]float64{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+func xterm256RGB(n int) [3]float64 {
+	switch {
+	case n < 16:
+		return ansi16RGB[n]
+	case n < 232:
+		levels := []float64{0, 95, 135, 175, 215, 255}
+		n -= 16
+		return [3]float64{levels[n/36], levels[n/6%6], levels[n%6]}
+	}
+	gray := float64(8 + 10*(n-232))
+	return [3]float64{gray, gray, gray}
+}
+
+// nearestANSI16 returns the basic color nearest to a 256 color
+func nearestANSI16(n int) int {
+	rgb := xterm256RGB(n)
+	nearest, minDist := 0, math.MaxFloat64
+	for idx, c := range ansi16RGB {
+		dist := math.Pow(rgb[0]-c[0], 2) + math.Pow(rgb[1]-c[1], 2) + math.Pow(rgb[2]-c[2], 2)
+		if dist < minDist {
+			nearest, minDist = idx, dist
+		}
+	}
+	return nearest
+}
+
+// downsampleColor converts "color+style" part of a spec to a basic color
+func downsampleColor(part string) string {
+	fields := strings.SplitN(part, "+", 2)
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 0 || n > 255 {
+		return part
+	}
+	style := ""
+	if len(fields) > 1 {
+		style = strings.Replace(fields[1], "h", "", -1)
+	}
+	basic := nearestANSI16(n)
+	if basic >= 8 {
+		style += "h"
+	}
+	if style == "" {
+		return ansi16Names[basic%8]
+	}
+	return ansi16Names[basic%8] + "+" + style
+}
+
+// downsampleSpec converts 256 colors of a spec to the 16 basic colors
+func downsampleSpec(spec string) string {
+	parts := strings.Split(spec, ":")
+	for idx, part := range parts {
+		parts[idx] = downsampleColor(part)
+	}
+	return strings.Join(parts, ":")
+}
+
+// themeSpec returns color spec of a font on active theme and terminal color depth
+func (screen *GameScreen) themeSpec(ft FontType) string {
+	theme := screen.theme
+	if screen.colorDepth == COLOR_DEPTH_NONE {
+		theme, _ = builtinTheme(THEME_MONOCHROME)
+	}
+	spec, ok := theme.Fonts[ft]
+	if !ok {
+		spec = theme.Fonts[REGULAR]
+	}
+	if screen.colorDepth == COLOR_DEPTH_16 {
+		return downsampleSpec(spec)
+	}
+	return spec
+}
+
+// SETTINGS_THEME_NUMBER is the number key of the first theme on settings, 1 and 2 are languages
+const SETTINGS_THEME_NUMBER = 3
+
+// themeCmds lists themes on settings, as many as number keys left after languages
+func (screen *GameScreen) themeCmds() TextLines {
+	cmds := TextLines{}.append(TextLine{content: loud.Localize("Theme:")})
+	for idx, theme := range screen.themes {
+		number := SETTINGS_THEME_NUMBER + idx
+		if number > 9 {
+			break
+		}
+		label := theme.Label()
+		if theme.Name == screen.theme.Name {
+			label += " ✓"
+		}
+		cmds = cmds.append(screen.keys.NumberedLine(number, label))
+	}
+	if screen.colorDepth == COLOR_DEPTH_NONE {
+		cmds = cmds.append(TextLine{content: loud.Localize("Colors are off for NO_COLOR or the terminal")})
+	}
+	return cmds
+}
+
+func findTheme(themes []Theme, name string) (Theme, bool) {
+	for _, theme := range themes {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+	return Theme{}, false
+}
+
+// SetTheme switches the active theme and redraws the whole screen
+func (screen *GameScreen) SetTheme(name string) bool {
+	theme, ok := findTheme(screen.themes, name)
+	if !ok {
+		return false
+	}
+	screen.theme = theme
+	screen.FreshRender()
+	return true
+}
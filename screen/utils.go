@@ -5,10 +5,7 @@ import (
 	"io"
 	"reflect"
 
-	"os"
-
 	"github.com/ahmetb/go-cursor"
-	"github.com/mgutz/ansi"
 
 	loud "github.com/Pylons-tech/LOUD/data"
 )
@@ -16,30 +13,13 @@ import (
 type TextLine struct {
 	content string
 	font    FontType
+	action  string // key action run by clicking the line, empty when it's not clickable
 }
 
 type TextLines []TextLine
 
-func (tl TextLines) append(elems ...string) TextLines {
-	elemsT := []TextLine{}
-	for _, el := range elems {
-		elemsT = append(elemsT, TextLine{
-			content: el,
-			font:    "",
-		})
-	}
-	return append(tl, elemsT...)
-}
-
-func (tl TextLines) appendT(elems ...string) TextLines {
-	elemsT := []TextLine{}
-	for _, el := range elems {
-		elemsT = append(elemsT, TextLine{
-			content: loud.Localize(el),
-			font:    "",
-		})
-	}
-	return append(tl, elemsT...)
+func (tl TextLines) append(elems ...TextLine) TextLines {
+	return append(tl, elems...)
 }
 
 func truncateRight(message string, width int) string {
@@ -109,24 +89,22 @@ func fillSpace(message string, width int) string {
 	return message + rightString
 }
 
-func drawVerticalLine(x, y, height int) {
-	color := ansi.ColorCode(fmt.Sprintf("255:%v", bgcolor))
+func (screen *GameScreen) drawVerticalLine(color string, x, y, height int) {
 	for i := 1; i < height; i++ {
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s│", cursor.MoveTo(y+i, x), color))
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s│", cursor.MoveTo(y+i, x), color))
 	}
 
-	io.WriteString(os.Stdout, fmt.Sprintf("%s%s┬", cursor.MoveTo(y, x), color))
-	io.WriteString(os.Stdout, fmt.Sprintf("%s%s┴", cursor.MoveTo(y+height, x), color))
+	io.WriteString(screen.frame, fmt.Sprintf("%s%s┬", cursor.MoveTo(y, x), color))
+	io.WriteString(screen.frame, fmt.Sprintf("%s%s┴", cursor.MoveTo(y+height, x), color))
 }
 
-func drawHorizontalLine(x, y, width int) {
-	color := ansi.ColorCode(fmt.Sprintf("255:%v", bgcolor))
+func (screen *GameScreen) drawHorizontalLine(color string, x, y, width int) {
 	for i := 1; i < width; i++ {
-		io.WriteString(os.Stdout, fmt.Sprintf("%s%s─", cursor.MoveTo(y, x+i), color))
+		io.WriteString(screen.frame, fmt.Sprintf("%s%s─", cursor.MoveTo(y, x+i), color))
 	}
 
-	io.WriteString(os.Stdout, fmt.Sprintf("%s%s├", cursor.MoveTo(y, x), color))
-	io.WriteString(os.Stdout, fmt.Sprintf("%s%s┤", cursor.MoveTo(y, x+width), color))
+	io.WriteString(screen.frame, fmt.Sprintf("%s%s├", cursor.MoveTo(y, x), color))
+	io.WriteString(screen.frame, fmt.Sprintf("%s%s┤", cursor.MoveTo(y, x+width), color))
 }
 
 func formatItem(item loud.Item) string {
@@ -137,6 +115,9 @@ func formatItem(item loud.Item) string {
 	if item.Attack > 0 {
 		itemStr += fmt.Sprintf(" attack=%d", item.Attack)
 	}
+	if item.Defense > 0 {
+		itemStr += fmt.Sprintf(" defense=%d", item.Defense)
+	}
 	return itemStr
 }
 
@@ -268,7 +249,7 @@ func (screen *GameScreen) renderTRLine(text1 string, text2 string, text3 string,
 	text2 = loud.Localize(text2)
 	text3 = loud.Localize(text3)
 
-	calcText := "│" + centerText(text1, " ", 20) + "│" + centerText(text2, " ", 15) + "│" + centerText(text3, " ", 15) + "│"
+	calcText := tableRow(trColumns, width, text1, text2, text3)
 	onColor := screen.regularFont()
 	if isActiveLine && isDisabledLine {
 		onColor = screen.brownBoldFont()
@@ -281,7 +262,7 @@ func (screen *GameScreen) renderTRLine(text1 string, text2 string, text3 string,
 }
 
 func (screen *GameScreen) renderItemTableLine(text1 string, isActiveLine bool, width int) string {
-	calcText := "│" + centerText(loud.Localize(text1), " ", 52) + "│"
+	calcText := tableRow(itemColumns, width, loud.Localize(text1))
 	onColor := screen.regularFont()
 	if isActiveLine {
 		onColor = screen.blueBoldFont()
@@ -289,10 +270,11 @@ func (screen *GameScreen) renderItemTableLine(text1 string, isActiveLine bool, w
 	return onColor(fillSpace(calcText, width))
 }
 
-func (screen *GameScreen) renderItemTrdReqTableLine(text1 string, text2 string, isActiveLine bool, isDisabledLine bool, width int) string {
+func (screen *GameScreen) renderItemTrdReqTableLine(text1 string, text2 string, text3 string, isActiveLine bool, isDisabledLine bool, width int) string {
 	text1 = loud.Localize(text1)
 	text2 = loud.Localize(text2)
-	calcText := "│" + centerText(text1, " ", 36) + "│" + centerText(text2, " ", 15) + "│"
+	text3 = loud.Localize(text3)
+	calcText := tableRow(itrColumns, width, text1, text2, text3)
 	onColor := screen.regularFont()
 	if isActiveLine && isDisabledLine {
 		onColor = screen.brownBoldFont()
@@ -304,6 +286,41 @@ func (screen *GameScreen) renderItemTrdReqTableLine(text1 string, text2 string,
 	return onColor(fillSpace(calcText, width))
 }
 
+func sparkline(values []float64, width int) string {
+	ticks := []rune("▁▂▃▄▅▆▇█")
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, v := range values {
+		if v < low {
+			low = v
+		}
+		if v > high {
+			high = v
+		}
+	}
+	line := []rune{}
+	for _, v := range values {
+		idx := len(ticks) / 2
+		if high > low {
+			idx = int((v - low) / (high - low) * float64(len(ticks)-1))
+		}
+		line = append(line, ticks[idx])
+	}
+	return string(line)
+}
+
+func lastSoldText(kind string, name string, level [2]int) string {
+	if price, ok := loud.LastSoldPrice(kind, name, level); ok {
+		return fmt.Sprintf("%d", price)
+	}
+	return "-"
+}
+
 func min(a, b uint64) uint64 {
 	if a < b {
 		return a
@@ -0,0 +1,211 @@
+package screen
+
+import (
+	"fmt"
+
+	loud "github.com/Pylons-tech/LOUD/data"
+)
+
+// watchAlertStatus is the trade request table which shows trade requests of the market
+var watchAlertStatus = map[string]ScreenStatus{
+	loud.MYORD_BUY_GOLD:  SHW_LOUD_BUY_TRDREQS,
+	loud.MYORD_SELL_GOLD: SHW_LOUD_SELL_TRDREQS,
+	loud.MYORD_BUY_ITEM:  SHW_BUYITM_TRDREQS,
+	loud.MYORD_SELL_ITEM: SHW_SELLITM_TRDREQS,
+	loud.MYORD_BUY_CHAR:  SHW_BUYCHR_TRDREQS,
+	loud.MYORD_SELL_CHAR: SHW_SELLCHR_TRDREQS,
+}
+
+func watchAlertPrice(alert loud.WatchAlert) string {
+	if alert.Rule.Kind == loud.TRDHIST_GOLD {
+		return fmt.Sprintf("%.4f", alert.Price)
+	}
+	return fmt.Sprintf("%.0f", alert.Price)
+}
+
+func (screen *GameScreen) watchAlertText(alert loud.WatchAlert) string {
+	label := alert.Label
+	if alert.Rule.Kind == loud.TRDHIST_GOLD {
+		label = screen.goldIcon() + label
+	}
+	return loud.Sprintf("watch alert: %s %s at %s, press %s to view", loud.Localize(alert.Market), label, watchAlertPrice(alert), screen.keys.Label(ACT_GO_WATCH_ALERT))
+}
+
+// pushWatchAlertToasts shows notifications for trade requests newly matching watch rules
+func (screen *GameScreen) pushWatchAlertToasts() {
+	if len(loud.LastWatchAlerts) == 0 {
+		return
+	}
+	for _, alert := range loud.LastWatchAlerts {
+		screen.toasts = append(screen.toasts, screen.watchAlertText(alert))
+	}
+	screen.lastWatchAlert = loud.LastWatchAlerts[len(loud.LastWatchAlerts)-1]
+	screen.hasWatchAlert = true
+	if screen.user.GetWatchBell() {
+		screen.bell = true
+	}
+}
+
+// watchTableIDs returns trade request IDs of the table in the order rows are shown
+func (screen *GameScreen) watchTableIDs(status ScreenStatus) []string {
+	ids := []string{}
+	switch status {
+	case SHW_LOUD_BUY_TRDREQS:
+		for _, request := range loud.BuyTrdReqs {
+			ids = append(ids, request.ID)
+		}
+	case SHW_LOUD_SELL_TRDREQS:
+		for _, request := range loud.SellTrdReqs {
+			ids = append(ids, request.ID)
+		}
+	case SHW_BUYITM_TRDREQS:
+		for _, request := range screen.filteredItemBuyTrdReqs() {
+			ids = append(ids, request.ID)
+		}
+	case SHW_SELLITM_TRDREQS:
+		for _, request := range screen.filteredItemSellTrdReqs() {
+			ids = append(ids, request.ID)
+		}
+	case SHW_BUYCHR_TRDREQS:
+		for _, request := range screen.filteredCharacterBuyTrdReqs() {
+			ids = append(ids, request.ID)
+		}
+	case SHW_SELLCHR_TRDREQS:
+		for _, request := range screen.filteredCharacterSellTrdReqs() {
+			ids = append(ids, request.ID)
+		}
+	}
+	return ids
+}
+
+func indexOfID(ids []string, id string) int {
+	for idx, v := range ids {
+		if v == id {
+			return idx
+		}
+	}
+	return -1
+}
+
+// jumpToWatchAlert opens trade request table of the alert with matching row selected.
+// When the table filter hides the row, the filter is bypassed until the table is left.
+func (screen *GameScreen) jumpToWatchAlert(alert loud.WatchAlert) bool {
+	status, ok := watchAlertStatus[alert.Market]
+	if !ok {
+		return false
+	}
+	screen.user.SetLocation(loud.PYLCNTRL)
+	screen.SetScreenStatus(status)
+	screen.unfilteredTable = ""
+	activeLine := indexOfID(screen.watchTableIDs(status), alert.TrdReqID)
+	if activeLine < 0 && screen.IsFilterableTable(status) {
+		screen.unfilteredTable = status
+		activeLine = indexOfID(screen.watchTableIDs(status), alert.TrdReqID)
+	}
+	if activeLine < 0 {
+		activeLine = 0
+	}
+	screen.activeLine = activeLine
+	screen.Render()
+	return true
+}
+
+func (screen *GameScreen) jumpToActiveWatchRule() bool {
+	rules := screen.user.GetWatchRules()
+	if len(rules) <= screen.activeLine || screen.activeLine < 0 {
+		return false
+	}
+	alerts := loud.WatchAlertsForRule(rules[screen.activeLine])
+	if len(alerts) == 0 {
+		screen.actionText = loud.Localize("no trade request matches this rule")
+		screen.Render()
+		return true
+	}
+	return screen.jumpToWatchAlert(alerts[0])
+}
+
+func (screen *GameScreen) addWatchRule(query string) {
+	rule, err := loud.ParseWatchRule(query)
+	if err != nil {
+		screen.actionText = loud.Localize(err.Error())
+		screen.Render()
+		return
+	}
+	screen.user.SetWatchRules(append(screen.user.GetWatchRules(), rule))
+	screen.SaveGame()
+	loud.UpdateWatchAlerts(screen.user)
+	screen.pushWatchAlertToasts()
+	screen.inputText = ""
+	screen.SetScreenStatusAndRefresh(SHW_WATCHLIST)
+}
+
+func (screen *GameScreen) removeActiveWatchRule() bool {
+	rules := screen.user.GetWatchRules()
+	if len(rules) <= screen.activeLine || screen.activeLine < 0 {
+		return false
+	}
+	nRules := append([]loud.WatchRule{}, rules[:screen.activeLine]...)
+	nRules = append(nRules, rules[screen.activeLine+1:]...)
+	screen.user.SetWatchRules(nRules)
+	screen.SaveGame()
+	loud.UpdateWatchAlerts(screen.user)
+	screen.Render()
+	return true
+}
+
+func (screen *GameScreen) toggleWatchBell() {
+	screen.user.SetWatchBell(!screen.user.GetWatchBell())
+	screen.SaveGame()
+	screen.Render()
+}
+
+var watchRuleColumns = []tableColumn{{56, 0, true}, {10, 1, false}}
+
+func (screen *GameScreen) renderWatchRuleLine(text1 string, text2 string, isActiveLine bool, width int) string {
+	calcText := tableRow(watchRuleColumns, width, text1, text2)
+	onColor := screen.regularFont()
+	if isActiveLine {
+		onColor = screen.blueBoldFont()
+	}
+	return onColor(fillSpace(calcText, width))
+}
+
+func (screen *GameScreen) renderWatchlist(width int) ([]string, []string) {
+	rules := screen.user.GetWatchRules()
+	bell := loud.Localize("off")
+	if screen.user.GetWatchBell() {
+		bell = loud.Localize("on")
+	}
+	infoLines := []string{
+		loud.Sprintf("Watchlist: %d rules, %d matches, bell %s", len(rules), len(loud.WatchAlerts), bell),
+		loud.Localize("watch rule example"),
+	}
+
+	tableLines := []string{}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(watchRuleColumns, width, "╭┬╮"), width)))
+	tableLines = append(tableLines, screen.renderWatchRuleLine(loud.Localize("Rule"), loud.Localize("Matches"), false, width))
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(watchRuleColumns, width, "├┼┤"), width)))
+	if screen.activeLine >= len(rules) {
+		screen.activeLine = len(rules) - 1
+	}
+	activeLine := screen.activeLine
+	numLines := screen.GetSituationBox().H - 5 - len(infoLines)
+	startLine := activeLine - numLines + 1
+	if startLine < 0 {
+		startLine = 0
+	}
+	endLine := startLine + numLines
+	if endLine > len(rules) {
+		endLine = len(rules)
+	}
+	screen.setTableRows(len(tableLines), startLine, endLine)
+	for li, rule := range rules[startLine:endLine] {
+		tableLines = append(tableLines, screen.renderWatchRuleLine(
+			rule.Query,
+			fmt.Sprintf("%d", len(loud.WatchAlertsForRule(rule))),
+			startLine+li == activeLine,
+			width))
+	}
+	tableLines = append(tableLines, screen.regularFont()(fillSpace(tableBorder(watchRuleColumns, width, "╰┴╯"), width)))
+	return infoLines, tableLines
+}
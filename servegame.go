@@ -47,7 +47,6 @@ func SetupScreenAndEvents(world data.World, logFile *os.File) {
 
 	log.Println("setting up screen and events")
 
-	tick := time.Tick(300 * time.Millisecond)
 	regRefreshTick := time.Tick(5 * time.Second)
 
 	if data.AutomateInput {
@@ -61,19 +60,14 @@ func SetupScreenAndEvents(world data.World, logFile *os.File) {
 				case screen.RSLT_CREATE_COOKBOOK:
 					if screenInstance.GetTxFailReason() != "" {
 						data.SomethingWentWrongMsg = "create cookbook failed, " + screenInstance.GetTxFailReason()
+						screenInstance.Render()
 						break automateloop
 					}
-					screenInstance.HandleInputKey(termbox.Event{
-						Ch: 122, // "z" 122 Switch user
-					})
+					screenInstance.HandleInputKey(screenInstance.ActionEvent(screen.ACT_SWITCH_USER))
 				case screen.RSLT_GET_PYLONS:
-					screenInstance.HandleInputKey(termbox.Event{
-						Ch: 106, // "j" 106 Create cookbook
-					})
+					screenInstance.HandleInputKey(screenInstance.ActionEvent(screen.ACT_CREATE_COOKBOOK))
 				case screen.RSLT_SWITCH_USER:
-					screenInstance.HandleInputKey(termbox.Event{
-						Ch: 121, // "y" 121 get initial pylons
-					})
+					screenInstance.HandleInputKey(screenInstance.ActionEvent(screen.ACT_GET_PYLONS))
 					data.AutomateRunCnt += 1
 					log.Printf("Running %dth automation task", data.AutomateRunCnt)
 				}
@@ -90,8 +84,9 @@ func SetupScreenAndEvents(world data.World, logFile *os.File) {
 		panic(err)
 	}
 	defer termbox.Close()
-	termbox.SetInputMode(termbox.InputEsc)
+	termbox.SetInputMode(termbox.InputEsc | termbox.InputMouse)
 
+	// the screen renders on state change, eg. key input, sync and block height
 	screenInstance.Render()
 
 	go func() {
@@ -102,17 +97,24 @@ func SetupScreenAndEvents(world data.World, logFile *os.File) {
 			case <-terminalCloseSignal:
 				screenInstance.Reset()
 				os.Exit(0)
-			case <-tick:
-				screenInstance.Render()
 			}
 		}
 	}()
 eventloop:
 	for {
-		switch ev := termbox.PollEvent(); ev.Type {
+		ev := termbox.PollEvent()
+		if ev.Type == termbox.EventMouse {
+			// clicks and wheel are handled as the key of what's under the mouse
+			keyEv, ok := screenInstance.HandleMouseEvent(ev)
+			if !ok {
+				continue
+			}
+			ev = keyEv
+		}
+		switch ev.Type {
 		case termbox.EventKey:
-			switch ev.Key {
-			case termbox.KeyEnter:
+			switch screenInstance.KeyAction(ev) {
+			case screen.ACT_SELECT:
 				if screenInstance.IsEndGameConfirmScreen() {
 					screenInstance.SaveGame()
 					screenInstance.Reset()
@@ -137,6 +139,7 @@ eventloop:
 // ServeGame runs the main game loop.
 func ServeGame(logFile *os.File) {
 	rand.Seed(time.Now().Unix())
+	data.LoadConfig()
 
 	world := data.LoadWorldFromDB("./world.db")
 	defer world.Close()